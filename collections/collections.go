@@ -0,0 +1,169 @@
+// Package collections provides generic helpers for working with slices and maps,
+// as used throughout the `structs` and `validators` packages.
+//
+// Functions receiving a callback pass it the position of each element along with the element itself,
+// so that the position is always available without having to track it separately.
+package collections
+
+// Check if the element is contained within the given collection.
+//
+// Example:
+//
+//	Contains([]string{"hello", "world", "!"}, "world") // -> true
+func Contains[T comparable](collection []T, element T) bool {
+	for _, item := range collection {
+		if item == element {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Applies a `transformer` function to every element in a list.
+//
+// Usage:
+//
+//	Map([]int{3, 4}, func(index, n int) int { return n * n }) // -> [9, 16]
+func Map[A any, B any](collection []A, transformFunc func(int, A) B) []B {
+	result := make([]B, len(collection))
+
+	for index, item := range collection {
+		result[index] = transformFunc(index, item)
+	}
+
+	return result
+}
+
+// Filters a collection and returns only the elements
+// that match the provided `inclusionTest`.
+//
+// Example:
+//
+// Filter and return all even numbers:
+//
+//	Filter([]int{16, 9, 25}, func(i, n int) bool { return n%2 == 0 }) // -> [16]
+func Filter[T any](collection []T, inclusionTest func(int, T) bool) []T {
+	result := make([]T, 0)
+
+	for index, item := range collection {
+		if inclusionTest(index, item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Combines the elements of a collection into a single value, starting from `initial`.
+//
+// Usage:
+//
+//	Reduce([]int{1, 2, 3}, 0, func(sum, index, n int) int { return sum + n }) // -> 6
+func Reduce[T any, R any](collection []T, initial R, reduceFunc func(R, int, T) R) R {
+	result := initial
+
+	for index, item := range collection {
+		result = reduceFunc(result, index, item)
+	}
+
+	return result
+}
+
+// Groups the elements of a collection by the key returned by `keyFunc`.
+// Elements keep their relative order within each group.
+//
+// Usage:
+//
+//	GroupBy([]string{"ant", "bee", "asp"}, func(index int, s string) byte { return s[0] })
+//	// -> {'a': ["ant", "asp"], 'b': ["bee"]}
+func GroupBy[T any, K comparable](collection []T, keyFunc func(int, T) K) map[K][]T {
+	result := map[K][]T{}
+
+	for index, item := range collection {
+		key := keyFunc(index, item)
+		result[key] = append(result[key], item)
+	}
+
+	return result
+}
+
+// Returns the keys of a map. Since maps are unordered, so are the returned keys.
+//
+// Usage:
+//
+//	Keys(map[string]int{"a": 1, "b": 2}) // -> ["a", "b"] or ["b", "a"]
+func Keys[K comparable, V any](m map[K]V) []K {
+	result := make([]K, 0, len(m))
+
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
+
+// Returns a new map holding the entries of all the given maps. Entries of later maps take precedence over earlier ones.
+//
+// Usage:
+//
+//	MergeMaps(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3}) // -> {"a": 1, "b": 3}
+func MergeMaps[K comparable, V any](maps ...map[K]V) map[K]V {
+	size := 0
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	result := make(map[K]V, size)
+	for _, m := range maps {
+		for key, value := range m {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// Returns the elements of a collection without duplicates, in the order they first appear.
+//
+// Usage:
+//
+//	Uniq([]int{3, 1, 3, 2, 1}) // -> [3, 1, 2]
+func Uniq[T comparable](collection []T) []T {
+	result := make([]T, 0, len(collection))
+	seen := make(map[T]bool, len(collection))
+
+	for _, item := range collection {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Splits a collection into chunks of the given size. The last chunk holds the remaining elements.
+// Chunks share their backing array with the collection. No chunks are returned if the size is not positive.
+//
+// Usage:
+//
+//	Chunk([]int{1, 2, 3, 4, 5}, 2) // -> [[1, 2], [3, 4], [5]]
+func Chunk[T any](collection []T, size int) [][]T {
+	if size <= 0 {
+		return [][]T{}
+	}
+
+	result := make([][]T, 0, (len(collection)+size-1)/size)
+
+	for start := 0; start < len(collection); start += size {
+		end := start + size
+		if end > len(collection) {
+			end = len(collection)
+		}
+
+		result = append(result, collection[start:end:end])
+	}
+
+	return result
+}
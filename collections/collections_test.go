@@ -0,0 +1,156 @@
+package collections
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func Test_Contains(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection []string
+		element    string
+		want       bool
+	}{
+		{name: "contained", collection: []string{"something", "else", "any"}, element: "any", want: true},
+		{name: "missing", collection: []string{"something", "else", "any"}, element: "thing", want: false},
+		{name: "empty", collection: nil, element: "any", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contains(tt.collection, tt.element); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Map(t *testing.T) {
+	got := Map([]int{3, 5, 7}, func(i int, n int) string { return strconv.Itoa(i) + ":" + strconv.Itoa(n*n) })
+
+	if want := []string{"0:9", "1:25", "2:49"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %v, want %v", got, want)
+	}
+}
+
+func Test_Filter(t *testing.T) {
+	got := Filter([]int{16, 9, 25, 4}, func(i int, n int) bool { return n%2 == 0 })
+
+	if want := []int{16, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+
+	if got := Filter([]int{}, func(int, int) bool { return true }); got == nil || len(got) != 0 {
+		t.Errorf("Filter() = %#v, want an empty list", got)
+	}
+}
+
+func Test_Reduce(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection []int
+		want       int
+	}{
+		{name: "sum", collection: []int{1, 2, 3}, want: 6},
+		{name: "empty", collection: []int{}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Reduce(tt.collection, 0, func(sum int, i int, n int) int { return sum + n }); got != tt.want {
+				t.Errorf("Reduce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	joined := Reduce([]string{"a", "b"}, "", func(s string, i int, item string) string { return s + strconv.Itoa(i) + item })
+	if joined != "0a1b" {
+		t.Errorf("Reduce() = %v, want %v", joined, "0a1b")
+	}
+}
+
+func Test_GroupBy(t *testing.T) {
+	got := GroupBy([]string{"ant", "bee", "asp", "cat"}, func(i int, s string) byte { return s[0] })
+	want := map[byte][]string{'a': {"ant", "asp"}, 'b': {"bee"}, 'c': {"cat"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy() = %v, want %v", got, want)
+	}
+}
+
+func Test_Keys(t *testing.T) {
+	got := Keys(map[string]int{"b": 2, "a": 1, "c": 3})
+	sort.Strings(got)
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
+
+func Test_MergeMaps(t *testing.T) {
+	a, b := map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3}
+
+	if got, want := MergeMaps(a, b), map[string]int{"a": 1, "b": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeMaps() = %v, want %v", got, want)
+	}
+
+	// The given maps are not modified
+	if want := map[string]int{"a": 1, "b": 2}; !reflect.DeepEqual(a, want) {
+		t.Errorf("MergeMaps() modified %v, want %v", a, want)
+	}
+
+	if got := MergeMaps[string, int](); got == nil || len(got) != 0 {
+		t.Errorf("MergeMaps() = %v, want an empty map", got)
+	}
+}
+
+func Test_Uniq(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection []int
+		want       []int
+	}{
+		{name: "duplicates", collection: []int{3, 1, 3, 2, 1}, want: []int{3, 1, 2}},
+		{name: "no duplicates", collection: []int{1, 2}, want: []int{1, 2}},
+		{name: "empty", collection: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Uniq(tt.collection); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Uniq() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Chunk(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection []int
+		size       int
+		want       [][]int
+	}{
+		{name: "remainder", collection: []int{1, 2, 3, 4, 5}, size: 2, want: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "exact", collection: []int{1, 2, 3, 4}, size: 2, want: [][]int{{1, 2}, {3, 4}}},
+		{name: "larger size", collection: []int{1, 2}, size: 5, want: [][]int{{1, 2}}},
+		{name: "empty", collection: []int{}, size: 2, want: [][]int{}},
+		{name: "invalid size", collection: []int{1, 2}, size: 0, want: [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chunk(tt.collection, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Appending to a chunk does not overwrite the elements of the next one
+	collection := []int{1, 2, 3, 4}
+	chunks := Chunk(collection, 2)
+	_ = append(chunks[0], 9)
+
+	if collection[2] != 3 {
+		t.Errorf("Chunk() = %v, want chunks to have no spare capacity", chunks)
+	}
+}
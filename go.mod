@@ -7,6 +7,7 @@ require (
 	github.com/invopop/jsonschema v0.7.0
 	github.com/xeipuuv/gojsonschema v1.2.0
 	golang.org/x/text v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
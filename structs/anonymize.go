@@ -0,0 +1,194 @@
+package structs
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// The literal name of the tag marking the fields holding personally identifiable information. See `Anonymize`.
+//
+// Example:
+//
+//	type Customer struct {
+//		Email string `json:"email" pii:"email"`
+//	}
+const PII_TAG_KEYWORD string = "pii"
+
+// Returned by `Anonymize` when no key is given.
+var ErrMissingAnonymizationKey = errors.New("anonymization key must not be empty")
+
+// Replaces the values of all the fields tagged with `pii` by fake values that preserve their format,
+// so that samples of production payloads can be safely turned into test fixtures.
+//
+// The format of a value is set by the tag (`email`, `uuid`, `phone` or `text`) or, when the tag is empty,
+// inferred from the value itself:
+//   - emails are replaced by valid emails under `example.com`, as in `user-1a2b3c4d@example.com`
+//   - UUIDs (strings or `uuid.UUID` values) are replaced by valid (version 4) UUIDs
+//   - phone numbers keep their length, separators and leading `+`, with every digit replaced
+//   - any other text keeps its length, case, spaces and punctuation, with every letter and digit replaced
+//
+// Fake values are derived from the original ones with an HMAC keyed by the given secret, so a value is always
+// replaced by the same fake value under the same key, which keeps the references between fixtures intact.
+// The key must be kept secret, since anyone holding it could recover low-entropy values (i.e. phone numbers)
+// by anonymizing every candidate and comparing the fakes. Empty values are kept as they are.
+// Tagged values that are neither strings nor UUIDs (i.e. numbers) are replaced by their zero value.
+// The fields of nested structs and the elements of slices/arrays and maps are anonymized as long as they are tagged.
+//
+// Returns `ErrInvalidModel` if the model is not a non-nil pointer to a struct,
+// and `ErrMissingAnonymizationKey` if the key is empty.
+//
+// Usage:
+//
+//	type Customer struct {
+//		Id    string `json:"id" pii:"uuid"`
+//		Name  string `json:"name" pii:""`
+//		Email string `json:"email" pii:""`
+//		Phone string `json:"phone" pii:"phone"`
+//	}
+//
+//	customer := Customer{Id: "5b1f...", Name: "Leo Ribeiro", Email: "leo@example.org", Phone: "+1 (555) 010-0100"}
+//	Anonymize(&customer, []byte(os.Getenv("ANONYMIZATION_KEY")))
+//	// -> {Id: "9c0e...", Name: "Qwa Nxyzkdh", Email: "user-1a2b3c4d@example.com", Phone: "+8 (172) 394-5508"}
+func Anonymize(model any, key []byte) error {
+	if err := ValidateModel(model); err != nil {
+		return err
+	}
+
+	if len(key) == 0 {
+		return ErrMissingAnonymizationKey
+	}
+
+	// Tags are not used as filters, since the tagged fields of nested structs would be left out otherwise
+	for _, attr := range leafAttributes(reflect.ValueOf(model), AttributeOptions{}) {
+		if _, ok := attr.Field.Tag.Lookup(PII_TAG_KEYWORD); !ok {
+			continue
+		}
+
+		value := attr.Value
+		if !value.IsValid() || value.Kind() == reflect.Pointer || !value.CanInterface() {
+			continue
+		}
+
+		var fake any
+		switch {
+		case value.Type() == uuidType:
+			fake = fakeUUID(value.Interface().(uuid.UUID).String(), key)
+		case value.Kind() == reflect.String:
+			format := ""
+			if values := tagValues(attr.Field, PII_TAG_KEYWORD); len(values) != 0 {
+				format = values[0]
+			}
+
+			fake = fakeString(value.String(), format, key)
+		case value.Kind() == reflect.Struct || value.Kind() == reflect.Slice || value.Kind() == reflect.Array || value.Kind() == reflect.Map:
+			// Containers without tagged fields/elements are left as they are
+			continue
+		default:
+			fake = reflect.Zero(value.Type()).Interface()
+		}
+
+		if err := SetValueByPath(model, attr.bracketName(), fake); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns a fake value with the same format as the given one. See `Anonymize`.
+func fakeString(value string, format string, key []byte) string {
+	if value == "" {
+		return value
+	}
+
+	if format == "" {
+		format = inferredFormat(value)
+	}
+
+	rng := fakeRand(value, key)
+
+	switch format {
+	case "email":
+		return fmt.Sprintf("user-%08x@example.com", rng.Uint32())
+	case "uuid":
+		return fakeUUID(value, key).String()
+	case "phone":
+		return strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return rune('0' + rng.Intn(10))
+			}
+
+			return r
+		}, value)
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r):
+			return rune('A' + rng.Intn(26))
+		case unicode.IsLetter(r):
+			return rune('a' + rng.Intn(26))
+		case unicode.IsDigit(r):
+			return rune('0' + rng.Intn(10))
+		}
+
+		return r
+	}, value)
+}
+
+// Returns the format of the value, as in `email` or `phone`.
+func inferredFormat(value string) string {
+	if _, err := uuid.Parse(value); err == nil {
+		return "uuid"
+	}
+
+	if at := strings.LastIndexByte(value, '@'); at > 0 && at < len(value)-1 {
+		return "email"
+	}
+
+	digits := 0
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			digits++
+		case !strings.ContainsRune("+-() .", r):
+			return "text"
+		}
+	}
+
+	if digits >= 7 {
+		return "phone"
+	}
+
+	return "text"
+}
+
+// Returns a version 4 UUID derived from the given value and key.
+func fakeUUID(value string, key []byte) uuid.UUID {
+	id, _ := uuid.NewRandomFromReader(bytes.NewReader(keyedHash(value, key)))
+	return id
+}
+
+// Returns a source of random numbers seeded by the given value and key,
+// so that the same value always results in the same fakes under the same key.
+func fakeRand(value string, key []byte) *rand.Rand {
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(keyedHash(value, key)[:8]))))
+}
+
+// Returns the HMAC-SHA256 of the value under the given key.
+func keyedHash(value string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+
+	return mac.Sum(nil)
+}
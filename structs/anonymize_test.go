@@ -0,0 +1,129 @@
+package structs
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_Anonymize(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" pii:""`
+		City   string `json:"city"`
+	}
+
+	type Customer struct {
+		Id       string            `json:"id" pii:"uuid"`
+		Ref      uuid.UUID         `json:"ref" pii:""`
+		Name     string            `json:"name" pii:""`
+		Email    *string           `json:"email" pii:""`
+		Phone    string            `json:"phone" pii:""`
+		Code     string            `json:"code" pii:"phone"`
+		Age      int               `json:"age" pii:""`
+		Aliases  []string          `json:"aliases" pii:""`
+		Contacts map[string]string `json:"contacts" pii:""`
+		Address  Address           `json:"address"`
+		Nickname string            `json:"nickname" pii:""`
+		Plan     string            `json:"plan"`
+	}
+
+	email, ref := "leo@example.org", uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	newCustomer := func() Customer {
+		email := email
+		return Customer{
+			Id:       "5b1f0c4e-8f3a-4c2e-9d7b-2a6e1f0c9b3d",
+			Ref:      ref,
+			Name:     "Leo Ribeiro",
+			Email:    &email,
+			Phone:    "+1 (555) 010-0100",
+			Code:     "AB-1234",
+			Age:      30,
+			Aliases:  []string{"leo@example.org", "Leonardo"},
+			Contacts: map[string]string{"work": "leo@work.example"},
+			Address:  Address{Street: "Main St 42", City: "Lisbon"},
+			Plan:     "premium",
+		}
+	}
+
+	key := []byte("secret")
+
+	customer := newCustomer()
+	if err := Anonymize(&customer, key); err != nil {
+		t.Fatalf("Anonymize() error = %v", err)
+	}
+
+	isEmail := func(value string) bool {
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value && regexp.MustCompile(`@example\.com$`).MatchString(value)
+	}
+
+	isUUID := func(value string) bool {
+		id, err := uuid.Parse(value)
+		return err == nil && id.Version() == 4
+	}
+
+	checks := []struct {
+		name  string
+		value string
+		valid func(string) bool
+	}{
+		{name: "id", value: customer.Id, valid: isUUID},
+		{name: "ref", value: customer.Ref.String(), valid: isUUID},
+		{name: "name", value: customer.Name, valid: regexp.MustCompile(`^[A-Z][a-z]{2} [A-Z][a-z]{6}$`).MatchString},
+		{name: "email", value: *customer.Email, valid: isEmail},
+		{name: "phone", value: customer.Phone, valid: regexp.MustCompile(`^\+\d \(\d{3}\) \d{3}-\d{4}$`).MatchString},
+		{name: "code", value: customer.Code, valid: regexp.MustCompile(`^AB-\d{4}$`).MatchString},
+		{name: "aliases[0]", value: customer.Aliases[0], valid: isEmail},
+		{name: "aliases[1]", value: customer.Aliases[1], valid: regexp.MustCompile(`^[A-Z][a-z]{7}$`).MatchString},
+		{name: "contacts.work", value: customer.Contacts["work"], valid: isEmail},
+		{name: "address.street", value: customer.Address.Street, valid: regexp.MustCompile(`^[A-Z][a-z]{3} [A-Z][a-z] \d{2}$`).MatchString},
+	}
+
+	original := newCustomer()
+	originals := map[string]string{
+		"id": original.Id, "ref": original.Ref.String(), "name": original.Name, "email": email, "phone": original.Phone, "code": original.Code,
+		"aliases[0]": original.Aliases[0], "aliases[1]": original.Aliases[1], "contacts.work": original.Contacts["work"], "address.street": original.Address.Street,
+	}
+
+	for _, check := range checks {
+		if check.value == originals[check.name] || !check.valid(check.value) {
+			t.Errorf("Anonymize() %v = %v, want a fake value with the format of %v", check.name, check.value, originals[check.name])
+		}
+	}
+
+	if customer.Age != 0 || customer.Nickname != "" || customer.Address.City != "Lisbon" || customer.Plan != "premium" {
+		t.Errorf("Anonymize() = %+v, want untagged and empty values to be kept and other values to be zeroed", customer)
+	}
+
+	// The same values always result in the same fakes
+	again := newCustomer()
+	_ = Anonymize(&again, key)
+
+	if again.Name != customer.Name || *again.Email != *customer.Email || again.Ref != customer.Ref || again.Aliases[0] != customer.Aliases[0] {
+		t.Errorf("Anonymize() = %+v, want %+v", again, customer)
+	}
+
+	if *again.Email != again.Aliases[0] {
+		t.Errorf("Anonymize() = %v, %v, want equal values to result in equal fakes", *again.Email, again.Aliases[0])
+	}
+
+	// Fakes depend on the key, so they cannot be recomputed without it
+	other := newCustomer()
+	_ = Anonymize(&other, []byte("other"))
+
+	if other.Name == customer.Name || *other.Email == *customer.Email || other.Ref == customer.Ref {
+		t.Errorf("Anonymize() = %+v, want fakes different from %+v", other, customer)
+	}
+
+	if err := Anonymize(customer, key); !errors.Is(err, ErrInvalidModel) {
+		t.Errorf("Anonymize() error = %v, want %v", err, ErrInvalidModel)
+	}
+
+	if err := Anonymize(&other, nil); !errors.Is(err, ErrMissingAnonymizationKey) {
+		t.Errorf("Anonymize() error = %v, want %v", err, ErrMissingAnonymizationKey)
+	}
+}
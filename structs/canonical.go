@@ -0,0 +1,336 @@
+package structs
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Returned by `CanonicalJSON` when the model is not a struct (or a pointer to one).
+var ErrCanonicalModel = errors.New("model must be a struct")
+
+// Serializes the model into a deterministic JSON document, suitable for signing and hashing.
+// The same values always produce the same bytes, regardless of the order of the fields in the Go source.
+//
+// The document is built from the attributes returned by `GetAttributes`, which means:
+//   - fields are named after their JSON names, and unexported fields (as well as `json:"-"`) are left out
+//   - the keys of every object (including maps) are sorted
+//   - numbers are written in their shortest form, with integral floats written as integers (`1.0` -> `1`)
+//     and exponents only used for very small or very large values (`1e-7`, `1e+21`)
+//   - strings are not HTML-escaped
+//
+// Values implementing `json.Marshaler` or `encoding.TextMarshaler` (i.e. `time.Time`) are encoded by their own methods,
+// and the resulting JSON is normalized the same way. An error is returned for values that cannot be represented,
+// like `NaN`.
+//
+// Usage:
+//
+//	type Payment struct {
+//		Amount   float64           `json:"amount"`
+//		Currency string            `json:"currency"`
+//		Metadata map[string]string `json:"metadata"`
+//	}
+//
+//	data, err := CanonicalJSON(Payment{Amount: 10.0, Currency: "BRL", Metadata: map[string]string{"b": "2", "a": "1"}})
+//	// -> {"amount":10,"currency":"BRL","metadata":{"a":"1","b":"2"}}
+func CanonicalJSON(model any) ([]byte, error) {
+	rv := reflect.ValueOf(model)
+	if rv.Kind() == reflect.Pointer {
+		rv, _ = PointerElement(rv)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrCanonicalModel
+	}
+
+	document, err := canonicalObject(StructAttributes(GetAttributes(rv, []string{})))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, document); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Returns the object formed by the top-level attributes of the given list (i.e. the fields of a struct).
+func canonicalObject(attrs StructAttributes) (map[string]any, error) {
+	object := map[string]any{}
+
+	for pos := 0; pos < len(attrs); pos = attrs.NextSibling(pos) {
+		name := GetJSONTagValue(attrs[pos].Field)
+		if name == "-" {
+			continue
+		}
+
+		value, err := canonicalValue(attrs[pos], attrs.Subtree(pos))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", attrs[pos].FullName(), err)
+		}
+
+		object[name] = value
+	}
+
+	return object, nil
+}
+
+// Returns the value of the attribute, built from its descendants when it is a struct, a slice/array or a map.
+func canonicalValue(attr StructAttribute, descendants StructAttributes) (any, error) {
+	value := attr.Value
+	if !value.IsValid() || value.Kind() == reflect.Pointer || (value.Kind() == reflect.Interface && value.IsNil()) {
+		return nil, nil
+	}
+
+	if !value.CanInterface() {
+		return nil, nil
+	}
+
+	switch value.Interface().(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return canonicalMarshaled(value.Interface())
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return canonicalObject(descendants)
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		object := map[string]any{}
+		for pos := 0; pos < len(descendants); pos = descendants.NextSibling(pos) {
+			entry, err := canonicalValue(descendants[pos], descendants.Subtree(pos))
+			if err != nil {
+				return nil, err
+			}
+
+			object[descendants[pos].Field.Name] = entry
+		}
+
+		return object, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && (value.IsNil() || value.Type().Elem().Kind() == reflect.Uint8) {
+			return canonicalMarshaled(value.Interface())
+		}
+
+		return canonicalList(value, descendants)
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(value.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Number(strconv.FormatUint(value.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return canonicalFloat(value.Float(), value.Type().Bits())
+	}
+
+	return canonicalMarshaled(value.Interface())
+}
+
+// Returns the elements of a slice/array. The elements of a list of structs are built from the fields
+// sharing their position, while the elements of any other list are attributes themselves. See `getListAttributes`.
+func canonicalList(value reflect.Value, descendants StructAttributes) ([]any, error) {
+	list := make([]any, 0, value.Len())
+
+	if elemType := baseType(value.Type().Elem()); elemType.Kind() != reflect.Struct || IsNullableWrapper(elemType) {
+		for pos := 0; pos < len(descendants); pos = descendants.NextSibling(pos) {
+			element, err := canonicalValue(descendants[pos], descendants.Subtree(pos))
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, element)
+		}
+
+		return list, nil
+	}
+
+	for l := 0; l < value.Len(); l++ {
+		element, err := PointerElement(value.Index(l))
+		if err != nil {
+			list = append(list, nil)
+			continue
+		}
+
+		fields := StructAttributes{}
+		for pos := 0; pos < len(descendants); pos = descendants.NextSibling(pos) {
+			if descendants[pos].ListPosition == l {
+				fields = append(fields, descendants[pos:descendants.NextSibling(pos)]...)
+			}
+		}
+
+		if element.CanInterface() {
+			switch element.Interface().(type) {
+			case json.Marshaler, encoding.TextMarshaler:
+				item, err := canonicalMarshaled(element.Interface())
+				if err != nil {
+					return nil, err
+				}
+
+				list = append(list, item)
+				continue
+			}
+		}
+
+		object, err := canonicalObject(fields)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, object)
+	}
+
+	return list, nil
+}
+
+// Encodes the value with encoding/json and normalizes the numbers of the result.
+func canonicalMarshaled(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return canonicalNumbers(document)
+}
+
+// Rewrites the numbers found in a decoded JSON document in their canonical form.
+func canonicalNumbers(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(n, 10)), nil
+		}
+
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return canonicalFloat(f, 64)
+	case map[string]any:
+		for key, item := range v {
+			normalized, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = normalized
+		}
+	case []any:
+		for i, item := range v {
+			normalized, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = normalized
+		}
+	}
+
+	return value, nil
+}
+
+// Formats a float in its shortest form, as JavaScript would (see RFC 8785).
+func canonicalFloat(f float64, bitSize int) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("unsupported value: %v", f)
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return json.Number(strconv.FormatFloat(f, 'f', -1, bitSize)), nil
+	}
+
+	// Exponents have no leading zeros, as in `1e-7` (instead of `1e-07`)
+	formatted := strconv.FormatFloat(f, 'e', -1, bitSize)
+	mantissa, exponent, _ := strings.Cut(formatted, "e")
+	sign, digits := exponent[:1], strings.TrimLeft(exponent[1:], "0")
+
+	return json.Number(mantissa + "e" + sign + digits), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(string(v))
+	case string:
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+
+		// Removes the newline added by the encoder
+		buf.Truncate(buf.Len() - 1)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonical(buf, key); err != nil {
+				return err
+			}
+
+			buf.WriteByte(':')
+
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		return fmt.Errorf("unsupported value: %v", v)
+	}
+
+	return nil
+}
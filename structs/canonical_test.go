@@ -0,0 +1,114 @@
+package structs
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_CanonicalJSON(t *testing.T) {
+	type Item struct {
+		Sku   string  `json:"sku"`
+		Price float64 `json:"price"`
+	}
+
+	type Order struct {
+		Total    float64           `json:"total"`
+		Customer *Author           `json:"customer"`
+		Items    []*Item           `json:"items"`
+		Metadata map[string]any    `json:"metadata"`
+		Quantity map[string]uint   `json:"quantity"`
+		Note     string            `json:"note"`
+		Rate     float32           `json:"rate"`
+		Placed   time.Time         `json:"placed"`
+		Extra    any               `json:"extra"`
+		Tags     []string          `json:"tags"`
+		Codes    [2]int            `json:"codes"`
+		Blob     []byte            `json:"blob"`
+		Labels   map[string]string `json:"labels"`
+		Secret   string            `json:"-"`
+		internal string
+	}
+
+	// Same fields as `Item`, declared in a different order
+	type ReorderedItem struct {
+		Price float64 `json:"price"`
+		Sku   string  `json:"sku"`
+	}
+
+	placed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		model   any
+		want    string
+		wantErr error
+	}{
+		{
+			name: "order",
+			model: &Order{
+				Total:    30.0,
+				Customer: &Author{Id: "7"},
+				Items:    []*Item{{Sku: "b", Price: 0.1}, nil, {Sku: "a", Price: 1e21}},
+				Metadata: map[string]any{"z": 1.50, "a": []any{2.0, "<x>"}},
+				Quantity: map[string]uint{"b": 2, "a": 1},
+				Note:     "<b>&</b>",
+				Rate:     0.1,
+				Placed:   placed,
+				Extra:    map[string]float64{"small": 0.0000001},
+				Codes:    [2]int{1, 2},
+				Blob:     []byte("hi"),
+				Labels:   map[string]string{},
+				Secret:   "s3cr3t",
+				internal: "internal",
+			},
+			want: `{"blob":"aGk=","codes":[1,2],"customer":{"id":"7"},"extra":{"small":1e-7},` +
+				`"items":[{"price":0.1,"sku":"b"},null,{"price":1e+21,"sku":"a"}],"labels":{},` +
+				`"metadata":{"a":[2,"<x>"],"z":1.5},"note":"<b>&</b>","placed":"2024-01-02T03:04:05Z",` +
+				`"quantity":{"a":1,"b":2},"rate":0.1,"tags":null,"total":30}`,
+		},
+		{
+			name:  "field order does not matter",
+			model: ReorderedItem{Sku: "a", Price: -2.50},
+			want:  `{"price":-2.5,"sku":"a"}`,
+		},
+		{
+			name:  "same values",
+			model: Item{Sku: "a", Price: -2.50},
+			want:  `{"price":-2.5,"sku":"a"}`,
+		},
+		{
+			name:  "zero values",
+			model: Item{},
+			want:  `{"price":0,"sku":""}`,
+		},
+		{
+			name:    "not a number",
+			model:   Item{Price: math.NaN()},
+			wantErr: errors.New("price: unsupported value: NaN"),
+		},
+		{
+			name:    "not a struct",
+			model:   []Item{},
+			wantErr: ErrCanonicalModel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON(tt.model)
+
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Errorf("CanonicalJSON() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil || string(got) != tt.want {
+				t.Errorf("CanonicalJSON() = %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
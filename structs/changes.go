@@ -0,0 +1,243 @@
+package structs
+
+import (
+	"reflect"
+)
+
+const (
+	// The literal name of the tag used to mark fields whose values must never be exposed.
+	//
+	// Example:
+	//
+	//	type Account struct {
+	//		Password string `json:"password" sensitive:""`
+	//	}
+	SENSITIVE_TAG_KEYWORD string = "sensitive"
+
+	// The value used in place of the values of sensitive fields.
+	REDACTED_VALUE string = "[REDACTED]"
+)
+
+const (
+	// The attribute only exists in the new version, as the element appended to a slice.
+	ADDED_ATTRIBUTE ChangeKind = "added"
+
+	// The attribute only exists in the old version, as the element removed from a slice.
+	REMOVED_ATTRIBUTE ChangeKind = "removed"
+
+	// The attribute exists in both versions, with different values.
+	MODIFIED_ATTRIBUTE ChangeKind = "modified"
+)
+
+type (
+	// The kind of change undergone by an attribute. See `Diff`.
+	ChangeKind string
+
+	// The change of a single attribute between two versions of a struct. See `Diff`.
+	Change struct {
+		// The full name of the attribute. See `StructAttribute.FullName()`.
+		Path string `json:"path"`
+
+		Kind ChangeKind `json:"kind"`
+
+		// The value before the change. Nil if the attribute did not exist.
+		Old any `json:"old"`
+
+		// The value after the change. Nil if the attribute no longer exists.
+		New any `json:"new"`
+	}
+
+	// A serializable event describing the change of a single attribute, along with who made it.
+	FieldChange struct {
+		Change
+
+		// Who made the change.
+		Actor string `json:"actor,omitempty"`
+	}
+
+	ChangeEventOptions struct {
+		// The actor recorded in every event.
+		Actor string
+
+		// The tag used to identify sensitive fields. Defaults to `SENSITIVE_TAG_KEYWORD`.
+		SensitiveTag string
+	}
+)
+
+// Compares two instances of the same struct and returns one event per changed attribute.
+// Events hold the changes reported by `Diff`, along with the actor, and honor a custom sensitive tag.
+// The values of fields tagged as sensitive, and of the ones nested inside them, are replaced by `REDACTED_VALUE`.
+//
+// Usage:
+//
+//	type Account struct {
+//		Email    string `json:"email"`
+//		Password string `json:"password" sensitive:""`
+//	}
+//
+//	before := Account{Email: "leo@example.com", Password: "123"}
+//	after := Account{Email: "leo@example.org", Password: "456"}
+//
+//	ChangeEvents(before, after, ChangeEventOptions{Actor: "admin"})
+//	// -> [
+//	//	{Path: "email", Kind: "modified", Old: "leo@example.com", New: "leo@example.org", Actor: "admin"},
+//	//	{Path: "password", Kind: "modified", Old: "[REDACTED]", New: "[REDACTED]", Actor: "admin"},
+//	// ]
+func ChangeEvents(before, after any, options ChangeEventOptions) []FieldChange {
+	changes := redactedChanges(before, after, options.SensitiveTag)
+
+	return Map(changes, func(_ int, change Change) FieldChange {
+		return FieldChange{Change: change, Actor: options.Actor}
+	})
+}
+
+// Compares two versions of the same struct and returns the attributes whose values differ,
+// which could be used to build audit trails. Only leaf attributes are compared (see `Snapshot`),
+// so a changed element of a slice is reported under its own path, as in `emails[1]`.
+//
+// Changes are ordered by the position of the attribute in `a`, followed by the attributes that only exist in `b`.
+// The values of sensitive fields are redacted. Use `ChangeEvents` to record who made the changes.
+//
+// Usage:
+//
+//	before := Person{Name: "Leo", Emails: []string{"leo@example.com"}}
+//	after := Person{Name: "Leonardo", Emails: []string{"leo@example.com", "leo@example.org"}}
+//
+//	Diff(before, after)
+//	// -> [
+//	//	{Path: "name", Kind: "modified", Old: "Leo", New: "Leonardo"},
+//	//	{Path: "emails[1]", Kind: "added", Old: nil, New: "leo@example.org"},
+//	// ]
+func Diff(a, b any) []Change {
+	return redactedChanges(a, b, "")
+}
+
+// Returns the changes between two values, whose sensitive values are replaced by `REDACTED_VALUE`. See `IsSensitive`.
+func redactedChanges(before, after any, sensitiveTag string) []Change {
+	changes := []Change{}
+
+	for _, change := range diffAttributes(reflect.ValueOf(before), reflect.ValueOf(after)) {
+		c := Change{
+			Path: change.path,
+			Kind: MODIFIED_ATTRIBUTE,
+			Old:  attributeValue(change.old.Value),
+			New:  attributeValue(change.new.Value),
+		}
+
+		switch {
+		case !change.old.Value.IsValid():
+			c.Kind = ADDED_ATTRIBUTE
+		case !change.new.Value.IsValid():
+			c.Kind = REMOVED_ATTRIBUTE
+		}
+
+		if IsSensitive(change.attribute(), sensitiveTag) {
+			if c.Old != nil {
+				c.Old = REDACTED_VALUE
+			}
+
+			if c.New != nil {
+				c.New = REDACTED_VALUE
+			}
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// Reports whether the attribute, or any of its parents, contains the given tag.
+// An empty tag defaults to `SENSITIVE_TAG_KEYWORD`.
+func IsSensitive(attribute StructAttribute, tag string) bool {
+	if tag == "" {
+		tag = SENSITIVE_TAG_KEYWORD
+	}
+
+	if _, ok := attribute.Field.Tag.Lookup(tag); ok {
+		return true
+	}
+
+	for _, parent := range attribute.Parents {
+		if _, ok := parent.Field.Tag.Lookup(tag); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns a copy of the attribute whose value is replaced by `REDACTED_VALUE`.
+// The values of its parents and children are dropped, since they would expose the original value as well.
+// The name of the attribute is preserved.
+//
+// Usage:
+//
+//	attr = RedactAttribute(attr)
+//	attr.FullName()        // -> "password"
+//	attr.Value.Interface() // -> "[REDACTED]"
+func RedactAttribute(attribute StructAttribute) StructAttribute {
+	redacted := attribute
+	redacted.Value = reflect.ValueOf(REDACTED_VALUE)
+	redacted.Children = nil
+
+	redacted.Parents = make([]StructAttribute, len(attribute.Parents))
+	for i, parent := range attribute.Parents {
+		parent.Value = reflect.Value{}
+		parent.Children = nil
+		parent.Parents = redacted.Parents[:i:i]
+		redacted.Parents[i] = parent
+	}
+
+	return redacted
+}
+
+// -------------------------------------------------------
+// -------------------------------------------------------
+// -------------------------------------------------------
+
+type attributeChange struct {
+	path string
+	old  StructAttribute
+	new  StructAttribute
+}
+
+func (c attributeChange) attribute() StructAttribute {
+	if c.new.Value.IsValid() {
+		return c.new
+	}
+
+	return c.old
+}
+
+// Compares the leaf attributes of two values and returns the ones that differ.
+// Changes are ordered by the position of the attribute in `a`,
+// followed by the attributes that only exist in `b`.
+func diffAttributes(a, b reflect.Value) (changes []attributeChange) {
+	previous := leafAttributes(a, AttributeOptions{})
+	current := leafAttributes(b, AttributeOptions{})
+
+	currentByPath := make(map[string]StructAttribute, len(current))
+	for _, attr := range current {
+		currentByPath[attr.FullName()] = attr
+	}
+
+	seen := make(map[string]bool, len(previous))
+	for _, attr := range previous {
+		path := attr.FullName()
+		seen[path] = true
+
+		other := currentByPath[path]
+		if !reflect.DeepEqual(attributeValue(attr.Value), attributeValue(other.Value)) {
+			changes = append(changes, attributeChange{path: path, old: attr, new: other})
+		}
+	}
+
+	for _, attr := range current {
+		if path := attr.FullName(); !seen[path] && attributeValue(attr.Value) != nil {
+			changes = append(changes, attributeChange{path: path, new: attr})
+		}
+	}
+
+	return changes
+}
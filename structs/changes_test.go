@@ -0,0 +1,174 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_ChangeEvents(t *testing.T) {
+	type Account struct {
+		Email    string   `json:"email"`
+		Password string   `json:"password" sensitive:""`
+		Roles    []string `json:"roles"`
+		Active   bool     `json:"active"`
+	}
+
+	before := Account{Email: "leo@example.com", Password: "123", Roles: []string{"ADMIN"}, Active: true}
+	after := Account{Email: "leo@example.org", Password: "456", Roles: []string{"ADMIN", "GUEST"}, Active: true}
+
+	tests := []struct {
+		name    string
+		before  any
+		after   any
+		options ChangeEventOptions
+		want    []FieldChange
+	}{
+		{
+			name:    "no changes",
+			before:  before,
+			after:   before,
+			options: ChangeEventOptions{},
+			want:    []FieldChange{},
+		},
+		{
+			name:    "changes",
+			before:  before,
+			after:   after,
+			options: ChangeEventOptions{Actor: "admin"},
+			want: []FieldChange{
+				{Change: Change{Path: "email", Kind: MODIFIED_ATTRIBUTE, Old: "leo@example.com", New: "leo@example.org"}, Actor: "admin"},
+				{Change: Change{Path: "password", Kind: MODIFIED_ATTRIBUTE, Old: REDACTED_VALUE, New: REDACTED_VALUE}, Actor: "admin"},
+				{Change: Change{Path: "roles[1]", Kind: ADDED_ATTRIBUTE, Old: nil, New: "GUEST"}, Actor: "admin"},
+			},
+		},
+		{
+			name:    "custom sensitive tag",
+			before:  before,
+			after:   after,
+			options: ChangeEventOptions{SensitiveTag: "secret"},
+			want: []FieldChange{
+				{Change: Change{Path: "email", Kind: MODIFIED_ATTRIBUTE, Old: "leo@example.com", New: "leo@example.org"}},
+				{Change: Change{Path: "password", Kind: MODIFIED_ATTRIBUTE, Old: "123", New: "456"}},
+				{Change: Change{Path: "roles[1]", Kind: ADDED_ATTRIBUTE, Old: nil, New: "GUEST"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChangeEvents(tt.before, tt.after, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangeEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	type Owner struct {
+		Name string `json:"name"`
+	}
+
+	type Document struct {
+		Title    string            `json:"title"`
+		Owner    *Owner            `json:"owner"`
+		Tags     []string          `json:"tags"`
+		Password string            `json:"password" sensitive:""`
+		Labels   map[string]string `json:"labels"`
+		Signer   *Owner            `json:"signer" sensitive:""`
+	}
+
+	before := Document{Title: "Draft", Owner: &Owner{Name: "Leo"}, Tags: []string{"a", "b"}, Password: "123", Labels: map[string]string{"env": "dev"}}
+	after := Document{Title: "Final", Owner: &Owner{Name: "Leo"}, Tags: []string{"a"}, Password: "456", Labels: map[string]string{"env": "dev", "team": "docs"}}
+
+	tests := []struct {
+		name string
+		a    any
+		b    any
+		want []Change
+	}{
+		{
+			name: "no changes",
+			a:    before,
+			b:    &before,
+			want: []Change{},
+		},
+		{
+			name: "changes",
+			a:    before,
+			b:    after,
+			want: []Change{
+				{Path: "title", Kind: MODIFIED_ATTRIBUTE, Old: "Draft", New: "Final"},
+				{Path: "tags[1]", Kind: REMOVED_ATTRIBUTE, Old: "b", New: nil},
+				{Path: "password", Kind: MODIFIED_ATTRIBUTE, Old: REDACTED_VALUE, New: REDACTED_VALUE},
+				{Path: "labels.team", Kind: ADDED_ATTRIBUTE, Old: nil, New: "docs"},
+			},
+		},
+		{
+			name: "nested changes",
+			a:    Document{Owner: &Owner{Name: "Leo"}, Signer: &Owner{Name: "Leo"}},
+			b:    Document{Owner: &Owner{Name: "Ana"}, Signer: &Owner{Name: "Ana"}},
+			want: []Change{
+				{Path: "owner.name", Kind: MODIFIED_ATTRIBUTE, Old: "Leo", New: "Ana"},
+				{Path: "signer.name", Kind: MODIFIED_ATTRIBUTE, Old: REDACTED_VALUE, New: REDACTED_VALUE},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FieldChange_JSON(t *testing.T) {
+	event := FieldChange{Change: Change{Path: "email", Kind: MODIFIED_ATTRIBUTE, Old: "a", New: "b"}}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"path":"email","kind":"modified","old":"a","new":"b"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func Test_RedactAttribute(t *testing.T) {
+	type Credentials struct {
+		Token string `json:"token"`
+	}
+
+	type Account struct {
+		Email       string      `json:"email"`
+		Credentials Credentials `json:"credentials" sensitive:""`
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(Account{Email: "leo@example.com", Credentials: Credentials{Token: "abc"}}), []string{})
+
+	sensitive := Map(attributes, func(_ int, attr StructAttribute) bool { return IsSensitive(attr, "") })
+	if want := []bool{false, true, true}; !reflect.DeepEqual(sensitive, want) {
+		t.Fatalf("IsSensitive() = %v, want %v", sensitive, want)
+	}
+
+	token := RedactAttribute(attributes[2])
+
+	if got := token.FullName(); got != "credentials.token" {
+		t.Errorf("RedactAttribute().FullName() = %v, want credentials.token", got)
+	}
+
+	if got := token.Value.Interface(); got != REDACTED_VALUE {
+		t.Errorf("RedactAttribute().Value = %v, want %v", got, REDACTED_VALUE)
+	}
+
+	if token.Parents[0].Value.IsValid() {
+		t.Errorf("RedactAttribute().Parents[0].Value = %v, want an invalid value", token.Parents[0].Value)
+	}
+
+	if got := attributes[2].Value.Interface(); got != "abc" {
+		t.Errorf("RedactAttribute() modified the original attribute: %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package structs
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type (
+	// Encodes a model into a wire format.
+	// Implementations are expected to walk the model with `GetAttributes` (or `GetAttributesWithOptions`),
+	// so that they honor the same tags as the rest of this package.
+	Encoder interface {
+		// Returns the representation of the model (a struct or a pointer to one) in the format.
+		Encode(model any) ([]byte, error)
+	}
+
+	// Decodes a wire format into a model.
+	// Implementations are expected to report errors the same way `Decode` does:
+	// keyed by the paths of the attributes (see `StructAttribute.FullName()`), using the codes found in `DecodingErrors`,
+	// with errors that do not belong to any attribute reported under the `_` key.
+	Decoder interface {
+		// Populates the model (a non-nil pointer to a struct) with the given data.
+		// Returns the errors found, or an empty map if there are none.
+		Decode(data []byte, model any) map[string][]string
+	}
+
+	// Adapter that allows the use of an ordinary function as an `Encoder`.
+	EncoderFunc func(model any) ([]byte, error)
+
+	// Adapter that allows the use of an ordinary function as a `Decoder`.
+	DecoderFunc func(data []byte, model any) map[string][]string
+
+	// Adapter for the built-in decoders, which also accept the options of the decoder. See `DecodeRequest`.
+	optionsDecoderFunc func(data []byte, model any, options DecoderOptions) map[string][]string
+
+	format struct {
+		encoder Encoder
+		decoder Decoder
+	}
+)
+
+// Returned when encoding into a format that has no registered encoder.
+var ErrUnregisteredFormat = errors.New("unregistered format")
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]format{
+		"json": {
+			encoder: EncoderFunc(json.Marshal),
+			decoder: optionsDecoderFunc(Decode),
+		},
+		"fixed": {
+			encoder: EncoderFunc(func(model any) ([]byte, error) {
+				record, err := EncodeFixedWidth(model)
+				return []byte(record), err
+			}),
+			decoder: DecoderFunc(func(data []byte, model any) map[string][]string { return DecodeFixedWidth(string(data), model) }),
+		},
+		"yaml": {
+			encoder: EncoderFunc(encodeYAML),
+			decoder: optionsDecoderFunc(DecodeYAML),
+		},
+		"xml": {
+			encoder: EncoderFunc(xml.Marshal),
+			decoder: optionsDecoderFunc(DecodeXML),
+		},
+	}
+)
+
+func (f EncoderFunc) Encode(model any) ([]byte, error) {
+	return f(model)
+}
+
+func (f DecoderFunc) Decode(data []byte, model any) map[string][]string {
+	return f(data, model)
+}
+
+func (f optionsDecoderFunc) Decode(data []byte, model any) map[string][]string {
+	return f(data, model, DecoderOptions{})
+}
+
+// Returns the decoder registered for the given format, or nil if there is none.
+func registeredDecoder(name string) Decoder {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	return formats[name].decoder
+}
+
+// Registers the encoder and decoder of a wire format, so that it can be used with `EncodeFormat` and `DecodeFormat`.
+// Either of them may be nil if the format is only read or only written.
+// The `json`, `yaml` (see `DecodeYAML`), `xml` (see `DecodeXML`) and `fixed` (see `EncodeFixedWidth`) formats are registered by default.
+// Registered formats are also used by `DecodeRequest`, for the media types named after them.
+//
+// Registering a format under the name of a registered format replaces it.
+// It panics if the name is empty.
+//
+// Usage:
+//
+//	RegisterFormat("form", EncoderFunc(encodeForm), DecoderFunc(decodeForm))
+//
+//	data, err := EncodeFormat("form", user)
+//	errs := DecodeFormat("form", data, &user)
+func RegisterFormat(name string, encoder Encoder, decoder Decoder) {
+	if name == "" {
+		panic("structs: cannot register a format without a name")
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[name] = format{encoder: encoder, decoder: decoder}
+}
+
+// Returns the names of the registered formats, sorted alphabetically.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Encodes the model using the encoder registered for the given format. See `RegisterFormat`.
+// Returns `ErrUnregisteredFormat` if the format has no registered encoder.
+func EncodeFormat(name string, model any) ([]byte, error) {
+	formatsMu.RLock()
+	f := formats[name]
+	formatsMu.RUnlock()
+
+	if f.encoder == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnregisteredFormat, name)
+	}
+
+	return f.encoder.Encode(model)
+}
+
+// Decodes the data into the model using the decoder registered for the given format. See `RegisterFormat`.
+// If the format has no registered decoder, an `UNREGISTERED_FORMAT` error is reported under the `_` key.
+func DecodeFormat(name string, data []byte, model any) map[string][]string {
+	formatsMu.RLock()
+	f := formats[name]
+	formatsMu.RUnlock()
+
+	if f.decoder == nil {
+		return map[string][]string{"_": {DecodingErrors["unregistered_format"]}}
+	}
+
+	if validations := f.decoder.Decode(data, model); validations != nil {
+		return validations
+	}
+
+	return map[string][]string{}
+}
@@ -0,0 +1,109 @@
+package structs
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_Formats(t *testing.T) {
+	type Payment struct {
+		Bank string `json:"bank" fixed:"start=0,len=3"`
+		Name string `json:"name" fixed:"start=3,len=5"`
+	}
+
+	// A format registered the way an external module would, reusing the attribute walk and the error paths
+	RegisterFormat("form", EncoderFunc(func(model any) ([]byte, error) {
+		values := url.Values{}
+		for _, attr := range GetAttributes(reflect.ValueOf(model), []string{}) {
+			values.Set(attr.FullName(), attr.Value.String())
+		}
+
+		return []byte(values.Encode()), nil
+	}), DecoderFunc(func(data []byte, model any) map[string][]string {
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return map[string][]string{"_": {DecodingErrors["invalid_payload"]}}
+		}
+
+		return BindParams(model, "json", values)
+	}))
+
+	RegisterFormat("write-only", EncoderFunc(func(model any) ([]byte, error) { return []byte("-"), nil }), nil)
+
+	tests := []struct {
+		name       string
+		format     string
+		model      Payment
+		data       string
+		wantErr    error
+		wantDecode map[string][]string
+	}{
+		{
+			name:       "json",
+			format:     "json",
+			model:      Payment{Bank: "341", Name: "Leo"},
+			data:       `{"bank":"341","name":"Leo"}`,
+			wantDecode: map[string][]string{},
+		},
+		{
+			name:       "fixed",
+			format:     "fixed",
+			model:      Payment{Bank: "341", Name: "Leo"},
+			data:       "341Leo  ",
+			wantDecode: map[string][]string{},
+		},
+		{
+			name:       "yaml",
+			format:     "yaml",
+			model:      Payment{Bank: "341", Name: "Leo"},
+			data:       "bank: \"341\"\nname: Leo\n",
+			wantDecode: map[string][]string{},
+		},
+		{
+			name:       "registered",
+			format:     "form",
+			model:      Payment{Bank: "341", Name: "Leo"},
+			data:       "bank=341&name=Leo",
+			wantDecode: map[string][]string{},
+		},
+		{
+			name:       "encoder only",
+			format:     "write-only",
+			data:       "-",
+			wantDecode: map[string][]string{"_": {"UNREGISTERED_FORMAT"}},
+		},
+		{
+			name:       "unregistered",
+			format:     "avro",
+			wantErr:    ErrUnregisteredFormat,
+			wantDecode: map[string][]string{"_": {"UNREGISTERED_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncodeFormat(tt.format, tt.model)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncodeFormat() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if string(data) != tt.data {
+				t.Errorf("EncodeFormat() = %q, want %q", data, tt.data)
+			}
+
+			var got Payment
+			if errs := DecodeFormat(tt.format, data, &got); !reflect.DeepEqual(errs, tt.wantDecode) {
+				t.Errorf("DecodeFormat() = %v, want %v", errs, tt.wantDecode)
+			}
+
+			if len(tt.wantDecode) == 0 && got != tt.model {
+				t.Errorf("DecodeFormat() model = %v, want %v", got, tt.model)
+			}
+		})
+	}
+
+	if got := Formats(); !reflect.DeepEqual(got, []string{"fixed", "form", "json", "write-only", "xml", "yaml"}) {
+		t.Errorf("Formats() = %v", got)
+	}
+}
@@ -1,10 +1,19 @@
 package structs
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/invopop/jsonschema"
+	"github.com/oleoneto/go-structs/collections"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -37,22 +46,118 @@ type (
 		// A function that runs after the decoder is done processing the data.
 		// This could be used for ignoring certain errors or providing custom error messages.
 		AfterHook func(validations map[string][]string) map[string][]string
+
+		// A function that runs after the model is populated with the values of the payload.
+		// It receives the paths of all the attributes present in the payload (see `SetValuesFromMap`),
+		// which could be used to tell apart fields that were explicitly set from those left at their zero value.
+		// See `DecodeWithResult` for obtaining them without a hook.
+		PopulateHook func(populated []string)
+
+		// Error codes that should be used instead of the defaults found in `DecodingErrors`, keyed the same way.
+		// For example: {"required": "MISSING"}
+		ErrorCodes map[string]string
+
+		// When set, panics raised while decoding the payload (including the ones raised by hooks)
+		// are recovered from and reported as an `UNEXPECTED_ERROR` for the offending attribute (see `AttributePanic`),
+		// or under the `_` key if it is unknown, as for the panics raised by hooks.
+		Recover bool
+
+		// When set, the payload is decoded into a new instance of the model, which is only copied into the model
+		// if no errors are found in the payload. Otherwise, the model is left untouched.
+		// Since the model is replaced by the decoded instance, fields absent from the payload are left at their zero values.
+		AtomicPopulate bool
+
+		// A JSON schema the payload should be checked against, instead of the one reflected from the model.
+		// This is useful when the contract is owned by a spec-first team. The model is still populated as usual.
+		//
+		// Only errors whose types are listed in `Rules` are reported. `JSONOverrides` is ignored.
+		ExternalSchema []byte
+
+		// Models of the sections of an envelope payload, such as `{"items": [...], "meta": {...}}`,
+		// keyed by the names of the properties holding them. For example: {"items": Item{}, "meta": Meta{}}
+		//
+		// When set, the payload is checked section by section, instead of against the schema of the model (or `ExternalSchema`).
+		// Sections holding arrays have each of their elements checked against the model of the section, as in `items[3].name`.
+		// Properties that are not sections are reported if `ADDITIONAL_PROPERTY` is checked. Missing sections are not reported.
+		// The model itself is still populated as usual.
+		Sections map[string]any
+
+		// The maximum number of bytes `DecodeReader` (and `DecodeRequest`) reads from a payload.
+		// Larger payloads are reported as `PAYLOAD_TOO_LARGE` under the `_` key. There is no limit when zero or negative.
+		MaxBytes int64
+
+		// The audiences the schema of the model is reflected for, as in `internal`. See `AUDIENCE_TAG_KEYWORD`.
+		// Fields restricted to other audiences are left out of the schema, which means they are reported
+		// as additional properties (if `ADDITIONAL_PROPERTY` is checked) and never required.
+		// Their values are never populated either, whatever the `Rules`, so clients cannot set them.
+		// This allows a single struct to describe both its public and internal contracts.
+		Audiences []string
+
+		// Set by `NewJSONDecoder`, whose options carry a copy of `DecodingErrors`:
+		// error codes are then only read from `ErrorCodes`, never from the package-level defaults.
+		isolated bool
 	}
 )
 
+// The outcome of decoding a payload. See `DecodeWithResult`.
+type DecodeResult struct {
+	// The errors found, keyed as in `Decode`.
+	Validations map[string][]string
+
+	// The paths of the attributes present in the payload, sorted alphabetically. See `SetValuesFromMap`.
+	// It is nil if the payload was not decoded, as when the model is invalid.
+	Populated []string
+}
+
+type (
+	// Populates the model with a payload, returning the paths of the attributes populated and the errors found. See `DecodeWithResult`.
+	populateFunc func(data []byte, model any) (populated []string, validations map[string][]string)
+
+	// Checks a payload, adding the errors found to the validations of the model.
+	checkFunc func(data []byte, model any, validations map[string][]string) map[string][]string
+)
+
 const (
 	ADDITIONAL_PROPERTY SchemaValidationRule = "additional_property_not_allowed"
 	REQUIRED_ATTRIBUTE  SchemaValidationRule = "required"
 	INVALID_TYPE        SchemaValidationRule = "invalid_type"
 )
 
+// The literal name of the tag restricting a field to some audiences. See `DecoderOptions.Audiences`.
+// Fields without the tag are part of every schema.
+//
+// Example:
+//
+//	type Account struct {
+//		Email      string `json:"email"`
+//		RiskScore  int    `json:"risk_score" audience:"internal"`
+//		PartnerRef string `json:"partner_ref" audience:"internal,partner"`
+//	}
+const AUDIENCE_TAG_KEYWORD string = "audience"
+
+// The default error codes returned by the decoder, keyed by validation rule.
+//
+// This map is shared by all goroutines and must not be modified once decoding starts.
+// Use `DecoderOptions.ErrorCodes` to customize error codes per call, or `NewJSONDecoder` for a decoder with its own codes.
 var DecodingErrors = map[string]string{
 	"required":                        "REQUIRED_ATTRIBUTE_MISSING",
+	"unexpected":                      "UNEXPECTED_ERROR",
+	"invalid_model":                   "INVALID_MODEL",
 	"invalid_payload":                 "INVALID_PAYLOAD",
 	"invalid_type":                    "INVALID_TYPE",
+	"invalid_length":                  "INVALID_LENGTH",
+	"unregistered":                    "UNREGISTERED_MODEL",
+	"unregistered_format":             "UNREGISTERED_FORMAT",
+	"too_large":                       "PAYLOAD_TOO_LARGE",
+	"unsupported_media_type":          "UNSUPPORTED_MEDIA_TYPE",
+	"invalid_sort":                    "INVALID_SORT_FIELD",
+	"invalid_filter":                  "INVALID_FILTER",
 	"additional_property_not_allowed": "ADDITIONAL_PROPERTY",
 }
 
+// JSON schemas reflected from models, keyed by `schemaCacheKey`. See `InvalidateSchemaCache`.
+var schemaCache sync.Map
+
 // Replacement for the standard `json.Unmarshal` implementation.
 // It deserializes a JSON object into a Go struct. This function does not
 // Panic when the value for a JSON field is incompatible with the type set in the struct.
@@ -86,29 +191,392 @@ var DecodingErrors = map[string]string{
 // 	]
 //	*/
 func Decode(data []byte, model any, options DecoderOptions) map[string][]string {
+	return DecodeWithResult(data, model, options).Validations
+}
+
+// Decodes a JSON payload like `Decode`, returning the paths of the attributes present in the payload along with the errors found.
+// This tells apart fields explicitly set to their zero values (i.e. `false`) from the ones absent from the payload,
+// as needed for PATCH semantics. Paths are sorted alphabetically and use the notation set in `SliceIndexNotation`.
+//
+// Usage:
+//
+//	result := DecodeWithResult([]byte(`{"enabled": false}`), &settings, DecoderOptions{})
+//	result.Populated   // -> ["enabled"]
+//	result.Validations // -> {}
+func DecodeWithResult(data []byte, model any, options DecoderOptions) (result DecodeResult) {
+	return options.decode(data, model, options.populateJSON, options.checkRules)
+}
+
+// Decodes a payload into the model, running the steps shared by every format: the hooks, `Recover` and `AtomicPopulate`.
+// The model (or the new instance, see `AtomicPopulate`) is populated by `populate`,
+// which returns the paths of the attributes it populated and the errors it found.
+// The payload is then checked by `check`, if any.
+func (options DecoderOptions) decode(data []byte, model any, populate populateFunc, check checkFunc) (result DecodeResult) {
 	validations := make(map[string][]string, 0)
 
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				key := "_"
+				if p, ok := r.(*AttributePanic); ok {
+					key = p.Path
+				}
+
+				result = DecodeResult{Validations: map[string][]string{key: {options.errorCode("unexpected")}}}
+			}
+		}()
+	}
+
 	if options.BeforeHook != nil {
 		data = options.BeforeHook(data, model)
 	}
 
-	SetValuesFromBytes(model, data)
+	// The instance the payload is decoded into, when it is not the model itself. See `AtomicPopulate`.
+	var instance reflect.Value
+
+	// The paths of the attributes present in the payload
+	var populated []string
 
 	afterFunc := func(validations map[string][]string) map[string][]string {
+		if options.AfterHook != nil {
+			validations = options.AfterHook(validations)
+		}
+
+		// The decoded instance is only copied into the model once the payload is known to be valid.
+		if instance.IsValid() && len(validations) == 0 {
+			reflect.ValueOf(model).Elem().Set(instance.Elem())
+		}
+
 		return validations
 	}
 
-	if options.AfterHook != nil {
-		afterFunc = options.AfterHook
+	if err := ValidateModel(model); err != nil {
+		validations["_"] = []string{options.errorCode("invalid_model")}
+		return DecodeResult{Validations: afterFunc(validations), Populated: populated}
+	}
+
+	target := model
+	if options.AtomicPopulate {
+		instance = reflect.New(reflect.TypeOf(model).Elem())
+		target = instance.Interface()
+	}
+
+	populated, errs := populate(data, target)
+	for path, codes := range errs {
+		validations[path] = codes
 	}
 
+	if options.PopulateHook != nil {
+		options.PopulateHook(populated)
+	}
+
+	if check != nil {
+		validations = check(data, model, validations)
+	}
+
+	return DecodeResult{Validations: afterFunc(validations), Populated: populated}
+}
+
+// Populates the model with a JSON payload, leaving out the fields restricted to other audiences.
+func (options DecoderOptions) populateJSON(data []byte, model any) ([]string, map[string][]string) {
+	values := getValues()
+	defer putValues(values)
+
+	// Fields restricted to other audiences are never populated
+	_ = json.Unmarshal(data, &values)
+	dropRestrictedValues(values, reflect.TypeOf(model), options.Audiences)
+
+	populated, _ := SetValuesFromMap(model, values)
+	return populated, nil
+}
+
+// Checks a JSON payload against the schema of the model (or of its sections), as set in `Rules`.
+func (options DecoderOptions) checkRules(data []byte, model any, validations map[string][]string) map[string][]string {
 	if len(data) == 0 || len(options.Rules) == 0 {
-		return afterFunc(validations)
+		return validations
+	}
+
+	if len(options.Sections) != 0 {
+		if err := options.checkSections(data, validations); err != nil {
+			return map[string][]string{"_": {options.errorCode("invalid_payload")}}
+		}
+
+		return validations
+	}
+
+	decoded := options.ExternalSchema
+	if len(decoded) == 0 {
+		decoded, _ = cachedSchema(model, options)
+	}
+
+	if err := options.checkSchema(decoded, gojsonschema.NewBytesLoader(data), "", validations); err != nil {
+		validations["_"] = []string{options.errorCode("invalid_payload")}
+	}
+
+	return validations
+}
+
+// Checks a document against a JSON schema, adding the errors found to the validations.
+// The paths of the errors are prefixed by the given path, if any.
+func (options DecoderOptions) checkSchema(schema []byte, document gojsonschema.JSONLoader, prefix string, validations map[string][]string) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), document)
+	if err != nil {
+		return err
+	}
+
+	res := Filter(result.Errors(), func(index int, err gojsonschema.ResultError) bool {
+		return Contains(options.Rules, SchemaValidationRule(err.Type()))
+	})
+
+	for _, err := range res {
+		name := jsonAttributeName(err.String())
+		normalizedName := regexp.MustCompile(`\[\d+\]`).ReplaceAllString(name, "")
+
+		if prefix != "" {
+			normalizedName = strings.TrimSuffix(prefix+"."+normalizedName, ".")
+		}
+
+		validations[notatedPath(normalizedName)] = []string{options.errorCode(err.Type())}
+	}
+
+	return nil
+}
+
+// Checks each section of an envelope payload against the schema of its own model. See `DecoderOptions.Sections`.
+func (options DecoderOptions) checkSections(data []byte, validations map[string][]string) error {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	for name, raw := range document {
+		model, ok := options.Sections[name]
+		if !ok {
+			if Contains(options.Rules, ADDITIONAL_PROPERTY) {
+				validations[name] = []string{options.errorCode("additional_property_not_allowed")}
+			}
+
+			continue
+		}
+
+		schema, err := cachedSchema(model, options)
+		if err != nil {
+			return err
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			if err := options.checkSchema(schema, gojsonschema.NewBytesLoader(raw), name, validations); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for i, item := range items {
+			if err := options.checkSchema(schema, gojsonschema.NewBytesLoader(item), name+"["+strconv.Itoa(i)+"]", validations); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// A JSON decoder carrying its own configuration, which is applied to every payload it decodes.
+// It implements `Decoder`, so it can be registered as the decoder of a format (see `RegisterFormat`).
+//
+// The default error codes (see `DecodingErrors`) are copied into the decoder when it is created,
+// so decoders with different settings can be used by different goroutines without interfering with each other,
+// and changes made to the defaults afterwards do not affect them.
+//
+// Usage:
+//
+//	decoder := NewJSONDecoder(DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}, ErrorCodes: map[string]string{"required": "MISSING"}})
+//	errs := decoder.Decode(payload, &user)
+type JSONDecoder struct {
+	options DecoderOptions
+}
+
+// Returns a decoder configured with the given options. The lists and maps of the options are copied,
+// so modifying them afterwards does not affect the decoder. See `JSONDecoder`.
+func NewJSONDecoder(options DecoderOptions) *JSONDecoder {
+	options.Rules = append([]SchemaValidationRule(nil), options.Rules...)
+	options.JSONOverrides = append([]JSONTypeOverride(nil), options.JSONOverrides...)
+	options.Audiences = append([]string(nil), options.Audiences...)
+	options.ErrorCodes = collections.MergeMaps(DecodingErrors, options.ErrorCodes)
+	options.isolated = true
+
+	if options.Sections != nil {
+		options.Sections = collections.MergeMaps(options.Sections)
 	}
 
+	return &JSONDecoder{options: options}
+}
+
+// Returns a copy of the options of the decoder, with the error codes it uses. See `NewJSONDecoder`.
+func (d *JSONDecoder) Options() DecoderOptions {
+	options := d.options
+	options.ErrorCodes = collections.MergeMaps(d.options.ErrorCodes)
+
+	return options
+}
+
+// Decodes a JSON payload into the model using the options of the decoder. See `Decode`.
+func (d *JSONDecoder) Decode(data []byte, model any) map[string][]string {
+	return Decode(data, model, d.options)
+}
+
+// Decodes a JSON payload into the model using the options of the decoder,
+// returning the paths of the attributes present in the payload as well. See `DecodeWithResult`.
+func (d *JSONDecoder) DecodeWithResult(data []byte, model any) DecodeResult {
+	return DecodeWithResult(data, model, d.options)
+}
+
+// Decodes a JSON payload read from `r` using the options of the decoder. See `DecodeReader`.
+func (d *JSONDecoder) DecodeReader(r io.Reader, model any) map[string][]string {
+	return DecodeReader(r, model, d.options)
+}
+
+// Decodes a JSON payload into a new instance of `T`. See `Decode`.
+//
+// Usage:
+//
+//	user, errs := DecodeInto[User](payload, options)
+func DecodeInto[T any](data []byte, options DecoderOptions) (*T, map[string][]string) {
+	model := new(T)
+	return model, Decode(data, model, options)
+}
+
+// Decodes a JSON payload read from `r` (i.e. a request body). See `Decode`.
+// The payload is read into a buffer reused across calls, so it is not copied again before being decoded.
+// Hooks must not retain the payload they receive once decoding is done.
+//
+// At most `DecoderOptions.MaxBytes` bytes are read. Larger payloads are reported as `PAYLOAD_TOO_LARGE`
+// and payloads that cannot be read as `INVALID_PAYLOAD`, both under the `_` key. In either case, the model is left untouched.
+//
+// Usage:
+//
+//	errs := DecodeReader(r.Body, &user, DecoderOptions{MaxBytes: 1 << 20})
+func DecodeReader(r io.Reader, model any, options DecoderOptions) map[string][]string {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if key := options.readPayload(r, buf); key != "" {
+		return options.payloadError(key)
+	}
+
+	return Decode(buf.Bytes(), model, options)
+}
+
+// Reads a payload into the buffer, up to `MaxBytes`.
+// Returns the key of the error to report if the payload could not be read or is too large.
+func (options DecoderOptions) readPayload(r io.Reader, buf *bytes.Buffer) string {
+	reader := r
+	if options.MaxBytes > 0 {
+		// Reading one more byte than allowed tells apart payloads of exactly `MaxBytes` from larger ones
+		reader = io.LimitReader(r, options.MaxBytes+1)
+	}
+
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return "invalid_payload"
+	}
+
+	if options.MaxBytes > 0 && int64(buf.Len()) > options.MaxBytes {
+		return "too_large"
+	}
+
+	return ""
+}
+
+// Returns the JSON schema the decoder checks payloads against.
+// Only `Rules`, `JSONOverrides`, `ExternalSchema` and `Audiences` are taken from the options.
+// Reflected schemas are cached per model type and options. See `InvalidateSchemaCache`.
+//
+// Usage:
+//
+//	schema, err := JSONSchema(&User{}, DecoderOptions{Rules: []SchemaValidationRule{ADDITIONAL_PROPERTY}})
+func JSONSchema(model any, options DecoderOptions) ([]byte, error) {
+	if err := ValidateModel(model); err != nil {
+		return nil, err
+	}
+
+	if len(options.ExternalSchema) != 0 {
+		return options.ExternalSchema, nil
+	}
+
+	schema, err := cachedSchema(model, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{}, schema...), nil
+}
+
+// Clears the JSON schemas reflected by the decoder, which are cached per model type and options.
+// This is only needed if the schema of a type could change at runtime, as when its `JSONSchema` method depends on state.
+func InvalidateSchemaCache() {
+	schemaCache.Range(func(key, _ any) bool {
+		schemaCache.Delete(key)
+		return true
+	})
+}
+
+// Returns the JSON schema reflected from the model, reflecting it only once per model type and options.
+// The returned bytes are shared between calls and must not be modified.
+func cachedSchema(model any, options DecoderOptions) ([]byte, error) {
+	key := schemaCacheKey{
+		model:                reflect.TypeOf(model),
+		additionalProperties: !Contains(options.Rules, ADDITIONAL_PROPERTY),
+	}
+
+	// Most decoders set neither option, so their keys are built without formatting (and allocating) anything
+	if len(options.JSONOverrides) != 0 {
+		key.overrides = fmt.Sprint(options.JSONOverrides)
+	}
+
+	if len(options.Audiences) != 0 {
+		key.audiences = fmt.Sprint(options.Audiences)
+	}
+
+	if schema, ok := schemaCache.Load(key); ok {
+		return schema.([]byte), nil
+	}
+
+	schema, err := reflectSchema(model, options).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	cached, _ := schemaCache.LoadOrStore(key, schema)
+	return cached.([]byte), nil
+}
+
+// The options a reflected schema depends on, along with the type of the model.
+type schemaCacheKey struct {
+	model                reflect.Type
+	additionalProperties bool
+	overrides            string
+	audiences            string
+}
+
+func reflectSchema(model any, options DecoderOptions) *jsonschema.Schema {
 	reflector := new(jsonschema.Reflector)
 	reflector.RequiredFromJSONSchemaTags = true
 	reflector.AllowAdditionalProperties = !Contains(options.Rules, ADDITIONAL_PROPERTY)
+	reflector.Mapper = func(t reflect.Type) *jsonschema.Schema {
+		// Raw messages accept any JSON value
+		if t == RawMessageType {
+			return &jsonschema.Schema{}
+		}
+
+		// Nullable wrappers (i.e. `sql.NullString`) accept the values they hold or null
+		if IsNullableWrapper(t) {
+			return nullableSchema(t)
+		}
+
+		return nil
+	}
 
 	schema := reflector.Reflect(model)
 	for _, t := range options.JSONOverrides {
@@ -117,29 +585,167 @@ func Decode(data []byte, model any, options DecoderOptions) map[string][]string
 		}
 	}
 
-	decoded, _ := schema.MarshalJSON()
+	restrictAudiences(schema, reflect.TypeOf(model), options.Audiences, map[reflect.Type]bool{})
 
-	result, verr := gojsonschema.Validate(
-		gojsonschema.NewBytesLoader(decoded),
-		gojsonschema.NewBytesLoader(data),
-	)
+	return schema
+}
 
-	if verr != nil {
-		validations["_"] = []string{DecodingErrors["invalid_payload"]}
-		return afterFunc(validations)
+// Returns the schema of a nullable wrapper of the given type. See `IsNullableWrapper`.
+// The type of the values held by types implementing `Nullable` is taken from their zero value.
+func nullableSchema(t reflect.Type) *jsonschema.Schema {
+	var schema *jsonschema.Schema
+	if position, ok := nullableField(t); ok {
+		schema = heldValueSchema(baseType(t.Field(position).Type))
+	} else if nullable, ok := nullableInterface(reflect.New(t).Elem()); ok && nullable.Value() != nil {
+		schema = heldValueSchema(baseType(reflect.TypeOf(nullable.Value())))
+	} else {
+		schema = &jsonschema.Schema{}
 	}
 
-	res := Filter(result.Errors(), func(index int, err gojsonschema.ResultError) bool {
-		return Contains(options.Rules, SchemaValidationRule(err.Type()))
-	})
+	// Schemas without a type accept any value, including null
+	if schema.Type == "" {
+		return schema
+	}
 
-	for _, err := range res {
-		name := jsonAttributeName(err.String())
-		normalizedName := regexp.MustCompile(`\[\d+\]`).ReplaceAllString(name, "")
-		validations[normalizedName] = []string{DecodingErrors[err.Type()]}
+	return &jsonschema.Schema{OneOf: []*jsonschema.Schema{schema, {Type: "null"}}}
+}
+
+// Returns the schema of the scalar values of the given type, as held by nullable wrappers.
+func heldValueSchema(t reflect.Type) *jsonschema.Schema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &jsonschema.Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch {
+	case t.Kind() == reflect.String:
+		return &jsonschema.Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &jsonschema.Schema{Type: "boolean"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &jsonschema.Schema{Type: "number"}
+	case isNumericKind(t.Kind()):
+		return &jsonschema.Schema{Type: "integer"}
 	}
 
-	return afterFunc(validations)
+	return &jsonschema.Schema{}
+}
+
+// Removes the properties of the fields restricted to audiences other than the given ones (see `AUDIENCE_TAG_KEYWORD`)
+// from the definition of the given type, as well as from the definitions of the types of its fields.
+func restrictAudiences(schema *jsonschema.Schema, t reflect.Type, audiences []string, visited map[reflect.Type]bool) {
+	t = baseType(t)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		restrictAudiences(schema, t.Elem(), audiences, visited)
+	case reflect.Struct:
+		if visited[t] {
+			return
+		}
+
+		visited[t] = true
+		restrictFields(schema, schema.Definitions[t.Name()], t, audiences, visited)
+	}
+}
+
+func restrictFields(schema *jsonschema.Schema, definition *jsonschema.Schema, t reflect.Type, audiences []string, visited map[reflect.Type]bool) {
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		// The fields of embedded structs are promoted to the definition of the struct embedding them
+		if ft := baseType(sf.Type); sf.Anonymous && sf.Tag.Get("json") == "" && ft.Kind() == reflect.Struct {
+			restrictFields(schema, definition, ft, audiences, visited)
+			continue
+		}
+
+		if !isAudienceAllowed(sf, audiences) {
+			if definition != nil && definition.Properties != nil {
+				name := GetJSONTagValue(sf)
+
+				definition.Properties.Delete(name)
+				definition.Required = Filter(definition.Required, func(_ int, required string) bool { return required != name })
+			}
+
+			continue
+		}
+
+		restrictAudiences(schema, sf.Type, audiences, visited)
+	}
+}
+
+// Reports whether the field is part of the contract of any of the given audiences. See `AUDIENCE_TAG_KEYWORD`.
+func isAudienceAllowed(sf reflect.StructField, audiences []string) bool {
+	allowed := tagValues(sf, AUDIENCE_TAG_KEYWORD)
+	return len(allowed) == 0 || len(Filter(allowed, func(_ int, audience string) bool { return Contains(audiences, audience) })) != 0
+}
+
+// Removes the values of the fields restricted to audiences other than the given ones (see `AUDIENCE_TAG_KEYWORD`)
+// from a decoded payload of the given type, as well as from the values of its fields.
+func dropRestrictedValues(value any, t reflect.Type, audiences []string) {
+	t = baseType(t)
+
+	switch v := value.(type) {
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, item := range v {
+				dropRestrictedValues(item, t.Elem(), audiences)
+			}
+		}
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, item := range v {
+				dropRestrictedValues(item, t.Elem(), audiences)
+			}
+		case reflect.Struct:
+			for name, item := range v {
+				index, ok := jsonFieldIndex(t, name)
+				if !ok {
+					continue
+				}
+
+				if sf := t.FieldByIndex(index); !isAudienceAllowed(sf, audiences) {
+					delete(v, name)
+				} else {
+					dropRestrictedValues(item, sf.Type, audiences)
+				}
+			}
+		}
+	}
+}
+
+// Returns the validations of a payload that could not be decoded at all, reporting the given error under the `_` key.
+func (options DecoderOptions) payloadError(key string) map[string][]string {
+	return options.afterHook(map[string][]string{"_": {options.errorCode(key)}})
+}
+
+// Runs `AfterHook` on the validations, if set.
+func (options DecoderOptions) afterHook(validations map[string][]string) map[string][]string {
+	if options.AfterHook != nil {
+		validations = options.AfterHook(validations)
+	}
+
+	return validations
+}
+
+// Returns the error code for the given key, giving precedence to `ErrorCodes`.
+// Options created by `NewJSONDecoder` only read the codes they carry. See `JSONDecoder`.
+func (options DecoderOptions) errorCode(key string) string {
+	if code, ok := options.ErrorCodes[key]; ok || options.isolated {
+		return code
+	}
+
+	return DecodingErrors[key]
+}
+
+// Returns the error code the decoder reports for the given key (see `DecodingErrors`), giving precedence to `ErrorCodes`.
+// This allows functions built on top of the decoder to report errors of their own using the same codes.
+//
+// Usage:
+//
+//	DecoderOptions{ErrorCodes: map[string]string{"invalid_payload": "BAD_BODY"}}.ErrorCode("invalid_payload") // -> BAD_BODY
+func (options DecoderOptions) ErrorCode(key string) string {
+	return options.errorCode(key)
 }
 
 func jsonAttributeName(str string) string {
@@ -1,8 +1,17 @@
 package structs
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 )
 
 func Test_Decode(t *testing.T) {
@@ -183,7 +192,7 @@ func Test_Decode(t *testing.T) {
 			name: "after hook - set custom errors",
 			args: args{
 				data:  []byte(`}`),
-				model: 0,
+				model: &Person{},
 				options: DecoderOptions{
 					Rules: []SchemaValidationRule{INVALID_TYPE},
 					AfterHook: func(m map[string][]string) map[string][]string {
@@ -193,6 +202,33 @@ func Test_Decode(t *testing.T) {
 			},
 			want: map[string][]string{"error": {"CUSTOM_ERROR"}},
 		},
+		{
+			name: "invalid model - 1",
+			args: args{
+				data:    []byte(`{}`),
+				model:   0,
+				options: DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE}},
+			},
+			want: map[string][]string{"_": {"INVALID_MODEL"}},
+		},
+		{
+			name: "invalid model - 2",
+			args: args{
+				data:    []byte(`{}`),
+				model:   Person{},
+				options: DecoderOptions{},
+			},
+			want: map[string][]string{"_": {"INVALID_MODEL"}},
+		},
+		{
+			name: "invalid model - 3",
+			args: args{
+				data:    []byte(`{}`),
+				model:   (*Person)(nil),
+				options: DecoderOptions{},
+			},
+			want: map[string][]string{"_": {"INVALID_MODEL"}},
+		},
 		{
 			name: "json type override - 1",
 			args: args{
@@ -242,6 +278,478 @@ func Test_Decode(t *testing.T) {
 	}
 }
 
+func Test_Decode_AtomicPopulate(t *testing.T) {
+	type Person struct {
+		Id   string `json:"id" jsonschema:"required"`
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		options DecoderOptions
+		want    Person
+	}{
+		{
+			name:    "invalid payload - model is populated",
+			data:    []byte(`{"name": "Leonardo"}`),
+			options: DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}},
+			want:    Person{Id: "1", Name: "Leonardo"},
+		},
+		{
+			name:    "invalid payload - model is untouched",
+			data:    []byte(`{"name": "Leonardo"}`),
+			options: DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}, AtomicPopulate: true},
+			want:    Person{Id: "1", Name: "Leo"},
+		},
+		{
+			name:    "valid payload - model is populated",
+			data:    []byte(`{"id": "2", "name": "Leonardo"}`),
+			options: DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}, AtomicPopulate: true},
+			want:    Person{Id: "2", Name: "Leonardo"},
+		},
+		{
+			name: "after hook - ignored errors",
+			data: []byte(`{"name": "Leonardo"}`),
+			options: DecoderOptions{
+				Rules:          []SchemaValidationRule{REQUIRED_ATTRIBUTE},
+				AtomicPopulate: true,
+				AfterHook: func(m map[string][]string) map[string][]string {
+					return map[string][]string{}
+				},
+			},
+			want: Person{Name: "Leonardo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := Person{Id: "1", Name: "Leo"}
+
+			Decode(tt.data, &model, tt.options)
+			if !reflect.DeepEqual(model, tt.want) {
+				t.Errorf("Decode() model = %v, want %v", model, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Decode_PopulateHook(t *testing.T) {
+	type Settings struct {
+		Enabled bool     `json:"enabled"`
+		Theme   string   `json:"theme"`
+		Tags    []string `json:"tags"`
+	}
+
+	var populated []string
+	options := DecoderOptions{
+		PopulateHook: func(paths []string) { populated = paths },
+	}
+
+	var settings Settings
+	Decode([]byte(`{"enabled": false, "tags": ["a"], "extra": 1}`), &settings, options)
+
+	want := []string{"enabled", "tags", "tags[0]"}
+	if !reflect.DeepEqual(populated, want) {
+		t.Errorf("Decode() populated = %v, want %v", populated, want)
+	}
+}
+
+func Test_DecodeWithResult(t *testing.T) {
+	type Settings struct {
+		Enabled bool     `json:"enabled" jsonschema:"required"`
+		Theme   string   `json:"theme"`
+		Tags    []string `json:"tags"`
+	}
+
+	tests := []struct {
+		name  string
+		data  []byte
+		model any
+		want  DecodeResult
+	}{
+		{
+			name:  "explicit zero values",
+			data:  []byte(`{"enabled": false, "tags": ["a"]}`),
+			model: &Settings{},
+			want:  DecodeResult{Validations: map[string][]string{}, Populated: []string{"enabled", "tags", "tags[0]"}},
+		},
+		{
+			name:  "invalid payload",
+			data:  []byte(`{"theme": "dark"}`),
+			model: &Settings{},
+			want:  DecodeResult{Validations: map[string][]string{"enabled": {"REQUIRED_ATTRIBUTE_MISSING"}}, Populated: []string{"theme"}},
+		},
+		{
+			name:  "invalid model",
+			data:  []byte(`{"theme": "dark"}`),
+			model: Settings{},
+			want:  DecodeResult{Validations: map[string][]string{"_": {"INVALID_MODEL"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DecodeWithResult(tt.data, tt.model, DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeWithResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Decode_RawMessage(t *testing.T) {
+	type Envelope struct {
+		Type    string           `json:"type" jsonschema:"required"`
+		Data    json.RawMessage  `json:"data" jsonschema:"required"`
+		Headers *json.RawMessage `json:"headers"`
+	}
+
+	for _, data := range []string{
+		`{"type": "created", "data": {"id": [1, 2]}, "headers": ["a"]}`,
+		`{"type": "created", "data": "text", "headers": {"a": 1}}`,
+		`{"type": "created", "data": 42}`,
+	} {
+		var envelope Envelope
+		options := DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE, REQUIRED_ATTRIBUTE, ADDITIONAL_PROPERTY}}
+
+		if got := Decode([]byte(data), &envelope, options); len(got) != 0 {
+			t.Errorf("Decode() = %v, want no errors", got)
+		}
+
+		var values map[string]json.RawMessage
+		_ = json.Unmarshal([]byte(data), &values)
+
+		if !jsonEqual(envelope.Data, values["data"]) {
+			t.Errorf("Decode() data = %s, want %s", envelope.Data, values["data"])
+		}
+	}
+}
+
+func jsonEqual(a, b []byte) bool {
+	var x, y any
+	_ = json.Unmarshal(a, &x)
+	_ = json.Unmarshal(b, &y)
+	return reflect.DeepEqual(x, y)
+}
+
+func Test_Decode_Recover(t *testing.T) {
+	type Person struct {
+		Name string `json:"name"`
+	}
+
+	options := DecoderOptions{
+		Recover: true,
+		BeforeHook: func(data []byte, model any) []byte {
+			panic("before hook")
+		},
+	}
+
+	want := map[string][]string{"_": {"UNEXPECTED_ERROR"}}
+	if got := Decode([]byte(`{}`), &Person{}, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+func Test_Decode_ErrorCodes(t *testing.T) {
+	type Person struct {
+		Id string `json:"id" jsonschema:"required"`
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		code := fmt.Sprint("MISSING_", i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			options := DecoderOptions{
+				Rules:      []SchemaValidationRule{REQUIRED_ATTRIBUTE},
+				ErrorCodes: map[string]string{"required": code},
+			}
+
+			want := map[string][]string{"id": {code}}
+			if got := Decode([]byte(`{}`), &Person{}, options); !reflect.DeepEqual(got, want) {
+				t.Errorf("Decode() = %v, want %v", got, want)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func Test_DecodeInto(t *testing.T) {
+	type Person struct {
+		Id   string `json:"id" jsonschema:"required"`
+		Name string `json:"name"`
+	}
+
+	options := DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE, REQUIRED_ATTRIBUTE}}
+
+	person, errs := DecodeInto[Person]([]byte(`{"id": "1", "name": "Leonardo"}`), options)
+	if len(errs) != 0 {
+		t.Errorf("DecodeInto() errors = %v, want none", errs)
+	}
+
+	if want := (Person{Id: "1", Name: "Leonardo"}); !reflect.DeepEqual(*person, want) {
+		t.Errorf("DecodeInto() = %v, want %v", *person, want)
+	}
+
+	_, errs = DecodeInto[Person]([]byte(`{"name": 1}`), options)
+	if want := map[string][]string{"id": {"REQUIRED_ATTRIBUTE_MISSING"}, "name": {"INVALID_TYPE"}}; !reflect.DeepEqual(errs, want) {
+		t.Errorf("DecodeInto() errors = %v, want %v", errs, want)
+	}
+}
+
+func Test_Decode_ExternalSchema(t *testing.T) {
+	type Person struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	schema := []byte(`{
+		"type": "object",
+		"properties": {"id": {"type": "string"}, "name": {"type": "string"}},
+		"required": ["id"],
+		"additionalProperties": false
+	}`)
+
+	options := DecoderOptions{
+		Rules:          []SchemaValidationRule{REQUIRED_ATTRIBUTE, ADDITIONAL_PROPERTY},
+		ExternalSchema: schema,
+	}
+
+	var person Person
+	errs := Decode([]byte(`{"name": "Leonardo", "age": 30}`), &person, options)
+
+	want := map[string][]string{"id": {"REQUIRED_ATTRIBUTE_MISSING"}, "age": {"ADDITIONAL_PROPERTY"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Decode() = %v, want %v", errs, want)
+	}
+
+	if person.Name != "Leonardo" {
+		t.Errorf("Decode() populated %+v, want name to be set", person)
+	}
+
+	if got, _ := JSONSchema(&person, options); string(got) != string(schema) {
+		t.Errorf("JSONSchema() = %s, want %s", got, schema)
+	}
+
+	options.ExternalSchema = []byte(`{`)
+	if errs := Decode([]byte(`{}`), &person, options); !reflect.DeepEqual(errs, map[string][]string{"_": {"INVALID_PAYLOAD"}}) {
+		t.Errorf("Decode() = %v, want INVALID_PAYLOAD", errs)
+	}
+}
+
+func Test_JSONSchema(t *testing.T) {
+	type Person struct {
+		Id string `json:"id" jsonschema:"required"`
+	}
+
+	data, err := JSONSchema(&Person{}, DecoderOptions{Rules: []SchemaValidationRule{ADDITIONAL_PROPERTY}})
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+
+	var schema struct {
+		Definitions map[string]struct {
+			Required             []string `json:"required"`
+			AdditionalProperties bool     `json:"additionalProperties"`
+		} `json:"$defs"`
+	}
+
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("JSONSchema() returned invalid JSON: %v", err)
+	}
+
+	definition := schema.Definitions["Person"]
+	if !reflect.DeepEqual(definition.Required, []string{"id"}) || definition.AdditionalProperties {
+		t.Errorf("JSONSchema() = %s", data)
+	}
+
+	if _, err := JSONSchema(Person{}, DecoderOptions{}); err != ErrInvalidModel {
+		t.Errorf("JSONSchema() error = %v, want %v", err, ErrInvalidModel)
+	}
+}
+
+func Test_JSONSchema_Cache(t *testing.T) {
+	type Person struct {
+		Id string `json:"id" jsonschema:"required"`
+	}
+
+	InvalidateSchemaCache()
+
+	countCached := func() (n int) {
+		schemaCache.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+
+	strict := DecoderOptions{Rules: []SchemaValidationRule{ADDITIONAL_PROPERTY}}
+	overridden := DecoderOptions{JSONOverrides: []JSONTypeOverride{{GoType: "Person", JSONType: "string"}}}
+
+	first, _ := JSONSchema(&Person{}, strict)
+	first[0] = '!'
+
+	second, _ := JSONSchema(&Person{}, strict)
+	if second[0] == '!' {
+		t.Errorf("JSONSchema() returned the cached schema itself")
+	}
+
+	lenient, _ := JSONSchema(&Person{}, DecoderOptions{})
+	custom, _ := JSONSchema(&Person{}, overridden)
+	if bytes.Equal(second, lenient) || bytes.Equal(lenient, custom) {
+		t.Errorf("JSONSchema() returned the same schema for different options")
+	}
+
+	if errs := Decode([]byte(`{"id": "1", "extra": true}`), &Person{}, strict); !reflect.DeepEqual(errs, map[string][]string{"extra": {"ADDITIONAL_PROPERTY"}}) {
+		t.Errorf("Decode() = %v", errs)
+	}
+
+	if got := countCached(); got != 3 {
+		t.Errorf("cached schemas = %v, want %v", got, 3)
+	}
+
+	// Cached schemas are not marshaled again
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = cachedSchema(&Person{}, strict) }); allocs > 1 {
+		t.Errorf("cachedSchema() allocations = %v, want at most %v", allocs, 1)
+	}
+
+	InvalidateSchemaCache()
+
+	if got := countCached(); got != 0 {
+		t.Errorf("cached schemas = %v, want %v", got, 0)
+	}
+}
+
+func Test_JSONSchema_Audiences(t *testing.T) {
+	type Audit struct {
+		CreatedBy string `json:"created_by" jsonschema:"required"`
+		Reviewer  string `json:"reviewer" audience:"internal"`
+	}
+
+	type Account struct {
+		Identifiable
+		Email      string  `json:"email" jsonschema:"required"`
+		RiskScore  int     `json:"risk_score" jsonschema:"required" audience:"internal"`
+		PartnerRef string  `json:"partner_ref" audience:"internal,partner"`
+		Audits     []Audit `json:"audits" audience:"internal"`
+		Owner      *Audit  `json:"owner"`
+	}
+
+	properties := func(data []byte) map[string][]string {
+		var schema struct {
+			Definitions map[string]struct {
+				Properties map[string]any `json:"properties"`
+				Required   []string       `json:"required"`
+			} `json:"$defs"`
+		}
+
+		_ = json.Unmarshal(data, &schema)
+
+		got := map[string][]string{}
+		for name, definition := range schema.Definitions {
+			for property := range definition.Properties {
+				got[name] = append(got[name], property)
+			}
+
+			sort.Strings(got[name])
+			got[name+".required"] = definition.Required
+		}
+
+		return got
+	}
+
+	tests := []struct {
+		name      string
+		audiences []string
+		want      map[string][]string
+	}{
+		{
+			name:      "public",
+			audiences: nil,
+			want: map[string][]string{
+				"Account":          {"email", "id", "owner"},
+				"Account.required": {"email"},
+				"Audit":            {"created_by"},
+				"Audit.required":   {"created_by"},
+			},
+		},
+		{
+			name:      "partner",
+			audiences: []string{"partner"},
+			want: map[string][]string{
+				"Account":          {"email", "id", "owner", "partner_ref"},
+				"Account.required": {"email"},
+				"Audit":            {"created_by"},
+				"Audit.required":   {"created_by"},
+			},
+		},
+		{
+			name:      "internal",
+			audiences: []string{"internal"},
+			want: map[string][]string{
+				"Account":          {"audits", "email", "id", "owner", "partner_ref", "risk_score"},
+				"Account.required": {"email", "risk_score"},
+				"Audit":            {"created_by", "reviewer"},
+				"Audit.required":   {"created_by"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := JSONSchema(&Account{}, DecoderOptions{Audiences: tt.audiences})
+			if err != nil {
+				t.Fatalf("JSONSchema() error = %v", err)
+			}
+
+			if got := properties(data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSONSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Fields of other audiences are not part of the contract checked by the decoder
+	strict := DecoderOptions{Rules: []SchemaValidationRule{ADDITIONAL_PROPERTY, REQUIRED_ATTRIBUTE}}
+	payload := []byte(`{"email": "leo@example.com", "risk_score": 3}`)
+
+	if errs := Decode(payload, &Account{}, strict); !reflect.DeepEqual(errs, map[string][]string{"risk_score": {"ADDITIONAL_PROPERTY"}}) {
+		t.Errorf("Decode() = %v", errs)
+	}
+
+	strict.Audiences = []string{"internal"}
+	if errs := Decode(payload, &Account{}, strict); !reflect.DeepEqual(errs, map[string][]string{}) {
+		t.Errorf("Decode() = %v", errs)
+	}
+
+	// Fields of other audiences are never populated, whatever the rules
+	payload = []byte(`{"email": "leo@example.com", "risk_score": 3, "partner_ref": "p", "audits": [{"created_by": "a", "reviewer": "c"}]}`)
+
+	var account Account
+	if Decode(payload, &account, DecoderOptions{}); !reflect.DeepEqual(account, Account{Email: "leo@example.com"}) {
+		t.Errorf("Decode() = %+v", account)
+	}
+
+	account = Account{}
+	if Decode(payload, &account, DecoderOptions{Audiences: []string{"partner"}}); !reflect.DeepEqual(account, Account{Email: "leo@example.com", PartnerRef: "p"}) {
+		t.Errorf("Decode() = %+v", account)
+	}
+
+	account = Account{}
+	want := Account{Email: "leo@example.com", RiskScore: 3, PartnerRef: "p", Audits: []Audit{{CreatedBy: "a", Reviewer: "c"}}}
+	if Decode(payload, &account, DecoderOptions{Audiences: []string{"internal"}}); !reflect.DeepEqual(account, want) {
+		t.Errorf("Decode() = %+v, want %+v", account, want)
+	}
+
+	type Team struct {
+		Audits []Audit `json:"audits"`
+	}
+
+	var team Team
+	if Decode([]byte(`{"audits": [{"created_by": "a", "reviewer": "c"}]}`), &team, DecoderOptions{}); !reflect.DeepEqual(team, Team{Audits: []Audit{{CreatedBy: "a"}}}) {
+		t.Errorf("Decode() = %+v", team)
+	}
+}
+
 func Test_jsonAttributeName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -273,6 +781,16 @@ func Test_jsonAttributeName(t *testing.T) {
 			arg:  "resources.0: Invalid type. Expected: string, given: integer",
 			want: "resources[0]",
 		},
+		{
+			name: "invalid type - nested arrays",
+			arg:  "grid.1.2: Invalid type. Expected: number, given: string",
+			want: "grid[1][2]",
+		},
+		{
+			name: "required - nested arrays",
+			arg:  "paths.1.0: x is required",
+			want: "paths[1][0].x",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -282,3 +800,214 @@ func Test_jsonAttributeName(t *testing.T) {
 		})
 	}
 }
+
+func Benchmark_Decode(b *testing.B) {
+	data := []byte(`{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "Leonardo", "emails": ["leo@example.com"], "address": {"street": "Main St"}}`)
+	options := DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE, REQUIRED_ATTRIBUTE, ADDITIONAL_PROPERTY}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var model benchmarkModel
+		Decode(data, &model, options)
+	}
+}
+
+func Benchmark_SetValuesFromBytes(b *testing.B) {
+	data := []byte(`{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "Leonardo", "emails": ["leo@example.com"], "address": {"street": "Main St"}}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var model benchmarkModel
+		SetValuesFromBytes(&model, data)
+	}
+}
+
+func Test_DecodeReader(t *testing.T) {
+	type Person struct {
+		Name string `json:"name" jsonschema:"required"`
+	}
+
+	tests := []struct {
+		name      string
+		reader    io.Reader
+		options   DecoderOptions
+		want      map[string][]string
+		wantModel Person
+	}{
+		{
+			name:      "valid",
+			reader:    strings.NewReader(`{"name": "Leo"}`),
+			options:   DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}},
+			want:      map[string][]string{},
+			wantModel: Person{Name: "Leo"},
+		},
+		{
+			name:      "schema errors",
+			reader:    strings.NewReader(`{}`),
+			options:   DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}},
+			want:      map[string][]string{"name": {"REQUIRED_ATTRIBUTE_MISSING"}},
+			wantModel: Person{},
+		},
+		{
+			name:      "exactly max bytes",
+			reader:    strings.NewReader(`{"name": "Leo"}`),
+			options:   DecoderOptions{MaxBytes: 15},
+			want:      map[string][]string{},
+			wantModel: Person{Name: "Leo"},
+		},
+		{
+			name:      "too large",
+			reader:    strings.NewReader(`{"name": "Leonardo"}`),
+			options:   DecoderOptions{MaxBytes: 15, ErrorCodes: map[string]string{"too_large": "TOO_BIG"}},
+			want:      map[string][]string{"_": {"TOO_BIG"}},
+			wantModel: Person{},
+		},
+		{
+			name:      "unreadable",
+			reader:    iotest.ErrReader(errors.New("connection reset")),
+			options:   DecoderOptions{},
+			want:      map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel: Person{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model Person
+
+			if got := DecodeReader(tt.reader, &model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeReader() = %v, want %v", got, tt.want)
+			}
+
+			if model != tt.wantModel {
+				t.Errorf("DecodeReader() model = %v, want %v", model, tt.wantModel)
+			}
+		})
+	}
+}
+
+func Test_Decode_Sections(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" jsonschema:"required"`
+		Qty  int    `json:"qty"`
+	}
+
+	type Meta struct {
+		Source string `json:"source" jsonschema:"required"`
+	}
+
+	type Batch struct {
+		Items []Item `json:"items"`
+		Meta  Meta   `json:"meta"`
+	}
+
+	rules := []SchemaValidationRule{ADDITIONAL_PROPERTY, REQUIRED_ATTRIBUTE, INVALID_TYPE}
+	sections := map[string]any{"items": Item{}, "meta": &Meta{}}
+
+	tests := []struct {
+		name      string
+		data      string
+		want      map[string][]string
+		wantModel Batch
+	}{
+		{
+			name:      "valid",
+			data:      `{"items": [{"name": "a", "qty": 1}, {"name": "b"}], "meta": {"source": "csv"}}`,
+			want:      map[string][]string{},
+			wantModel: Batch{Items: []Item{{Name: "a", Qty: 1}, {Name: "b"}}, Meta: Meta{Source: "csv"}},
+		},
+		{
+			name: "errors per section",
+			data: `{"items": [{"name": "a"}, {"qty": "1"}, {"name": "c", "extra": 1}, 4], "meta": {}, "other": true}`,
+			want: map[string][]string{
+				"items[1].name":  {"REQUIRED_ATTRIBUTE_MISSING"},
+				"items[1].qty":   {"INVALID_TYPE"},
+				"items[2].extra": {"ADDITIONAL_PROPERTY"},
+				"items[3]":       {"INVALID_TYPE"},
+				"meta.source":    {"REQUIRED_ATTRIBUTE_MISSING"},
+				"other":          {"ADDITIONAL_PROPERTY"},
+			},
+			wantModel: Batch{},
+		},
+		{
+			name:      "invalid payload",
+			data:      `[1, 2]`,
+			want:      map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel: Batch{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model Batch
+
+			got := Decode([]byte(tt.data), &model, DecoderOptions{Rules: rules, Sections: sections})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+
+			if len(tt.want) == 0 && !reflect.DeepEqual(model, tt.wantModel) {
+				t.Errorf("Decode() model = %+v, want %+v", model, tt.wantModel)
+			}
+		})
+	}
+}
+
+func Test_NewJSONDecoder(t *testing.T) {
+	type Person struct {
+		Id   string `json:"id" jsonschema:"required"`
+		Name string `json:"name"`
+	}
+
+	errorCodes := map[string]string{"required": "MISSING"}
+	rules := []SchemaValidationRule{REQUIRED_ATTRIBUTE}
+
+	custom := NewJSONDecoder(DecoderOptions{Rules: rules, ErrorCodes: errorCodes})
+	standard := NewJSONDecoder(DecoderOptions{Rules: rules})
+
+	// Changes made to the options and defaults after the decoders are created do not affect them
+	errorCodes["required"] = "CHANGED"
+	rules[0] = ADDITIONAL_PROPERTY
+	DecodingErrors["required"] = "CHANGED"
+	defer func() { DecodingErrors["required"] = "REQUIRED_ATTRIBUTE_MISSING" }()
+
+	data := []byte(`{"name": "Leo"}`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if got, want := custom.Decode(data, &Person{}), map[string][]string{"id": {"MISSING"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("JSONDecoder.Decode() = %v, want %v", got, want)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if got, want := standard.DecodeReader(bytes.NewReader(data), &Person{}), map[string][]string{"id": {"REQUIRED_ATTRIBUTE_MISSING"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("JSONDecoder.DecodeReader() = %v, want %v", got, want)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// Decoders are decoders of formats as well
+	var _ Decoder = custom
+
+	if got := custom.Options().ErrorCodes["invalid_type"]; got != "INVALID_TYPE" {
+		t.Errorf("JSONDecoder.Options() error code = %v, want %v", got, "INVALID_TYPE")
+	}
+	// Codes added to the defaults after the decoders are created are not used by them either
+	DecodingErrors["custom"] = "CUSTOM"
+	defer delete(DecodingErrors, "custom")
+
+	if got := custom.Options().ErrorCode("custom"); got != "" {
+		t.Errorf("DecoderOptions.ErrorCode() = %v, want %v", got, "")
+	}
+	if got := (DecoderOptions{}).ErrorCode("custom"); got != "CUSTOM" {
+		t.Errorf("DecoderOptions.ErrorCode() = %v, want %v", got, "CUSTOM")
+	}
+}
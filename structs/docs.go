@@ -0,0 +1,226 @@
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// The literal name of the tag holding a human-readable description of the field.
+	//
+	// Example:
+	//
+	//	type Resource struct {
+	//		Name string `json:"name" description:"The name of the resource"`
+	//	}
+	DESCRIPTION_TAG_KEYWORD string = "description"
+
+	// The literal name of the tag holding an example value of the field.
+	//
+	// Example:
+	//
+	//	type Resource struct {
+	//		Name string `json:"name" example:"Leonardo"`
+	//	}
+	EXAMPLE_TAG_KEYWORD string = "example"
+)
+
+// Documentation of a single field of a model.
+type FieldDoc struct {
+	// The path of the field. List elements are represented by `[]`, as in `addresses[].street`.
+	Path string
+
+	// The JSON name of the field.
+	JSONName string
+
+	// The Go type of the field, as in `[]string`.
+	Type string
+
+	// The validation rules of the field.
+	Rules []string
+
+	// The value of the `description` tag.
+	Description string
+
+	// The value of the `example` tag.
+	Example string
+}
+
+var htmlDocsTemplate = template.Must(template.New("docs").Parse(`{{range .}}<section id="{{.Name}}">
+<h2>{{.Name}}</h2>
+<table>
+<thead><tr><th>Field</th><th>Type</th><th>Rules</th><th>Description</th><th>Example</th></tr></thead>
+<tbody>
+{{range .Fields}}<tr><td><code>{{.Path}}</code></td><td><code>{{.Type}}</code></td><td>{{range $i, $rule := .Rules}}{{if $i}}, {{end}}<code>{{$rule}}</code>{{end}}</td><td>{{.Description}}</td><td>{{.Example}}</td></tr>
+{{end}}</tbody>
+</table>
+</section>
+{{end}}`))
+
+// Describes every field of the given model (a struct or a pointer to one), in the order they are declared.
+// Unlike `GetAttributes`, the model is described based on its type alone, so empty slices still have their elements described.
+//
+// Usage:
+//
+//	type Person struct {
+//		Name   string   `json:"name" validate:"min=2" description:"Full name" example:"Leonardo"`
+//		Emails []string `json:"emails" validate:"each:email"`
+//	}
+//
+//	DescribeModel(Person{})
+//	// -> [
+//	//	{Path: name, JSONName: name, Type: string, Rules: [min=2], Description: Full name, Example: Leonardo},
+//	//	{Path: emails, JSONName: emails, Type: []string, Rules: [each:email]},
+//	// ]
+func DescribeModel(model any) []FieldDoc {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return []FieldDoc{}
+	}
+
+	return describeType(t, "", map[reflect.Type]bool{})
+}
+
+// Generates a Markdown document describing each of the given models, keyed by the name of their section.
+// Models are listed in alphabetical order.
+//
+// Usage:
+//
+//	GenerateMarkdown(map[string]any{"Person": Person{}})
+//	// ## Person
+//	//
+//	// | Field | Type | Rules | Description | Example |
+//	// | --- | --- | --- | --- | --- |
+//	// | `name` | `string` | `min=2` | Full name | Leonardo |
+//	// ...
+func GenerateMarkdown(models map[string]any) string {
+	var sb strings.Builder
+
+	escape := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	for i, name := range sortedModelNames(models) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "## %s\n\n", name)
+		sb.WriteString("| Field | Type | Rules | Description | Example |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+
+		for _, doc := range DescribeModel(models[name]) {
+			rules := Map(doc.Rules, func(_ int, rule string) string { return "`" + escape.Replace(rule) + "`" })
+
+			fmt.Fprintf(
+				&sb,
+				"| `%s` | `%s` | %s | %s | %s |\n",
+				doc.Path,
+				doc.Type,
+				strings.Join(rules, ", "),
+				escape.Replace(doc.Description),
+				escape.Replace(doc.Example),
+			)
+		}
+	}
+
+	return sb.String()
+}
+
+// Generates an HTML fragment describing each of the given models, keyed by the name of their section.
+// Models are listed in alphabetical order. All values are escaped.
+// See `GenerateMarkdown`.
+func GenerateHTML(models map[string]any) string {
+	type section struct {
+		Name   string
+		Fields []FieldDoc
+	}
+
+	sections := Map(sortedModelNames(models), func(_ int, name string) section {
+		return section{Name: name, Fields: DescribeModel(models[name])}
+	})
+
+	var buf bytes.Buffer
+	_ = htmlDocsTemplate.Execute(&buf, sections)
+
+	return buf.String()
+}
+
+func describeType(t reflect.Type, scope string, visiting map[reflect.Type]bool) (docs []FieldDoc) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Recursive types are only described once
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return docs
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		if sf.Anonymous {
+			docs = append(docs, describeType(sf.Type, scope, visiting)...)
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := GetJSONTagValue(sf)
+		if name == "-" {
+			continue
+		}
+
+		path := strings.TrimPrefix(scope+"."+name, ".")
+
+		rules := []string{}
+		if _, ok := sf.Tag.Lookup(VALIDATION_TAG_KEYWORD); ok {
+			rules = GetTagRules(sf, VALIDATION_TAG_KEYWORD)
+		}
+
+		docs = append(docs, FieldDoc{
+			Path:        path,
+			JSONName:    name,
+			Type:        sf.Type.String(),
+			Rules:       append([]string{}, rules...),
+			Description: sf.Tag.Get(DESCRIPTION_TAG_KEYWORD),
+			Example:     sf.Tag.Get(EXAMPLE_TAG_KEYWORD),
+		})
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if ft == RawMessageType || ft == uuidType {
+			continue
+		}
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			docs = append(docs, describeType(ft, path, visiting)...)
+		case reflect.Slice, reflect.Array:
+			docs = append(docs, describeType(ft.Elem(), path+"[]", visiting)...)
+		}
+	}
+
+	return docs
+}
+
+func sortedModelNames(models map[string]any) []string {
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
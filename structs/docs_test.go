@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type docsAddress struct {
+	Street string `json:"street" validate:"min=3" description:"Street | number"`
+}
+
+type docsPerson struct {
+	Identifiable
+	Name      string        `json:"name" validate:"min=2,max=8" description:"Full name" example:"Leonardo"`
+	Emails    []string      `json:"emails" validate:"each:email"`
+	Addresses []docsAddress `json:"addresses"`
+	Manager   *docsPerson   `json:"manager"`
+	Secret    string        `json:"-"`
+	internal  string
+}
+
+func Test_DescribeModel(t *testing.T) {
+	got := DescribeModel(&docsPerson{})
+
+	want := []FieldDoc{
+		{Path: "id", JSONName: "id", Type: "string", Rules: []string{}},
+		{Path: "name", JSONName: "name", Type: "string", Rules: []string{"min=2", "max=8"}, Description: "Full name", Example: "Leonardo"},
+		{Path: "emails", JSONName: "emails", Type: "[]string", Rules: []string{"each:email"}},
+		{Path: "addresses", JSONName: "addresses", Type: "[]structs.docsAddress", Rules: []string{}},
+		{Path: "addresses[].street", JSONName: "street", Type: "string", Rules: []string{"min=3"}, Description: "Street | number"},
+		{Path: "manager", JSONName: "manager", Type: "*structs.docsPerson", Rules: []string{}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DescribeModel() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_GenerateMarkdown(t *testing.T) {
+	got := GenerateMarkdown(map[string]any{"Person": docsPerson{}, "Address": docsAddress{}})
+
+	want := strings.Join([]string{
+		"## Address",
+		"",
+		"| Field | Type | Rules | Description | Example |",
+		"| --- | --- | --- | --- | --- |",
+		"| `street` | `string` | `min=3` | Street \\| number |  |",
+		"",
+		"## Person",
+		"",
+	}, "\n")
+
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("GenerateMarkdown() = %v, want prefix %v", got, want)
+	}
+
+	if !strings.Contains(got, "| `name` | `string` | `min=2`, `max=8` | Full name | Leonardo |\n") {
+		t.Errorf("GenerateMarkdown() = %v, missing name field", got)
+	}
+}
+
+func Test_GenerateHTML(t *testing.T) {
+	type Note struct {
+		Text string `json:"text" validate:"min=1" description:"<b>Body</b>"`
+	}
+
+	got := GenerateHTML(map[string]any{"Note": Note{}})
+
+	for _, want := range []string{`<section id="Note">`, "<code>text</code>", "<code>min=1</code>", "&lt;b&gt;Body&lt;/b&gt;"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GenerateHTML() = %v, missing %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// The literal name of the fixed-width tag as it'll appear in the struct.
+	//
+	// Attributes:
+	//	- start: position (0-based) of the first character of the field in the record. Required.
+	//	- len: number of characters the field takes in the record. Required.
+	//	- pad: side where the padding is added, either `left` or `right`.
+	// Defaults to `left` for numbers and `right` for everything else.
+	//	- fill: character used for padding.
+	// Defaults to `0` for numbers and a blank space for everything else.
+	//	- decimals: number of implied decimal places of a float field.
+	//
+	// Example:
+	//
+	//	type Payment struct {
+	//		Bank   string  `json:"bank" fixed:"start=0,len=3"`
+	//		Amount float64 `json:"amount" fixed:"start=3,len=15,decimals=2"`
+	//	}
+	FIXED_WIDTH_TAG_KEYWORD string = "fixed"
+)
+
+var (
+	// Returned when the fixed-width tag of a field is malformed.
+	ErrInvalidFixedWidthTag = errors.New("invalid fixed-width tag")
+
+	// Returned when the value of a field does not fit in the length set in its fixed-width tag.
+	ErrFixedWidthOverflow = errors.New("value exceeds fixed-width field length")
+)
+
+// Position of a field within a fixed-width record.
+type FixedWidthColumn struct {
+	// The JSON name of the field.
+	Path string
+
+	// Position (0-based) of the first character of the field in the record.
+	Start int
+
+	// Number of characters the field takes in the record.
+	Length int
+}
+
+type fixedWidthField struct {
+	field    reflect.StructField
+	start    int
+	length   int
+	padLeft  bool
+	fill     rune
+	decimals int
+}
+
+// Encodes the given struct into a fixed-width (positional) record, such as the ones found in CNAB files.
+// Only the fields containing the `fixed` tag are encoded. Positions not covered by any field are left blank.
+//
+// Usage:
+//
+//	type Payment struct {
+//		Bank   string  `json:"bank" fixed:"start=0,len=3"`
+//		Name   string  `json:"name" fixed:"start=3,len=10"`
+//		Amount float64 `json:"amount" fixed:"start=13,len=8,decimals=2"`
+//	}
+//
+//	EncodeFixedWidth(Payment{Bank: "341", Name: "Leonardo", Amount: 12.5}) // -> "341Leonardo  00001250", nil
+func EncodeFixedWidth(model any) (string, error) {
+	rv, _ := PointerElement(reflect.ValueOf(model))
+	if rv.Kind() != reflect.Struct {
+		return "", ErrInvalidModel
+	}
+
+	fields, err := fixedWidthFields(rv.Type())
+	if err != nil {
+		return "", err
+	}
+
+	record := []rune(strings.Repeat(" ", fixedWidthRecordLength(fields)))
+
+	for _, f := range fields {
+		value, err := PointerElement(rv.FieldByIndex(f.field.Index))
+		if err != nil {
+			// Nil pointers are left blank
+			continue
+		}
+
+		text, err := f.format(value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", GetJSONTagValue(f.field), err)
+		}
+
+		copy(record[f.start:], []rune(text))
+	}
+
+	return string(record), nil
+}
+
+// Populates the given struct pointer with the values found in a fixed-width (positional) record.
+// See `EncodeFixedWidth`.
+//
+// Returns the errors found for each field, keyed by their JSON names:
+//   - `INVALID_LENGTH`: the record is too short to contain the field.
+//   - `INVALID_TYPE`: the value of the field could not be converted to the type of the struct field.
+//
+// Usage:
+//
+//	var payment Payment
+//	DecodeFixedWidth("341Leonardo  0000125x", &payment) // -> {"amount": ["INVALID_TYPE"]}
+func DecodeFixedWidth(line string, model any) (validations map[string][]string) {
+	validations = make(map[string][]string)
+
+	if err := ValidateModel(model); err != nil {
+		validations["_"] = []string{DecodingErrors["invalid_model"]}
+		return validations
+	}
+
+	rv := reflect.ValueOf(model).Elem()
+
+	fields, err := fixedWidthFields(rv.Type())
+	if err != nil {
+		validations["_"] = []string{DecodingErrors["invalid_model"]}
+		return validations
+	}
+
+	record := []rune(line)
+
+	for _, f := range fields {
+		name := GetJSONTagValue(f.field)
+
+		if f.start+f.length > len(record) {
+			validations[name] = []string{DecodingErrors["invalid_length"]}
+			continue
+		}
+
+		if err := f.parse(string(record[f.start:f.start+f.length]), rv.FieldByIndex(f.field.Index)); err != nil {
+			validations[name] = []string{DecodingErrors["invalid_type"]}
+		}
+	}
+
+	return validations
+}
+
+// Returns the position of each of the fixed-width fields of the given struct, in the order they are declared.
+//
+// Usage:
+//
+//	FixedWidthLayout(Payment{})
+//	// -> [{Path: bank, Start: 0, Length: 3}, {Path: name, Start: 3, Length: 10}, {Path: amount, Start: 13, Length: 8}]
+func FixedWidthLayout(model any) ([]FixedWidthColumn, error) {
+	rv, _ := PointerElement(reflect.ValueOf(model))
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrInvalidModel
+	}
+
+	fields, err := fixedWidthFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	return Map(fields, func(_ int, f fixedWidthField) FixedWidthColumn {
+		return FixedWidthColumn{Path: GetJSONTagValue(f.field), Start: f.start, Length: f.length}
+	}), nil
+}
+
+// Returns the fields of the given struct type that contain the fixed-width tag, including the ones of embedded structs.
+func fixedWidthFields(t reflect.Type) (fields []fixedWidthField, err error) {
+	for _, sf := range reflect.VisibleFields(t) {
+		if sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+
+		if _, ok := sf.Tag.Lookup(FIXED_WIDTH_TAG_KEYWORD); !ok {
+			continue
+		}
+
+		f, err := newFixedWidthField(sf)
+		if err != nil {
+			return fields, err
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+func newFixedWidthField(sf reflect.StructField) (f fixedWidthField, err error) {
+	tag := GetTag(sf, FIXED_WIDTH_TAG_KEYWORD)
+	invalid := fmt.Errorf("%w: field %s", ErrInvalidFixedWidthTag, sf.Name)
+
+	f = fixedWidthField{field: sf, fill: ' '}
+
+	kind := sf.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = sf.Type.Elem().Kind()
+	}
+
+	if isNumericKind(kind) {
+		f.padLeft = true
+		f.fill = '0'
+	}
+
+	if f.start, err = strconv.Atoi(tag["start"]); err != nil || f.start < 0 {
+		return f, invalid
+	}
+
+	if f.length, err = strconv.Atoi(tag["len"]); err != nil || f.length <= 0 {
+		return f, invalid
+	}
+
+	switch tag["pad"] {
+	case "":
+	case "left":
+		f.padLeft = true
+	case "right":
+		f.padLeft = false
+	default:
+		return f, invalid
+	}
+
+	if fill, ok := tag["fill"]; ok {
+		runes := []rune(fill)
+		if len(runes) != 1 {
+			return f, invalid
+		}
+
+		f.fill = runes[0]
+	}
+
+	if decimals, ok := tag["decimals"]; ok {
+		if f.decimals, err = strconv.Atoi(decimals); err != nil || f.decimals < 0 {
+			return f, invalid
+		}
+	}
+
+	return f, nil
+}
+
+// Returns the text representation of the value, padded to the length of the field.
+func (f fixedWidthField) format(value reflect.Value) (string, error) {
+	var text string
+
+	switch value.Kind() {
+	case reflect.String:
+		text = value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		text = strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		scaled := math.Round(value.Float() * math.Pow10(f.decimals))
+		text = strconv.FormatFloat(scaled, 'f', 0, 64)
+	default:
+		return "", fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	padding := f.length - len([]rune(text))
+	if padding < 0 {
+		return "", ErrFixedWidthOverflow
+	}
+
+	// Signs go before the padding, i.e. -0042
+	if f.padLeft && f.fill == '0' && strings.HasPrefix(text, "-") {
+		return "-" + strings.Repeat("0", padding) + text[1:], nil
+	}
+
+	if f.padLeft {
+		return strings.Repeat(string(f.fill), padding) + text, nil
+	}
+
+	return text + strings.Repeat(string(f.fill), padding), nil
+}
+
+// Converts the text found in the record and sets it as the value of the struct field.
+func (f fixedWidthField) parse(text string, field reflect.Value) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		field = field.Elem()
+	}
+
+	if f.padLeft {
+		text = strings.TrimLeft(text, string(f.fill))
+	} else {
+		text = strings.TrimRight(text, string(f.fill))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+		return nil
+	}
+
+	// Numbers consisting only of padding are zero
+	text = strings.TrimSpace(text)
+	if text == "" || text == "-" {
+		text = "0"
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(float64(n) / math.Pow10(f.decimals))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+func fixedWidthRecordLength(fields []fixedWidthField) (length int) {
+	for _, f := range fields {
+		if end := f.start + f.length; end > length {
+			length = end
+		}
+	}
+
+	return length
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
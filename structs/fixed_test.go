@@ -0,0 +1,129 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fixedWidthPayment struct {
+	Bank   string  `json:"bank" fixed:"start=0,len=3"`
+	Name   string  `json:"name" fixed:"start=3,len=10"`
+	Amount float64 `json:"amount" fixed:"start=13,len=8,decimals=2"`
+	Code   *int    `json:"code" fixed:"start=21,len=4,pad=right,fill= "`
+	Notes  string  `json:"notes"`
+}
+
+func Test_EncodeFixedWidth(t *testing.T) {
+	code := 42
+
+	tests := []struct {
+		name    string
+		model   any
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "payment - 1",
+			model: fixedWidthPayment{Bank: "341", Name: "Leonardo", Amount: 12.5, Code: &code},
+			want:  "341Leonardo  0000125042  ",
+		},
+		{
+			name:  "payment - 2",
+			model: &fixedWidthPayment{Bank: "1", Amount: -3},
+			want:  "1            -0000300    ",
+		},
+		{
+			name:    "overflow - 1",
+			model:   fixedWidthPayment{Name: "Leonardo Ribeiro"},
+			wantErr: ErrFixedWidthOverflow,
+		},
+		{
+			name: "invalid tag - 1",
+			model: struct {
+				Name string `fixed:"start=0"`
+			}{},
+			wantErr: ErrInvalidFixedWidthTag,
+		},
+		{
+			name:    "invalid model - 1",
+			model:   "341",
+			wantErr: ErrInvalidModel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeFixedWidth(tt.model)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EncodeFixedWidth() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("EncodeFixedWidth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DecodeFixedWidth(t *testing.T) {
+	code := 42
+
+	tests := []struct {
+		name   string
+		line   string
+		want   fixedWidthPayment
+		errors map[string][]string
+	}{
+		{
+			name:   "payment - 1",
+			line:   "341Leonardo  0000125042  ",
+			want:   fixedWidthPayment{Bank: "341", Name: "Leonardo", Amount: 12.5, Code: &code},
+			errors: map[string][]string{},
+		},
+		{
+			name:   "payment - 2",
+			line:   "341Leonardo  0000125x",
+			want:   fixedWidthPayment{Bank: "341", Name: "Leonardo"},
+			errors: map[string][]string{"amount": {"INVALID_TYPE"}, "code": {"INVALID_LENGTH"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got fixedWidthPayment
+
+			if errs := DecodeFixedWidth(tt.line, &got); !reflect.DeepEqual(errs, tt.errors) {
+				t.Errorf("DecodeFixedWidth() = %v, want %v", errs, tt.errors)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeFixedWidth() populated %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DecodeFixedWidth_InvalidModel(t *testing.T) {
+	want := map[string][]string{"_": {"INVALID_MODEL"}}
+
+	if got := DecodeFixedWidth("341", fixedWidthPayment{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeFixedWidth() = %v, want %v", got, want)
+	}
+}
+
+func Test_FixedWidthLayout(t *testing.T) {
+	got, err := FixedWidthLayout(&fixedWidthPayment{})
+	if err != nil {
+		t.Fatalf("FixedWidthLayout() error = %v", err)
+	}
+
+	want := []FixedWidthColumn{
+		{Path: "bank", Start: 0, Length: 3},
+		{Path: "name", Start: 3, Length: 10},
+		{Path: "amount", Start: 13, Length: 8},
+		{Path: "code", Start: 21, Length: 4},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FixedWidthLayout() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type FlattenOptions struct {
+	// When set, any fields not containing at least one of these tags will be ignored.
+	FilterTags []string
+
+	// When set, any fields contained in this list will be ignored.
+	// Note that the name of the field should be the one defined in the struct.
+	IgnoredFields []string
+
+	// The notation used for the keys of the map. Defaults to `SliceIndexNotation` when empty.
+	Notation PathNotation
+
+	// When set, values that are nil or equal to the zero value of their type are left out.
+	OmitZero bool
+}
+
+// Flattens the model into a map of its leaf values, keyed by their paths.
+// Keys use the same notation as `StructAttribute.FullName()`, unless `FlattenOptions.Notation` is set.
+// This could be used for audit logs or for indexing documents in search engines that expect flat fields.
+//
+// As in `Snapshot`, only leaf attributes are included, meaning a slice of strings will produce
+// one entry per element instead of an entry for the slice itself. Empty slices and maps are leaves themselves.
+//
+// Usage:
+//
+//	type Bucket struct {
+//		Owner Author `json:"owner"`
+//		Files []File `json:"files"`
+//	}
+//
+//	ToFlatMap(Bucket{Owner: Author{Id: "1"}, Files: []File{{Dir: "/tmp"}}}, FlattenOptions{})
+//	// -> {"owner.id": "1", "files[0].dir": "/tmp"}
+//
+//	ToFlatMap(bucket, FlattenOptions{Notation: DOT_NOTATION})
+//	// -> {"owner.id": "1", "files.0.dir": "/tmp"}
+func ToFlatMap(model any, opts FlattenOptions) map[string]any {
+	flattened := map[string]any{}
+
+	attributes := leafAttributes(reflect.ValueOf(model), AttributeOptions{
+		FilterTags:    opts.FilterTags,
+		IgnoredFields: opts.IgnoredFields,
+	})
+
+	for _, attr := range attributes {
+		if opts.OmitZero && (!attr.Value.IsValid() || attr.Value.IsZero()) {
+			continue
+		}
+
+		path := attr.FullName()
+		if opts.Notation != "" {
+			path = FormatPath(ParsePath(attr.bracketName()), opts.Notation)
+		}
+
+		flattened[path] = attributeValue(attr.Value)
+	}
+
+	return flattened
+}
+
+// Populates the given struct pointer with the values of a flattened map, keyed by their paths,
+// which makes it the inverse of `ToFlatMap`. Intermediate structs, pointers, maps and slices are created as needed.
+// Values are converted to the types of their fields as done by `SetValueByPath`, so that the (string) values
+// of a form submission can be used as well.
+//
+// Paths are set in alphabetical order and the first error found is returned, after all the other paths are set.
+// Returns `ErrInvalidModel` if the entity is not a non-nil pointer to a struct.
+//
+// Since the paths of a form submission are chosen by the client, their positions are checked before anything is set:
+// nothing is set if any of them is not lower than `MAX_SLICE_LENGTH` (`ErrSliceTooLong`).
+// Negative numbers are not positions (see `ParsePath`), so paths like `articles[-1]` are not found.
+//
+// Usage:
+//
+//	var bucket Bucket
+//	err := FromFlatMap(&bucket, map[string]any{"owner.id": "1", "files[0].dir": "/tmp"})
+//	// -> Bucket{Owner: Author{Id: "1"}, Files: []File{{Dir: "/tmp"}}}
+func FromFlatMap(model any, values map[string]any) error {
+	if err := ValidateModel(model); err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		for _, segment := range ParsePath(path) {
+			if segment.IsIndex && segment.Index >= MAX_SLICE_LENGTH {
+				return fmt.Errorf("%w: %s", ErrSliceTooLong, path)
+			}
+		}
+	}
+
+	var firstErr error
+	for _, path := range paths {
+		if err := SetValueByPath(model, path, values[path]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
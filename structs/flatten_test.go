@@ -0,0 +1,182 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_ToFlatMap(t *testing.T) {
+	type File struct {
+		Dir  string `json:"dir" index:""`
+		Size int    `json:"size"`
+	}
+
+	type Bucket struct {
+		Name   string            `json:"name" index:""`
+		Owner  *Author           `json:"owner" index:""`
+		Files  []File            `json:"files" index:""`
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+		Secret string            `json:"secret"`
+	}
+
+	bucket := Bucket{
+		Name:   "assets",
+		Owner:  &Author{Id: "1"},
+		Files:  []File{{Dir: "/tmp", Size: 2}, {Dir: "/var"}},
+		Tags:   []string{},
+		Labels: map[string]string{"env": "prod"},
+		Secret: "s3cr3t",
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		opts  FlattenOptions
+		want  map[string]any
+	}{
+		{
+			name:  "all fields",
+			model: bucket,
+			opts:  FlattenOptions{},
+			want: map[string]any{
+				"name":          "assets",
+				"owner.id":      "1",
+				"files[0].dir":  "/tmp",
+				"files[0].size": 2,
+				"files[1].dir":  "/var",
+				"files[1].size": 0,
+				"tags":          []string{},
+				"labels.env":    "prod",
+				"secret":        "s3cr3t",
+			},
+		},
+		{
+			name:  "tagged fields",
+			model: &bucket,
+			opts:  FlattenOptions{FilterTags: []string{"index"}},
+			// Fields without the tag are left out, so the owner has no attributes of its own
+			want: map[string]any{
+				"name":         "assets",
+				"owner":        Author{Id: "1"},
+				"files[0].dir": "/tmp",
+				"files[1].dir": "/var",
+			},
+		},
+		{
+			name:  "dot notation without zero values",
+			model: bucket,
+			opts:  FlattenOptions{Notation: DOT_NOTATION, OmitZero: true, IgnoredFields: []string{"Secret"}},
+			want: map[string]any{
+				"name":         "assets",
+				"owner.id":     "1",
+				"files.0.dir":  "/tmp",
+				"files.0.size": 2,
+				"files.1.dir":  "/var",
+				"tags":         []string{},
+				"labels.env":   "prod",
+			},
+		},
+		{
+			name:  "nil pointer",
+			model: Bucket{},
+			opts:  FlattenOptions{OmitZero: true},
+			want:  map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToFlatMap(tt.model, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToFlatMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FromFlatMap(t *testing.T) {
+	type Article struct {
+		Title string `json:"title"`
+		Views int    `json:"views"`
+	}
+
+	type Blog struct {
+		Name     string            `json:"name"`
+		Owner    *Author           `json:"owner"`
+		Articles []Article         `json:"articles"`
+		Labels   map[string]string `json:"labels"`
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]any
+		want    Blog
+		wantErr error
+	}{
+		{
+			name:   "nested values",
+			values: map[string]any{"name": "Notes", "owner.id": "1", "articles[1].title": "x", "articles[0].views": "12", "labels.lang": "en"},
+			want: Blog{
+				Name:     "Notes",
+				Owner:    &Author{Id: "1"},
+				Articles: []Article{{Views: 12}, {Title: "x"}},
+				Labels:   map[string]string{"lang": "en"},
+			},
+		},
+		{
+			name:    "invalid values",
+			values:  map[string]any{"name": "Notes", "articles[0].views": "many", "summary": "?"},
+			want:    Blog{Name: "Notes", Articles: []Article{{}}},
+			wantErr: errors.New("articles[0].views: cannot convert string to int"),
+		},
+		{
+			name:   "empty",
+			values: map[string]any{},
+			want:   Blog{},
+		},
+		{
+			name:    "negative index",
+			values:  map[string]any{"name": "Notes", "articles[-1].title": "x"},
+			want:    Blog{Name: "Notes"},
+			wantErr: errors.New("attribute not found: articles[-1].title"),
+		},
+		{
+			name:    "slice too long",
+			values:  map[string]any{"name": "Notes", "articles[50000000].title": "x"},
+			want:    Blog{},
+			wantErr: errors.New("slice length exceeds the maximum: articles[50000000].title"),
+		},
+		{
+			name:    "slice too long (dot notation)",
+			values:  map[string]any{"articles.10000.title": "x"},
+			want:    Blog{},
+			wantErr: errors.New("slice length exceeds the maximum: articles.10000.title"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blog Blog
+			err := FromFlatMap(&blog, tt.values)
+
+			if (err == nil) != (tt.wantErr == nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("FromFlatMap() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(blog, tt.want) {
+				t.Errorf("FromFlatMap() = %+v, want %+v", blog, tt.want)
+			}
+		})
+	}
+
+	// Flattened maps can be turned back into the models they came from
+	blog := Blog{Name: "Notes", Owner: &Author{Id: "1"}, Articles: []Article{{Title: "a", Views: 1}, {Title: "b"}}, Labels: map[string]string{"lang": "en"}}
+
+	var restored Blog
+	if err := FromFlatMap(&restored, ToFlatMap(blog, FlattenOptions{})); err != nil || !reflect.DeepEqual(restored, blog) {
+		t.Errorf("FromFlatMap() = %+v, %v, want %+v", restored, err, blog)
+	}
+
+	if err := FromFlatMap(blog, map[string]any{}); !errors.Is(err, ErrInvalidModel) {
+		t.Errorf("FromFlatMap() error = %v, want %v", err, ErrInvalidModel)
+	}
+}
@@ -2,41 +2,35 @@ package structs
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+
+	"github.com/oleoneto/go-structs/collections"
 )
 
 // Check if the element is contained within the given collection.
+// See `collections.Contains`.
 //
 // Example:
 //
 //	Contains([]string{"hello", "world", "!"}, "world") // -> true
 func Contains[T comparable](collection []T, element T) bool {
-	for _, item := range collection {
-		if item == element {
-			return true
-		}
-	}
-
-	return false
+	return collections.Contains(collection, element)
 }
 
 // Applies a `transformer` function to every element in a list.
+// See `collections.Map`.
 //
 // Usage:
 //
 //	Map([]int{3, 4}, func(index, n int) int { return n * n }) // -> [9, 16]
 func Map[A any, B any](collection []A, transformFunc func(int, A) B) []B {
-	result := make([]B, len(collection))
-
-	for index, item := range collection {
-		result[index] = transformFunc(index, item)
-	}
-
-	return result
+	return collections.Map(collection, transformFunc)
 }
 
 // Filters a collection and returns only the elements
-// that match the provided `inclusionTest`.
+// that match the provided `inclusionTest`. See `collections.Filter`.
 //
 // Example:
 //
@@ -44,19 +38,31 @@ func Map[A any, B any](collection []A, transformFunc func(int, A) B) []B {
 //
 // 	Filter([]int{16, 9, 25}, func(i, n int) bool { return n%2 == 0 }) // -> [16]
 func Filter[T any](collection []T, inclusionTest func(int, T) bool) []T {
-	result := make([]T, 0)
+	return collections.Filter(collection, inclusionTest)
+}
 
-	for index, item := range collection {
-		if inclusionTest(index, item) {
-			result = append(result, item)
-		}
+// MARK: - Reflection Helpers
+
+// Returned when a model is not a non-nil pointer to a struct.
+var ErrInvalidModel = errors.New("model must be a non-nil pointer to a struct")
+
+// Returns `ErrInvalidModel` unless the model is a non-nil pointer to a struct.
+//
+// Usage:
+//
+//	ValidateModel(&User{})          // -> nil
+//	ValidateModel(User{})           // -> ErrInvalidModel
+//	ValidateModel((*User)(nil))     // -> ErrInvalidModel
+func ValidateModel(model any) error {
+	rv := reflect.ValueOf(model)
+
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidModel
 	}
 
-	return result
+	return nil
 }
 
-// MARK: - Reflection Helpers
-
 func PointerElement(rv reflect.Value) (reflect.Value, error) {
 	el := rv
 
@@ -71,6 +77,149 @@ func PointerElement(rv reflect.Value) (reflect.Value, error) {
 	return el, nil
 }
 
+// Returned by `ConvertAssign` when the destination is not a non-nil pointer.
+var ErrInvalidDestination = errors.New("destination must be a non-nil pointer")
+
+// Returns the value found at the given path (see `ParsePath`), following fields by their JSON names,
+// the elements of slices/arrays by their positions and the entries of maps by their keys.
+// Pointers and interfaces along the path are dereferenced. The returned value itself is not.
+//
+// Unlike `GetAttributeByPath`, any value can be reached, including the elements of a slice/array of structs.
+// Values reached through a map are not settable, since map entries are not addressable.
+//
+// Returns `ErrAttributeNotFound` if the path does not match any value, including when a nil pointer is found along the path.
+//
+// Usage:
+//
+//	rv, err := FieldByPath(reflect.ValueOf(&library), "articles[1].authors[0]")
+//	rv.Interface() // -> Author{Id: "7"}
+//
+//	rv, _ = FieldByPath(reflect.ValueOf(&library), "articles[1].title")
+//	rv.SetString("Notes") // -> library.Articles[1].Title == "Notes"
+func FieldByPath(rv reflect.Value, path string) (reflect.Value, error) {
+	notFound := fmt.Errorf("%w: %s", ErrAttributeNotFound, path)
+
+	segments := ParsePath(path)
+	if len(segments) == 0 {
+		return reflect.Value{}, notFound
+	}
+
+	for _, segment := range segments {
+		for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+			if rv.IsNil() {
+				return reflect.Value{}, notFound
+			}
+
+			rv = rv.Elem()
+		}
+
+		switch rv.Kind() {
+		case reflect.Struct:
+			index, ok := jsonFieldIndex(rv.Type(), segment.Name)
+			if segment.IsIndex || !ok {
+				return reflect.Value{}, notFound
+			}
+
+			// Fails on nil embedded pointers
+			field, err := rv.FieldByIndexErr(index)
+			if err != nil {
+				return reflect.Value{}, notFound
+			}
+
+			rv = field
+		case reflect.Slice, reflect.Array:
+			if !segment.IsIndex || segment.Index < 0 || segment.Index >= rv.Len() {
+				return reflect.Value{}, notFound
+			}
+
+			rv = rv.Index(segment.Index)
+		case reflect.Map:
+			name := segment.Name
+			if segment.IsIndex {
+				name = strconv.Itoa(segment.Index)
+			}
+
+			key, err := convertValue(name, rv.Type().Key())
+			if err != nil {
+				return reflect.Value{}, notFound
+			}
+
+			if rv = rv.MapIndex(key); !rv.IsValid() {
+				return reflect.Value{}, notFound
+			}
+		default:
+			return reflect.Value{}, notFound
+		}
+	}
+
+	return rv, nil
+}
+
+// Returns the value itself if it is addressable, or an addressable copy of it otherwise,
+// so that methods with pointer receivers can be called on values read from maps or from structs passed by value.
+// Changes made to a copy are not reflected in the original value.
+//
+// Usage:
+//
+//	rv := EnsureAddressable(reflect.ValueOf(Author{Id: "1"}))
+//	rv.CanAddr() // -> true
+func EnsureAddressable(rv reflect.Value) reflect.Value {
+	if !rv.IsValid() || rv.CanAddr() {
+		return rv
+	}
+
+	addressable := reflect.New(rv.Type()).Elem()
+	addressable.Set(rv)
+
+	return addressable
+}
+
+// Returns a new settable value of the given type, with all of its pointer layers allocated.
+// This means a `*Author` holds a pointer to an empty `Author`, instead of a nil pointer.
+//
+// Usage:
+//
+//	rv := NewElem(reflect.TypeOf(&Author{}))
+//	rv.IsNil()             // -> false
+//	rv.Elem().Interface() // -> Author{}
+func NewElem(t reflect.Type) reflect.Value {
+	rv := reflect.New(t).Elem()
+
+	for el := rv; el.Kind() == reflect.Pointer; el = el.Elem() {
+		el.Set(reflect.New(el.Type().Elem()))
+	}
+
+	return rv
+}
+
+// Converts the source value to the type pointed to by the destination and assigns it, as done by `SetValueByPath`:
+// numbers are converted between numeric types (as long as they fit), strings are parsed into numbers and booleans,
+// nullable wrappers are scanned, and anything else is converted through its JSON representation. A nil source assigns the zero value.
+//
+// Returns `ErrInvalidDestination` if the destination is not a non-nil pointer.
+//
+// Usage:
+//
+//	var age int
+//	ConvertAssign(&age, "42") // -> age == 42
+//
+//	var tags []string
+//	ConvertAssign(&tags, []any{"a", "b"}) // -> tags == ["a", "b"]
+func ConvertAssign(dst any, src any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrInvalidDestination
+	}
+
+	converted, err := convertValue(src, rv.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	rv.Elem().Set(converted)
+	return nil
+}
+
 func stringPointer(v string) *string {
 	return &v
 }
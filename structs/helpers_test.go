@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -148,3 +149,109 @@ func Test_PointerElement_WhenNil(t *testing.T) {
 		t.Errorf(`expected an error but got nil`)
 	}
 }
+
+func Test_FieldByPath(t *testing.T) {
+	type Article struct {
+		Title   string   `json:"title"`
+		Authors []Author `json:"authors"`
+	}
+
+	type Library struct {
+		Owner    *Author           `json:"owner"`
+		Articles []Article         `json:"articles"`
+		Counts   map[int]string    `json:"counts"`
+		Labels   map[string]Author `json:"labels"`
+	}
+
+	library := Library{
+		Articles: []Article{{Title: "a"}, {Title: "b", Authors: []Author{{Id: "7"}}}},
+		Counts:   map[int]string{1: "one"},
+		Labels:   map[string]Author{"main": {Id: "2"}},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    any
+		wantErr bool
+	}{
+		{name: "field", path: "articles[0].title", want: "a"},
+		{name: "element of a list of structs", path: "articles[1].authors[0]", want: Author{Id: "7"}},
+		{name: "dot notation", path: "articles.1.authors.0.id", want: "7"},
+		{name: "map entry", path: "labels.main.id", want: "2"},
+		{name: "map with non-string keys", path: "counts.1", want: "one"},
+		{name: "nil pointer", path: "owner.id", wantErr: true},
+		{name: "index out of range", path: "articles[2]", wantErr: true},
+		{name: "negative index", path: "articles[-1]", wantErr: true},
+		{name: "negative index (dot notation)", path: "articles.-1", wantErr: true},
+		{name: "missing map entry", path: "labels.other", wantErr: true},
+		{name: "unknown field", path: "articles[0].body", wantErr: true},
+		{name: "empty path", path: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FieldByPath(reflect.ValueOf(&library), tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FieldByPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && !reflect.DeepEqual(got.Interface(), tt.want) {
+				t.Errorf("FieldByPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Values reached through pointers can be set
+	rv, _ := FieldByPath(reflect.ValueOf(&library), "articles[1].title")
+	rv.SetString("Notes")
+
+	if library.Articles[1].Title != "Notes" {
+		t.Errorf("FieldByPath() = %v, want %v", library.Articles[1].Title, "Notes")
+	}
+}
+
+func Test_EnsureAddressable(t *testing.T) {
+	rv := EnsureAddressable(reflect.ValueOf(Author{Id: "1"}))
+	if !rv.CanAddr() || rv.Interface() != (Author{Id: "1"}) {
+		t.Errorf("EnsureAddressable() = %v, want an addressable copy", rv)
+	}
+
+	author := Author{Id: "1"}
+	original := reflect.ValueOf(&author).Elem()
+
+	if rv := EnsureAddressable(original); rv.Addr().Pointer() != original.Addr().Pointer() {
+		t.Errorf("EnsureAddressable() = a copy, want the value itself")
+	}
+}
+
+func Test_NewElem(t *testing.T) {
+	rv := NewElem(reflect.TypeOf((**Author)(nil)))
+
+	if !rv.CanSet() || rv.IsNil() || rv.Elem().IsNil() {
+		t.Fatalf("NewElem() = %v, want allocated pointers", rv)
+	}
+
+	if got := rv.Elem().Elem().Interface(); got != (Author{}) {
+		t.Errorf("NewElem() = %v, want %v", got, Author{})
+	}
+}
+
+func Test_ConvertAssign(t *testing.T) {
+	var age int
+	if err := ConvertAssign(&age, "42"); err != nil || age != 42 {
+		t.Errorf("ConvertAssign() = %v, %v, want %v", age, err, 42)
+	}
+
+	var tags []string
+	if err := ConvertAssign(&tags, []any{"a", "b"}); err != nil || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Errorf("ConvertAssign() = %v, %v, want %v", tags, err, []string{"a", "b"})
+	}
+
+	if err := ConvertAssign(&age, "many"); err == nil {
+		t.Errorf("ConvertAssign() error = nil, want an error")
+	}
+
+	if err := ConvertAssign(age, 1); !errors.Is(err, ErrInvalidDestination) {
+		t.Errorf("ConvertAssign() error = %v, want %v", err, ErrInvalidDestination)
+	}
+}
@@ -0,0 +1,403 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flattens a JSON object into a list of attributes, without the need for a Go struct.
+//
+// The returned attributes follow the same conventions as the ones returned by `GetAttributes`,
+// so `FullName()`, `Children` and `Parents` can be used the same way.
+// Object keys are visited in alphabetical order.
+//
+// Usage:
+//
+//	attributes, err := GetJSONAttributes([]byte(`{"name": "Leo", "emails": ["leo@example.com"]}`))
+//
+// `attributes` would contain the following elements:
+//
+//	emails
+//		-> Value: ["leo@example.com"]
+//		-> Children: [emails[0]]
+//	emails[0]
+//		-> Value: "leo@example.com"
+//		-> Parents: [emails]
+//	name
+//		-> Value: "Leo"
+func GetJSONAttributes(data []byte) ([]StructAttribute, error) {
+	var values any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	object, ok := values.(map[string]any)
+	if !ok {
+		return nil, errors.New("json payload must be an object")
+	}
+
+	return getJSONAttributes(object, []StructAttribute{}, 0), nil
+}
+
+// Extracts the values found at the given paths of a JSON payload.
+// Paths use the same syntax as `StructAttribute.FullName()`. Paths not found in the payload are omitted.
+// Numbers are returned as `json.Number` so that their precision is never lost.
+//
+// Usage:
+//
+//	data := []byte(`{"user": {"id": 1, "emails": ["leo@example.com"]}, "items": [...]}`)
+//
+//	ExtractJSON(data, "user.id", "user.emails[0]", "user.name")
+//	// -> {"user.id": json.Number("1"), "user.emails[0]": "leo@example.com"}
+func ExtractJSON(data []byte, paths ...string) map[string]any {
+	values := make(map[string]any, len(paths))
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return values
+	}
+
+	for _, path := range paths {
+		if value, ok := jsonValueAt(document, ParsePath(path)); ok {
+			values[path] = value
+		}
+	}
+
+	return values
+}
+
+// Removes the properties of a JSON payload that do not correspond to any field of the given model.
+// Nested objects, lists of objects and maps of objects are trimmed as well. Invalid payloads are returned unchanged.
+//
+// The function signature matches `DecoderOptions.BeforeHook`, so it can be used to ignore unknown
+// properties sent by trusted systems while still rejecting them via `ADDITIONAL_PROPERTY` elsewhere:
+//
+//	options := DecoderOptions{
+//		Rules:      []SchemaValidationRule{ADDITIONAL_PROPERTY},
+//		BeforeHook: TrimUnknown,
+//	}
+//
+// Usage:
+//
+//	type User struct {
+//		Name string `json:"name"`
+//	}
+//
+//	TrimUnknown([]byte(`{"name": "Leo", "_meta": {"source": "crm"}}`), &User{}) // -> {"name":"Leo"}
+func TrimUnknown(data []byte, model any) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return data
+	}
+
+	trimmed, err := json.Marshal(trimJSONValue(document, reflect.TypeOf(model)))
+	if err != nil {
+		return data
+	}
+
+	return trimmed
+}
+
+// Returns the JSON representation of the model containing only the requested fields (also known as sparse fieldsets).
+//
+// Fields are separated by commas and nested fields use dots, as in the `?fields=` query parameter of JSON:API.
+// Selecting a field whose value is a list selects that field in each of its elements.
+// Unknown fields are ignored and an empty selection returns every field.
+// Numbers are kept as `json.Number` so that their precision is never lost.
+//
+// Usage:
+//
+//	type Person struct {
+//		Name    string  `json:"name"`
+//		Age     int     `json:"age"`
+//		Contact Contact `json:"contact"`
+//	}
+//
+//	Project(person, "name,contact.emails")
+//	// -> {"name": "Leo", "contact": {"emails": ["leo@example.com"]}}
+func Project(model any, fieldsParam string) map[string]any {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return map[string]any{}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil || document == nil {
+		return map[string]any{}
+	}
+
+	if strings.TrimSpace(fieldsParam) == "" {
+		return document
+	}
+
+	projection := map[string]any{}
+	for _, field := range strings.Split(fieldsParam, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			projectJSONValue(document, projection, strings.Split(field, "."))
+		}
+	}
+
+	return projection
+}
+
+// -------------------------------------------------------
+// -------------------------------------------------------
+// -------------------------------------------------------
+
+// Copies the value found at the given path from the source object to the destination object.
+func projectJSONValue(source map[string]any, destination map[string]any, path []string) {
+	value, ok := source[path[0]]
+	if !ok {
+		return
+	}
+
+	if len(path) == 1 {
+		destination[path[0]] = value
+		return
+	}
+
+	switch node := value.(type) {
+	case map[string]any:
+		nested, _ := destination[path[0]].(map[string]any)
+		if nested == nil {
+			nested = map[string]any{}
+			destination[path[0]] = nested
+		}
+
+		projectJSONValue(node, nested, path[1:])
+	case []any:
+		list, _ := destination[path[0]].([]any)
+		if len(list) != len(node) {
+			list = make([]any, len(node))
+			destination[path[0]] = list
+		}
+
+		for i, item := range node {
+			object, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			nested, _ := list[i].(map[string]any)
+			if nested == nil {
+				nested = map[string]any{}
+				list[i] = nested
+			}
+
+			projectJSONValue(object, nested, path[1:])
+		}
+	}
+}
+
+// Removes the properties of a decoded JSON value that are not found in the given type.
+func trimJSONValue(value any, t reflect.Type) any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return value
+	}
+
+	switch node := value.(type) {
+	case map[string]any:
+		// The values of maps are trimmed following the type of their elements
+		if t.Kind() == reflect.Map {
+			for k, v := range node {
+				node[k] = trimJSONValue(v, t.Elem())
+			}
+
+			return node
+		}
+
+		if t.Kind() != reflect.Struct {
+			return node
+		}
+
+		fields := jsonFields(t)
+		for k, v := range node {
+			ft, ok := fields[k]
+			if !ok {
+				delete(node, k)
+				continue
+			}
+
+			node[k] = trimJSONValue(v, ft)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return node
+		}
+
+		for i, v := range node {
+			node[i] = trimJSONValue(v, t.Elem())
+		}
+	}
+
+	return value
+}
+
+// Returns the types of the fields of a struct type keyed by their JSON names.
+// The fields of embedded structs are promoted, just like `encoding/json` does.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		if sf.Tag.Get("json") == "-" {
+			continue
+		}
+
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+
+				continue
+			}
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		fields[GetJSONTagValue(sf)] = sf.Type
+	}
+
+	return fields
+}
+
+// Navigates a decoded JSON document and returns the value at the given path.
+func jsonValueAt(document any, segments []PathSegment) (any, bool) {
+	current := document
+
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]any:
+			// Numeric keys are parsed as slice positions
+			key := segment.Name
+			if segment.IsIndex {
+				key = strconv.Itoa(segment.Index)
+			}
+
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+
+			current = value
+		case []any:
+			if !segment.IsIndex || segment.Index >= len(node) {
+				return nil, false
+			}
+
+			current = node[segment.Index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func getJSONAttributes(object map[string]any, parents []StructAttribute, currentIndex int) (attributes []StructAttribute) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := reflect.ValueOf(object[key])
+
+		sa := StructAttribute{
+			Value:        value,
+			Field:        jsonStructField(key, value),
+			Parents:      parents,
+			ListPosition: currentIndex,
+		}
+
+		position := len(attributes)
+		attributes = append(attributes, sa)
+
+		newParents := append(append([]StructAttribute{}, parents...), sa)
+
+		switch v := object[key].(type) {
+		case map[string]any:
+			attributes = append(attributes, getJSONAttributes(v, newParents, -1)...)
+		case []any:
+			children, nestedValues := getJSONListAttributes(v, newParents)
+			attributes[position].Children = children
+			attributes = append(attributes, nestedValues...)
+		}
+	}
+
+	return attributes
+}
+
+// Returns the attributes of the elements of a JSON array: its direct children, followed by all of them (including their descendants).
+// Nested arrays are walked recursively. See `getListAttributes`.
+func getJSONListAttributes(list []any, parents []StructAttribute) (children []StructAttribute, attributes []StructAttribute) {
+	for l, item := range list {
+		if nested, ok := item.(map[string]any); ok {
+			nestedValues := getJSONAttributes(nested, parents, l)
+			children = append(children, nestedValues...)
+			attributes = append(attributes, nestedValues...)
+			continue
+		}
+
+		el := reflect.ValueOf(item)
+		child := StructAttribute{
+			Value:        el,
+			Parents:      parents,
+			ListPosition: l,
+			isPrimitive:  true,
+		}
+
+		child.Field = jsonStructField(child.bracketName(), el)
+
+		var nestedValues []StructAttribute
+		if items, ok := item.([]any); ok {
+			child.Children, nestedValues = getJSONListAttributes(items, withParent(parents, child))
+		}
+
+		children = append(children, child)
+		attributes = append(append(attributes, child), nestedValues...)
+	}
+
+	return children, attributes
+}
+
+func jsonStructField(name string, value reflect.Value) reflect.StructField {
+	sf := reflect.StructField{Name: name}
+
+	if value.IsValid() {
+		sf.Type = value.Type()
+	}
+
+	return sf
+}
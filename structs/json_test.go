@@ -0,0 +1,283 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_GetJSONAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "flat",
+			data: []byte(`{"name": "Leo", "age": 30, "active": true, "alias": null}`),
+			want: map[string]any{"active": true, "age": float64(30), "alias": nil, "name": "Leo"},
+		},
+		{
+			name: "nested",
+			data: []byte(`{"contact": {"emails": ["leo@example.com"]}, "articles": [{"id": 1}, {"id": 2}]}`),
+			want: map[string]any{
+				"articles":          []any{map[string]any{"id": float64(1)}, map[string]any{"id": float64(2)}},
+				"articles[0].id":    float64(1),
+				"articles[1].id":    float64(2),
+				"contact":           map[string]any{"emails": []any{"leo@example.com"}},
+				"contact.emails":    []any{"leo@example.com"},
+				"contact.emails[0]": "leo@example.com",
+			},
+		},
+		{
+			name: "nested arrays",
+			data: []byte(`{"grid": [[1, 2], [3]]}`),
+			want: map[string]any{
+				"grid":       []any{[]any{float64(1), float64(2)}, []any{float64(3)}},
+				"grid[0]":    []any{float64(1), float64(2)},
+				"grid[0][0]": float64(1),
+				"grid[0][1]": float64(2),
+				"grid[1]":    []any{float64(3)},
+				"grid[1][0]": float64(3),
+			},
+		},
+		{
+			name:    "invalid payload",
+			data:    []byte(`{`),
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			data:    []byte(`[1, 2]`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, err := GetJSONAttributes(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetJSONAttributes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := map[string]any{}
+			for _, attr := range attributes {
+				got[attr.FullName()] = attributeValue(attr.Value)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetJSONAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetJSONAttributes_Children(t *testing.T) {
+	attributes, _ := GetJSONAttributes([]byte(`{"emails": ["a", "b"], "name": "Leo"}`))
+
+	names := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+	if want := []string{"emails", "emails[0]", "emails[1]", "name"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("GetJSONAttributes() = %v, want %v", names, want)
+	}
+
+	if got := len(attributes[0].Children); got != 2 {
+		t.Errorf("len(Children) = %v, want %v", got, 2)
+	}
+}
+
+func Test_ExtractJSON(t *testing.T) {
+	data := []byte(`{"user": {"id": 1, "emails": ["leo@example.com"]}, "items": [{"sku": "A1"}]}`)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		paths []string
+		want  map[string]any
+	}{
+		{
+			name:  "found",
+			data:  data,
+			paths: []string{"user.id", "user.emails[0]", "items[0].sku"},
+			want:  map[string]any{"user.id": json.Number("1"), "user.emails[0]": "leo@example.com", "items[0].sku": "A1"},
+		},
+		{
+			name:  "large numbers",
+			data:  []byte(`{"id": 9007199254740993, "ratio": 0.1}`),
+			paths: []string{"id", "ratio"},
+			want:  map[string]any{"id": json.Number("9007199254740993"), "ratio": json.Number("0.1")},
+		},
+		{
+			name:  "not found",
+			data:  data,
+			paths: []string{"user.name", "user.emails[3]", "items.sku", "user.id.value"},
+			want:  map[string]any{},
+		},
+		{
+			name:  "objects",
+			data:  data,
+			paths: []string{"items[0]"},
+			want:  map[string]any{"items[0]": map[string]any{"sku": "A1"}},
+		},
+		{
+			name:  "invalid payload",
+			data:  []byte(`{`),
+			paths: []string{"user.id"},
+			want:  map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractJSON(tt.data, tt.paths...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TrimUnknown(t *testing.T) {
+	type Base struct {
+		Id string `json:"id"`
+	}
+
+	type Author struct {
+		Name string `json:"name"`
+	}
+
+	type Article struct {
+		Base
+		Title    string            `json:"title"`
+		Authors  []*Author         `json:"authors"`
+		Metadata map[string]string `json:"metadata"`
+		Editors  map[string]Author `json:"editors"`
+		Internal string            `json:"-"`
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "nothing to trim",
+			data: []byte(`{"id": "1", "title": "Go"}`),
+			want: `{"id":"1","title":"Go"}`,
+		},
+		{
+			name: "unknown properties",
+			data: []byte(`{"id": "1", "_meta": {"source": "crm"}, "Internal": "x", "authors": [{"name": "Leo", "age": 30}], "metadata": {"a": "b"}}`),
+			want: `{"authors":[{"name":"Leo"}],"id":"1","metadata":{"a":"b"}}`,
+		},
+		{
+			name: "maps of structs",
+			data: []byte(`{"id": "1", "editors": {"pt": {"name": "Leo", "age": 30}, "en": null}}`),
+			want: `{"editors":{"en":null,"pt":{"name":"Leo"}},"id":"1"}`,
+		},
+		{
+			name: "numbers are preserved",
+			data: []byte(`{"id": 12345678901234567890}`),
+			want: `{"id":12345678901234567890}`,
+		},
+		{
+			name: "invalid payload",
+			data: []byte(`{`),
+			want: `{`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimUnknown(tt.data, &Article{}); string(got) != tt.want {
+				t.Errorf("TrimUnknown() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_TrimUnknown_BeforeHook(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	var user User
+	options := DecoderOptions{
+		Rules:      []SchemaValidationRule{ADDITIONAL_PROPERTY},
+		BeforeHook: TrimUnknown,
+	}
+
+	if got := Decode([]byte(`{"name": "Leo", "_meta": 1}`), &user, options); len(got) != 0 {
+		t.Errorf("Decode() = %v, want no errors", got)
+	}
+
+	if user.Name != "Leo" {
+		t.Errorf("Decode() name = %v, want %v", user.Name, "Leo")
+	}
+}
+
+func Test_Project(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+
+	type Person struct {
+		Name      string    `json:"name"`
+		Age       int       `json:"age"`
+		Emails    []string  `json:"emails"`
+		Address   Address   `json:"address"`
+		Addresses []Address `json:"addresses"`
+	}
+
+	person := Person{
+		Name:      "Leo",
+		Age:       30,
+		Emails:    []string{"leo@example.com"},
+		Address:   Address{Street: "Main St", City: "Boston"},
+		Addresses: []Address{{Street: "1st Ave", City: "Boston"}, {Street: "2nd Ave", City: "Austin"}},
+	}
+
+	tests := []struct {
+		name   string
+		fields string
+		want   map[string]any
+	}{
+		{
+			name:   "fields - 1",
+			fields: "name, emails,unknown,address.city",
+			want: map[string]any{
+				"name":    "Leo",
+				"emails":  []any{"leo@example.com"},
+				"address": map[string]any{"city": "Boston"},
+			},
+		},
+		{
+			name:   "fields - 2",
+			fields: "addresses.street,address.street,address.city",
+			want: map[string]any{
+				"address":   map[string]any{"street": "Main St", "city": "Boston"},
+				"addresses": []any{map[string]any{"street": "1st Ave"}, map[string]any{"street": "2nd Ave"}},
+			},
+		},
+		{
+			name:   "fields - 3",
+			fields: "age,name.first",
+			want:   map[string]any{"age": json.Number("30")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Project(&person, tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Project() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Project(person, ""); len(got) != 5 {
+		t.Errorf("Project() = %v, want all fields", got)
+	}
+}
@@ -0,0 +1,201 @@
+package structs
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Implemented by types holding a value that may be missing, so that they are handled as nullable values
+// (as pointers are) by `GetAttributes`, the validators and the JSON schemas, without descending into their fields.
+//
+// `IsNull` reports whether the value is missing, in which case the value of the attribute is a nil pointer to the type.
+// Otherwise, `Value` returns the value that is walked and validated in place of the type itself.
+//
+// Usage:
+//
+//	type Optional[T any] struct {
+//		value T
+//		set   bool
+//	}
+//
+//	func (o Optional[T]) IsNull() bool { return !o.set }
+//	func (o Optional[T]) Value() any   { return o.value }
+type Nullable interface {
+	IsNull() bool
+	Value() any
+}
+
+var (
+	nullableType      = reflect.TypeOf((*Nullable)(nil)).Elem()
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType       = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	jsonUnmarshalType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+	// Positions of the fields holding the values of nullable wrappers, keyed by type. See `nullableField`.
+	nullableFields sync.Map
+)
+
+// Reports whether values of the given type wrap a nullable scalar, as `sql.NullString`, `sql.NullInt64` or `pgtype.Text`.
+//
+// Types implementing `Nullable` (directly or through a pointer) are nullable wrappers.
+// Other than those, a nullable wrapper is a struct implementing both `driver.Valuer` and `sql.Scanner` (through a pointer),
+// with a `Valid` field telling whether it holds a value. The value itself is held by its first other exported field.
+//
+// Nullable wrappers are handled as pointers to the values they hold: `GetAttributes` never descends into their fields,
+// and the value of their attributes is either the value they hold or, if they are not `Valid`, a nil pointer to it.
+// This means the value is validated as if the field was declared as, say, a `*string`.
+func IsNullableWrapper(t reflect.Type) bool {
+	if t != nil && (t.Implements(nullableType) || reflect.PointerTo(t).Implements(nullableType)) {
+		return true
+	}
+
+	_, ok := nullableField(t)
+	return ok
+}
+
+// Returns the position of the field holding the value of a nullable wrapper. See `IsNullableWrapper`.
+func nullableField(t reflect.Type) (int, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return -1, false
+	}
+
+	if position, ok := nullableFields.Load(t); ok {
+		return position.(int), position.(int) != -1
+	}
+
+	position := -1
+	if valid, ok := t.FieldByName("Valid"); ok && valid.Type.Kind() == reflect.Bool && len(valid.Index) == 1 &&
+		reflect.PointerTo(t).Implements(valuerType) && reflect.PointerTo(t).Implements(scannerType) {
+		for i := 0; i < t.NumField(); i++ {
+			if sf := t.Field(i); sf.IsExported() && sf.Name != "Valid" {
+				position = i
+				break
+			}
+		}
+	}
+
+	nullableFields.Store(t, position)
+	return position, position != -1
+}
+
+// Returns the value held by a nullable wrapper or a nil pointer to its type if the wrapper holds no value.
+// The second value reports whether the given value is a nullable wrapper at all.
+func nullableValue(rv reflect.Value) (reflect.Value, bool) {
+	if !rv.IsValid() {
+		return rv, false
+	}
+
+	if nullable, ok := nullableInterface(rv); ok {
+		if nullable.IsNull() || nullable.Value() == nil {
+			return reflect.Zero(reflect.PointerTo(rv.Type())), true
+		}
+
+		value, _ := PointerElement(reflect.ValueOf(nullable.Value()))
+		return value, true
+	}
+
+	position, ok := nullableField(rv.Type())
+	if !ok {
+		return rv, false
+	}
+
+	inner := rv.Field(position)
+	if !rv.FieldByName("Valid").Bool() {
+		return reflect.Zero(reflect.PointerTo(inner.Type())), true
+	}
+
+	return inner, true
+}
+
+// Returns the value as a `Nullable`, if its type (or a pointer to it) implements the interface.
+func nullableInterface(rv reflect.Value) (Nullable, bool) {
+	if !rv.CanInterface() {
+		return nil, false
+	}
+
+	if rv.Type().Implements(nullableType) {
+		// Nil pointers cannot be asked whether they are null
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, false
+		}
+
+		return rv.Interface().(Nullable), true
+	}
+
+	if !reflect.PointerTo(rv.Type()).Implements(nullableType) {
+		return nil, false
+	}
+
+	return EnsureAddressable(rv).Addr().Interface().(Nullable), true
+}
+
+// Collects the values of the nullable wrappers that cannot be decoded from JSON (i.e. `sql.NullString`) found in a payload,
+// following the fields of the given type. The values are keyed by their paths (in bracket notation)
+// and replaced by `null` in the payload, so that they can be set through `sql.Scanner` instead.
+// Objects are left in the payload, since they match the fields of the wrappers.
+func nullableJSONValues(value any, t reflect.Type, scope string, values map[string]any) {
+	t = baseType(t)
+
+	switch node := value.(type) {
+	case map[string]any:
+		if t.Kind() == reflect.Map {
+			for k, v := range node {
+				path := strings.TrimPrefix(scope+"."+k, ".")
+				if _, isObject := v.(map[string]any); isScannedType(t.Elem()) && !isObject {
+					values[path], node[k] = v, nil
+					continue
+				}
+
+				nullableJSONValues(v, t.Elem(), path, values)
+			}
+
+			return
+		}
+
+		if t.Kind() != reflect.Struct || IsNullableWrapper(t) {
+			return
+		}
+
+		fields := jsonFields(t)
+		for k, v := range node {
+			ft, ok := fields[k]
+			if !ok {
+				continue
+			}
+
+			path := strings.TrimPrefix(scope+"."+k, ".")
+			if _, isObject := v.(map[string]any); isScannedType(ft) && !isObject {
+				values[path], node[k] = v, nil
+				continue
+			}
+
+			nullableJSONValues(v, ft, path, values)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+
+		for i, v := range node {
+			path := scope + "[" + strconv.Itoa(i) + "]"
+			if _, isObject := v.(map[string]any); isScannedType(t.Elem()) && !isObject {
+				values[path], node[i] = v, nil
+				continue
+			}
+
+			nullableJSONValues(v, t.Elem(), path, values)
+		}
+	}
+}
+
+// Reports whether values of the given type are nullable wrappers that must be set through `sql.Scanner`.
+func isScannedType(t reflect.Type) bool {
+	t = baseType(t)
+	_, ok := nullableField(t)
+	return ok && !reflect.PointerTo(t).Implements(jsonUnmarshalType)
+}
@@ -0,0 +1,232 @@
+package structs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type nullableRecord struct {
+	Name      sql.NullString           `json:"name"`
+	Age       sql.NullInt64            `json:"age"`
+	DeletedAt sql.NullTime             `json:"deleted_at"`
+	Nickname  *sql.NullString          `json:"nickname"`
+	Aliases   []sql.NullString         `json:"aliases"`
+	Scores    map[string]sql.NullInt64 `json:"scores"`
+}
+
+// A `Nullable` implementation, as provided by packages with optional types.
+type optional[T any] struct {
+	value T
+	set   bool
+}
+
+func (o optional[T]) IsNull() bool { return !o.set }
+func (o optional[T]) Value() any   { return o.value }
+
+// A `Nullable` implementation through a pointer, holding values of any type.
+type dynamic struct {
+	value any
+}
+
+func (d *dynamic) IsNull() bool { return d.value == nil }
+func (d *dynamic) Value() any   { return d.value }
+
+type nullableOptions struct {
+	Rating optional[float64] `json:"rating"`
+	Label  optional[string]  `json:"label"`
+	Extra  dynamic           `json:"extra"`
+}
+
+func Test_IsNullableWrapper(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{name: "sql.NullString", value: sql.NullString{}, want: true},
+		{name: "sql.NullTime", value: sql.NullTime{}, want: true},
+		{name: "sql.NullFloat64", value: sql.NullFloat64{}, want: true},
+		{name: "Nullable", value: optional[int]{}, want: true},
+		{name: "Nullable through a pointer", value: dynamic{}, want: true},
+		{name: "struct with a Valid field", value: struct{ Valid bool }{}, want: false},
+		{name: "time.Time", value: time.Time{}, want: false},
+		{name: "string", value: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNullableWrapper(reflect.TypeOf(tt.value)); got != tt.want {
+				t.Errorf("IsNullableWrapper() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetAttributes_NullableWrappers(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	record := nullableRecord{
+		Name:      sql.NullString{String: "Leo", Valid: true},
+		Age:       sql.NullInt64{Int64: 30},
+		DeletedAt: sql.NullTime{Time: deletedAt, Valid: true},
+		Aliases:   []sql.NullString{{String: "leo", Valid: true}, {}},
+		Scores:    map[string]sql.NullInt64{"math": {Int64: 10, Valid: true}},
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(record), []string{})
+
+	want := []struct {
+		name  string
+		value any
+	}{
+		{name: "name", value: "Leo"},
+		{name: "age", value: (*int64)(nil)},
+		{name: "deleted_at", value: deletedAt},
+		{name: "nickname", value: (*sql.NullString)(nil)},
+		{name: "aliases", value: record.Aliases},
+		{name: "aliases[0]", value: "leo"},
+		{name: "aliases[1]", value: (*string)(nil)},
+		{name: "scores", value: record.Scores},
+		{name: "scores.math", value: int64(10)},
+	}
+
+	if len(attributes) != len(want) {
+		t.Fatalf("GetAttributes() = %v attributes, want %v", len(attributes), len(want))
+	}
+
+	for i, attr := range attributes {
+		if attr.FullName() != want[i].name || !reflect.DeepEqual(attr.Value.Interface(), want[i].value) {
+			t.Errorf("GetAttributes() = %v: %v, want %v: %v", attr.FullName(), attr.Value, want[i].name, want[i].value)
+		}
+	}
+
+	if got := Summary(record).Total; got != len(attributes) {
+		t.Errorf("Summary() = %v attributes, want %v", got, len(attributes))
+	}
+
+	attr, err := GetAttributeByPath(record, "aliases[0]")
+	if err != nil || attr.Value.Interface() != "leo" {
+		t.Errorf("GetAttributeByPath() = %v, %v, want %v", attr.Value, err, "leo")
+	}
+
+	if _, err := GetAttributeByPath(record, "name.String"); err == nil {
+		t.Errorf("GetAttributeByPath() error = nil, want %v", ErrAttributeNotFound)
+	}
+}
+
+func Test_GetAttributes_Nullable(t *testing.T) {
+	options := nullableOptions{
+		Rating: optional[float64]{value: 4.5, set: true},
+		Label:  optional[string]{value: "new"},
+		Extra:  dynamic{value: &Author{Id: "1"}},
+	}
+
+	want := map[string]any{
+		"rating": 4.5,
+		"label":  (*optional[string])(nil),
+		"extra":  Author{Id: "1"},
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(options), []string{})
+	if len(attributes) != len(want) {
+		t.Fatalf("GetAttributes() = %v attributes, want %v", len(attributes), len(want))
+	}
+
+	for _, attr := range attributes {
+		if !reflect.DeepEqual(attr.Value.Interface(), want[attr.FullName()]) {
+			t.Errorf("GetAttributes() %v = %v, want %v", attr.FullName(), attr.Value, want[attr.FullName()])
+		}
+	}
+
+	flattened := ToFlatMap(options, FlattenOptions{OmitZero: true})
+	if _, ok := flattened["label"]; ok || len(flattened) != 2 {
+		t.Errorf("ToFlatMap() = %v, want null values to be left out", flattened)
+	}
+}
+
+func Test_SetValuesFromMap_NullableWrappers(t *testing.T) {
+	var record nullableRecord
+
+	values := map[string]any{}
+	_ = json.Unmarshal([]byte(`{
+		"name": "Leo",
+		"age": null,
+		"deleted_at": "2024-01-02T03:04:05Z",
+		"nickname": "leo",
+		"aliases": ["a", null],
+		"scores": {"math": 10}
+	}`), &values)
+
+	populated, err := SetValuesFromMap(&record, values)
+	if err != nil {
+		t.Fatalf("SetValuesFromMap() error = %v", err)
+	}
+
+	want := nullableRecord{
+		Name:      sql.NullString{String: "Leo", Valid: true},
+		DeletedAt: sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true},
+		Nickname:  &sql.NullString{String: "leo", Valid: true},
+		Aliases:   []sql.NullString{{String: "a", Valid: true}, {}},
+		Scores:    map[string]sql.NullInt64{"math": {Int64: 10, Valid: true}},
+	}
+
+	if !reflect.DeepEqual(record, want) {
+		t.Errorf("SetValuesFromMap() = %+v, want %+v", record, want)
+	}
+
+	wantPopulated := []string{"age", "aliases", "aliases[0]", "aliases[1]", "deleted_at", "name", "nickname", "scores"}
+	if !reflect.DeepEqual(populated, wantPopulated) {
+		t.Errorf("SetValuesFromMap() = %v, want %v", populated, wantPopulated)
+	}
+
+	if err := SetValueByPath(&record, "age", "42"); err != nil || record.Age != (sql.NullInt64{Int64: 42, Valid: true}) {
+		t.Errorf("SetValueByPath() = %+v, %v, want %+v", record.Age, err, sql.NullInt64{Int64: 42, Valid: true})
+	}
+
+	if err := SetValueByPath(&record, "age", "many"); err == nil {
+		t.Errorf("SetValueByPath() error = nil, want an error")
+	}
+}
+
+func Test_JSONSchema_NullableWrappers(t *testing.T) {
+	type model struct {
+		nullableRecord
+		nullableOptions
+	}
+
+	data, err := JSONSchema(&model{}, DecoderOptions{})
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]any `json:"properties"`
+		} `json:"$defs"`
+	}
+
+	_ = json.Unmarshal(data, &schema)
+
+	nullable := func(schema map[string]any) any {
+		return map[string]any{"oneOf": []any{schema, map[string]any{"type": "null"}}}
+	}
+
+	properties := schema.Definitions["model"].Properties
+	want := map[string]any{
+		"name":       nullable(map[string]any{"type": "string"}),
+		"age":        nullable(map[string]any{"type": "integer"}),
+		"deleted_at": nullable(map[string]any{"type": "string", "format": "date-time"}),
+		"nickname":   nullable(map[string]any{"type": "string"}),
+		"rating":     nullable(map[string]any{"type": "number"}),
+		// Values of unknown types accept anything, including null
+		"extra": true,
+	}
+
+	for name, schema := range want {
+		if !reflect.DeepEqual(properties[name], schema) {
+			t.Errorf("JSONSchema() %v = %v, want %v", name, properties[name], schema)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package structs
+
+import (
+	"reflect"
+	"strconv"
+)
+
+const (
+	// The literal name of the tag used for binding path parameters to a field.
+	//
+	// Example:
+	//
+	//	type Request struct {
+	//		UserId string `json:"user_id" pathparam:"id"`
+	//	}
+	PATH_PARAM_TAG_KEYWORD string = "pathparam"
+
+	// The literal name of the tag used for binding query string parameters to a field.
+	//
+	// Example:
+	//
+	//	type Request struct {
+	//		Limit int      `json:"limit" query:"limit"`
+	//		Tags  []string `json:"tags" query:"tag"`
+	//	}
+	QUERY_TAG_KEYWORD string = "query"
+)
+
+// Populates the fields of the given struct pointer containing the specified tag with the matching parameters.
+// Parameters are converted to the type of their fields. Slice fields receive all the values of a parameter,
+// while any other field only receives the first one. Fields without a matching parameter are left untouched.
+//
+// Returns an `INVALID_TYPE` error for every parameter that could not be converted, keyed by the JSON name of its field.
+//
+// Usage:
+//
+//	type Request struct {
+//		Limit int      `json:"limit" query:"limit"`
+//		Tags  []string `json:"tags" query:"tag"`
+//	}
+//
+//	var r Request
+//	BindParams(&r, QUERY_TAG_KEYWORD, map[string][]string{"limit": {"ten"}, "tag": {"a", "b"}})
+//	// -> {"limit": ["INVALID_TYPE"]}
+func BindParams(model any, tag string, params map[string][]string) (validations map[string][]string) {
+	validations = make(map[string][]string)
+
+	if err := ValidateModel(model); err != nil {
+		validations["_"] = []string{DecodingErrors["invalid_model"]}
+		return validations
+	}
+
+	rv := reflect.ValueOf(model).Elem()
+
+	for _, sf := range reflect.VisibleFields(rv.Type()) {
+		if sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+
+		if _, ok := sf.Tag.Lookup(tag); !ok {
+			continue
+		}
+
+		values, ok := params[GetTagValue(sf, tag)]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		if err := setStringValues(rv.FieldByIndex(sf.Index), values); err != nil {
+			validations[GetJSONTagValue(sf)] = []string{DecodingErrors["invalid_type"]}
+		}
+	}
+
+	return validations
+}
+
+// Sets the value of the field from its string representation.
+func setStringValues(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		value := reflect.New(field.Type().Elem())
+		if err := setStringValues(value.Elem(), values); err != nil {
+			return err
+		}
+
+		field.Set(value)
+		return nil
+	case reflect.Slice:
+		list := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := setStringValues(list.Index(i), []string{v}); err != nil {
+				return err
+			}
+		}
+
+		field.Set(list)
+		return nil
+	}
+
+	value := values[0]
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(n)
+	default:
+		return strconv.ErrSyntax
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_BindParams(t *testing.T) {
+	type Request struct {
+		Id     string   `json:"id" pathparam:"id"`
+		Limit  *int     `json:"limit" query:"limit"`
+		Tags   []string `json:"tags" query:"tag"`
+		Ratio  float64  `json:"ratio" query:"ratio"`
+		DryRun bool     `json:"dry_run" query:"dry_run"`
+		Name   string   `json:"name"`
+	}
+
+	limit := 10
+
+	tests := []struct {
+		name   string
+		tag    string
+		params map[string][]string
+		want   Request
+		errors map[string][]string
+	}{
+		{
+			name:   "query - 1",
+			tag:    QUERY_TAG_KEYWORD,
+			params: map[string][]string{"limit": {"10"}, "tag": {"a", "b"}, "ratio": {"0.5"}, "dry_run": {"true"}, "id": {"1"}},
+			want:   Request{Limit: &limit, Tags: []string{"a", "b"}, Ratio: 0.5, DryRun: true},
+			errors: map[string][]string{},
+		},
+		{
+			name:   "query - 2",
+			tag:    QUERY_TAG_KEYWORD,
+			params: map[string][]string{"limit": {"ten"}, "dry_run": {"maybe"}},
+			want:   Request{},
+			errors: map[string][]string{"limit": {"INVALID_TYPE"}, "dry_run": {"INVALID_TYPE"}},
+		},
+		{
+			name:   "path - 1",
+			tag:    PATH_PARAM_TAG_KEYWORD,
+			params: map[string][]string{"id": {"1"}, "limit": {"10"}},
+			want:   Request{Id: "1"},
+			errors: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Request
+
+			if errs := BindParams(&got, tt.tag, tt.params); !reflect.DeepEqual(errs, tt.errors) {
+				t.Errorf("BindParams() = %v, want %v", errs, tt.errors)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BindParams() populated %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
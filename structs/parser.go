@@ -1,12 +1,15 @@
 package structs
 
 import (
-	"bytes"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 )
@@ -20,11 +23,41 @@ const (
 	//		Name string `json:"name" validate:"min=6"`
 	//	}
 	VALIDATION_TAG_KEYWORD string = "validate"
+
+	// Prefix of the validation rules that only apply to the elements of a slice/array.
+	//
+	// When a field declares at least one of these rules, its elements are validated
+	// using exclusively these rules, and the remaining rules only apply to the slice/array itself.
+	//
+	// Example:
+	//
+	//	type Resource struct {
+	//		Emails []string `json:"emails" validate:"min=1,each:min=3,each:email"`
+	//	}
+	EACH_RULE_PREFIX string = "each:"
+
+	// The largest length a slice can be grown to by `SetValueByPath`, which keeps paths such as `emails[50000000]`
+	// from allocating arbitrarily large slices. Elements of slices already longer than this can still be set.
+	MAX_SLICE_LENGTH int = 10000
 )
 
 var (
 	// Tag attributes that should be excluded
-	NON_INHERITABLE_TAG_ATTRIBUTES = []string{"max", "min"}
+	//
+	// This list is shared by all goroutines and must not be modified once attributes are being fetched.
+	// Use `AttributeOptions.NonInheritableTagAttributes` to exclude other attributes.
+	NON_INHERITABLE_TAG_ATTRIBUTES = []string{
+		"at_least_one_of", "eqfield", "exactly_one_of", "gtfield", "len", "max", "min", "range",
+		"required", "required_if", "required_with", "required_without",
+	}
+
+	// Values of this type are kept as they are found in the payload and are never processed any further.
+	RawMessageType = reflect.TypeOf(json.RawMessage{})
+
+	uuidType = reflect.TypeOf(uuid.UUID{})
+
+	// Parsed tag values keyed by `tagCacheKey`
+	tagValuesCache sync.Map
 )
 
 // Fetches all the fields of the given struct instance and returns a flattened list with all of its attributes.
@@ -65,11 +98,159 @@ var (
 //
 // Each returned attribute will expose its underlying value as well as
 // the definitions for its field type as found in the parent struct type.
+//
+// The entries of a map are named after their keys, as in `settings.theme`, and listed in alphabetical order.
+// See `AttributeOptions.MapKeyLess`.
+//
+// Attributes are always listed in the same order: fields in the order they are declared (with the fields of an embedded struct
+// in place of the struct itself), each followed by its descendants, and the elements of slices/arrays in index order.
+// See `AttributeOptions.Order` to list fields alphabetically instead.
+//
+// Unexported fields are skipped. See `AttributeOptions.UnexportedFields`.
 func GetAttributes(entity reflect.Value, filterTags []string, ignoredFields ...string) (attributes []StructAttribute) {
+	return GetAttributesWithOptions(entity, AttributeOptions{
+		FilterTags:    filterTags,
+		IgnoredFields: ignoredFields,
+	})
+}
+
+// Fetches all the fields of the given struct instance using the provided options.
+// See `GetAttributes`.
+func GetAttributesWithOptions(entity reflect.Value, options AttributeOptions) (attributes []StructAttribute) {
 	currentIndex := 0
 	parents := []StructAttribute{}
 
-	return getAttributes(entity, parents, filterTags, ignoredFields, currentIndex)
+	attributes = getAttributes(entity, parents, options, currentIndex)
+	if options.Order == PATH_ORDER {
+		attributes = sortedByPath(attributes)
+	}
+
+	return attributes
+}
+
+// Returned by `GetAttributeByPath` when the path does not match any attribute of the model.
+var ErrAttributeNotFound = errors.New("attribute not found")
+
+// Finds the attribute at the given path, as it would be returned by `GetAttributes(reflect.ValueOf(model), []string{})`.
+// Only the fields along the path are visited, so callers do not have to walk the whole model to find a single attribute.
+//
+// Paths in any of the notations supported by `ParsePath` are accepted.
+// Since the elements of a slice/array of structs are not attributes themselves, paths like `articles[1]` are not found.
+//
+// Usage:
+//
+//	attr, err := GetAttributeByPath(library, "articles[1].authors[0].id")
+//	attr.FullName()        // -> "articles[1].authors[0].id"
+//	attr.Value.Interface() // -> "7"
+//
+//	_, err = GetAttributeByPath(library, "articles[9].title")
+//	errors.Is(err, ErrAttributeNotFound) // -> true
+func GetAttributeByPath(model any, path string) (StructAttribute, error) {
+	notFound := fmt.Errorf("%w: %s", ErrAttributeNotFound, path)
+	options := AttributeOptions{}
+
+	segments := ParsePath(path)
+	if len(segments) == 0 || segments[0].IsIndex {
+		return StructAttribute{}, notFound
+	}
+
+	attr, ok := fieldAttribute(reflect.ValueOf(model), segments[0].Name, []StructAttribute{}, 0)
+	if !ok {
+		return StructAttribute{}, notFound
+	}
+
+	// The field whose rules are inherited by the elements of slices/arrays and the entries of maps
+	field := attr.Field
+
+	for i := 1; i < len(segments); i++ {
+		segment, value := segments[i], attr.Value
+
+		if !value.IsValid() || isOpaqueType(value) {
+			return StructAttribute{}, notFound
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			if segment.IsIndex {
+				return StructAttribute{}, notFound
+			}
+
+			attr, ok = fieldAttribute(value, segment.Name, withParent(attr.Parents, attr), -1)
+			field = attr.Field
+		case reflect.Slice, reflect.Array:
+			if !segment.IsIndex || segment.Index < 0 || segment.Index >= value.Len() || value.Type() == uuidType {
+				return StructAttribute{}, notFound
+			}
+
+			if elemType := baseType(value.Type().Elem()); elemType.Kind() != reflect.Struct || IsNullableWrapper(elemType) {
+				children, _ := getListAttributes(attr, value, field, options)
+				attr = children[segment.Index]
+				continue
+			}
+
+			// Only the fields of the elements of a slice/array of structs are attributes
+			if i+1 == len(segments) || segments[i+1].IsIndex {
+				return StructAttribute{}, notFound
+			}
+
+			i++
+			attr, ok = fieldAttribute(value.Index(segment.Index), segments[i].Name, withParent(attr.Parents, attr), segment.Index)
+			field = attr.Field
+		case reflect.Map:
+			key := segment.Name
+			if segment.IsIndex {
+				key = strconv.Itoa(segment.Index)
+			}
+
+			children, _ := getMapAttributes(attr, value, field, options)
+			entries := Filter(children, func(_ int, entry StructAttribute) bool { return entry.Field.Name == key })
+
+			ok = len(entries) != 0
+			if ok {
+				attr = entries[0]
+			}
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return StructAttribute{}, notFound
+		}
+	}
+
+	return attr, nil
+}
+
+// Returns the attribute of the exported field of the given struct whose JSON name matches `name`,
+// following the same rules used by `getAttributes`. The fields of embedded structs are searched as well.
+func fieldAttribute(rv reflect.Value, name string, parents []StructAttribute, currentIndex int) (StructAttribute, bool) {
+	if rv.Kind() == reflect.Pointer {
+		rv, _ = PointerElement(rv)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return StructAttribute{}, false
+	}
+
+	for position := 0; position < rv.NumField(); position++ {
+		value, _ := PointerElement(rv.Field(position))
+		rsf := rv.Type().Field(position)
+
+		if rsf.Anonymous {
+			if sa, ok := fieldAttribute(value, name, parents, currentIndex); ok {
+				return sa, true
+			}
+
+			continue
+		}
+
+		if rsf.IsExported() && GetJSONTagValue(rsf) == name {
+			value, _ = nullableValue(value)
+			return StructAttribute{Value: value, Field: rsf, Parents: parents, ListPosition: currentIndex}, true
+		}
+	}
+
+	return StructAttribute{}, false
 }
 
 // Get the first value of the `json` tag.
@@ -93,16 +274,19 @@ func GetJSONTagValue(sf reflect.StructField) string {
 // You can obtain the `orm` tag the following way:
 //	GetTagValue(name_sf, "orm") // -> "pk=name"
 func GetTagValue(sf reflect.StructField, tagName string) string {
-	name := sf.Name
+	tag := sf.Tag.Get(tagName)
 
-	// Attribute name should come from json tag
-	tag := strings.Split(sf.Tag.Get(tagName), ",")
+	// Only the first value is needed, so there's no need to split the whole tag
+	if end := strings.IndexByte(tag, ','); end != -1 {
+		tag = tag[:end]
+	}
 
-	if len(tag) != 0 && tag[0] != "" {
-		name = tag[0]
+	// Attribute name should come from json tag
+	if tag != "" {
+		return tag
 	}
 
-	return name
+	return sf.Name
 }
 
 // Get the full value of the given tag.
@@ -117,14 +301,72 @@ func GetTagValue(sf reflect.StructField, tagName string) string {
 //
 // You can obtain the `orm` tag the following way:
 //	GetTagValues(name_sf, "orm") // -> "pk=name,noupdate,required,pk"
+//
+// Values are split on every comma. Use `GetTagRules` to read the rules of validation tags.
+//
+// Since struct tags never change, the parsed values are cached. Callers get a copy of them, which they are free to modify.
 func GetTagValues(sf reflect.StructField, tagName string) []string {
+	return copyValues(tagValues(sf, tagName))
+}
+
+// Returns the cached values of the given tag. See `GetTagValues`.
+// The returned slice is shared between calls and must not be modified.
+func tagValues(sf reflect.StructField, tagName string) []string {
+	key := tagCacheKey{tag: sf.Tag, name: tagName}
+	if values, ok := tagValuesCache.Load(key); ok {
+		return values.([]string)
+	}
+
 	r, exists := sf.Tag.Lookup(tagName)
+	if !exists {
+		return []string{}
+	}
+
+	values, _ := tagValuesCache.LoadOrStore(key, strings.Split(r, ","))
+	return values.([]string)
+}
+
+// Get the rules of the given validation tag.
+// Unlike `GetTagValues`, commas enclosed in parentheses or quotes (or escaped) are not treated as separators,
+// which allows rules like `regex(^\d{1,3}$)` and `in='a,b|c'`. See `ParseRules`.
+//
+// Usage:
+//
+//	// Email string `validate:"required,in='a,b|c'"`
+//	GetTagRules(email_sf, "validate") // -> [required in='a,b|c']
+//
+// Since struct tags never change, the rules are cached. Callers get a copy of them, which they are free to modify.
+func GetTagRules(sf reflect.StructField, tagName string) []string {
+	return copyValues(tagRules(sf, tagName))
+}
 
-	if exists {
-		return strings.Split(r, ",")
+// Returns the cached rules of the given validation tag. See `GetTagRules`.
+// The returned slice is shared between calls and must not be modified.
+func tagRules(sf reflect.StructField, tagName string) []string {
+	key := tagCacheKey{tag: sf.Tag, name: tagName, rules: true}
+	if values, ok := tagValuesCache.Load(key); ok {
+		return values.([]string)
 	}
 
-	return []string{}
+	r, exists := sf.Tag.Lookup(tagName)
+	if !exists {
+		return []string{}
+	}
+
+	values, _ := tagValuesCache.LoadOrStore(key, splitTagValue(r))
+	return values.([]string)
+}
+
+// Returns a copy of the cached values of a tag.
+func copyValues(values []string) []string {
+	return append(make([]string, 0, len(values)), values...)
+}
+
+// Splits the value of a tag on the commas that are not enclosed in parentheses or quotes, nor escaped. See `ParseRules`.
+// Malformed values are split up to the point where they stop making sense.
+func splitTagValue(value string) []string {
+	values, _ := splitRules(value)
+	return values
 }
 
 // Get each of the attributes of the given tag.
@@ -229,39 +471,343 @@ func MatchingFields(v any, tag string, requiredKeywords []string) (result []stri
 	return matchingFields(rv, parents, tag, requiredKeywords)
 }
 
-func SetValuesFromMap(entity any, values map[string]any) {
+// Populates the given struct pointer with the provided values, keyed by their JSON names.
+// Values whose types are incompatible with the type of their fields are ignored.
+// Nullable wrappers, as `sql.NullString`, are set from the values they hold (or `nil`). See `IsNullableWrapper`.
+// See `SetValueByPath` to set a single nested field.
+//
+// Returns the paths of all the attributes that were present in the provided values
+// (see `StructAttribute.FullName()`), sorted alphabetically.
+// This allows callers to distinguish between fields that were explicitly set and those left at their zero value.
+//
+// Returns `ErrInvalidModel` if the entity is not a non-nil pointer to a struct.
+//
+// Usage:
+//
+//	type Settings struct {
+//		Enabled bool   `json:"enabled"`
+//		Theme   string `json:"theme"`
+//	}
+//
+//	var settings Settings
+//	SetValuesFromMap(&settings, map[string]any{"enabled": false}) // -> ["enabled"], nil
+func SetValuesFromMap(entity any, values map[string]any) (populated []string, err error) {
+	if err := ValidateModel(entity); err != nil {
+		return populated, err
+	}
+
 	rv := reflect.ValueOf(entity)
 	attrs := GetAttributes(rv, []string{})
 
+	// Nullable wrappers without a JSON representation of their own (i.e. `sql.NullString`) are set through `sql.Scanner`
+	nullables := map[string]any{}
+	nullableJSONValues(values, rv.Type(), "", nullables)
+
 	for _, attr := range attrs {
-		if v, ok := values[attr.FullName()]; ok {
-			if sf := rv.Elem().FieldByName(attr.Field.Name); sf.CanSet() {
+		if _, ok := nullables[attr.bracketName()]; ok {
+			continue
+		}
+
+		if v, ok := values[attr.bracketName()]; ok {
+			if sf := rv.Elem().FieldByName(attr.Field.Name); sf.CanSet() && !isOpaqueType(sf) {
 				value := reflect.ValueOf(v)
 
-				switch sf.Type().Kind() {
-				case reflect.Array, reflect.Slice:
-					if value.Kind() != sf.Type().Kind() {
-						delete(values, attr.FullName())
+				// All pointer layers are removed, so that `*[]*Author` is handled as `[]Author`
+				switch ft := baseType(sf.Type()); {
+				case ft.Kind() == reflect.Array || ft.Kind() == reflect.Slice:
+					if value.Kind() != ft.Kind() {
+						delete(values, attr.bracketName())
 					}
-				case reflect.Pointer:
-					if value.Kind() != sf.Type().Elem().Kind() {
-						delete(values, attr.FullName())
+				case sf.Kind() == reflect.Pointer:
+					if value.Kind() != ft.Kind() {
+						delete(values, attr.bracketName())
 					}
-				case reflect.Struct:
 				}
 			}
 		}
 	}
 
-	buf := new(bytes.Buffer)
+	buf := getBuffer()
+	defer putBuffer(buf)
+
 	json.NewEncoder(buf).Encode(values)
 	json.NewDecoder(buf).Decode(entity)
+
+	paths := make([]string, 0, len(nullables))
+	for path := range nullables {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		_ = SetValueByPath(entity, path, nullables[path])
+	}
+
+	populated = populatedPaths(values, reflect.TypeOf(entity), "")
+	sort.Strings(populated)
+
+	return Map(populated, func(_ int, path string) string { return notatedPath(path) }), nil
 }
 
-func SetValuesFromBytes(entity any, data []byte) {
-	values := map[string]any{}
+// Populates the given struct pointer with the values found in the JSON payload.
+// See `SetValuesFromMap`.
+func SetValuesFromBytes(entity any, data []byte) (populated []string, err error) {
+	values := getValues()
+	defer putValues(values)
+
 	_ = json.Unmarshal(data, &values)
-	SetValuesFromMap(entity, values)
+	return SetValuesFromMap(entity, values)
+}
+
+// Returned by `SetValueByPath` when a slice would have to grow beyond `MAX_SLICE_LENGTH` to fit the given position.
+var ErrSliceTooLong = errors.New("slice length exceeds the maximum")
+
+// Sets the value of the attribute at the given path, as in `contact.emails[2]` (see `ParsePath`).
+// Nil pointers and maps along the path are allocated, and slices are grown as needed to fit the given positions,
+// up to `MAX_SLICE_LENGTH` elements.
+//
+// The value is converted to the type of the field: numbers are converted between numeric types (as long as they fit),
+// strings are parsed into numbers and booleans, and anything else is converted through its JSON representation,
+// so that a `[]any` can be set into a `[]string` or a `map[string]any` into a struct. A nil value sets the zero value.
+// Nullable wrappers, as `sql.NullString`, are set through their `Scan` method.
+//
+// Returns `ErrInvalidModel` if the entity is not a non-nil pointer to a struct,
+// `ErrAttributeNotFound` if the path does not match any field (including negative positions)
+// and `ErrSliceTooLong` if a slice would have to grow beyond `MAX_SLICE_LENGTH`.
+//
+// Usage:
+//
+//	var person Person
+//	SetValueByPath(&person, "contact.emails[2]", "leo@example.com")
+//	// -> person.Contact.Emails == ["", "", "leo@example.com"]
+//
+//	SetValueByPath(&person, "age", "42")
+//	// -> person.Age == 42
+func SetValueByPath(entity any, path string, value any) error {
+	if err := ValidateModel(entity); err != nil {
+		return err
+	}
+
+	segments := ParsePath(path)
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: %s", ErrAttributeNotFound, path)
+	}
+
+	return setValueByPath(reflect.ValueOf(entity).Elem(), segments, path, value)
+}
+
+func setValueByPath(target reflect.Value, segments []PathSegment, path string, value any) error {
+	if len(segments) == 0 {
+		converted, err := convertValue(value, target.Type())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		target.Set(converted)
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer && target.IsNil() {
+		target.Set(NewElem(target.Type()))
+	}
+
+	target, _ = PointerElement(target)
+
+	notFound := fmt.Errorf("%w: %s", ErrAttributeNotFound, path)
+	segment := segments[0]
+
+	if target.Type() == RawMessageType {
+		return notFound
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		index, ok := jsonFieldIndex(target.Type(), segment.Name)
+		if segment.IsIndex || !ok {
+			return notFound
+		}
+
+		for _, position := range index[:len(index)-1] {
+			target = target.Field(position)
+
+			// Embedded pointers are allocated so that their fields can be set
+			if target.Kind() == reflect.Pointer {
+				if target.IsNil() {
+					if !target.CanSet() {
+						return notFound
+					}
+
+					target.Set(reflect.New(target.Type().Elem()))
+				}
+
+				target = target.Elem()
+			}
+		}
+
+		return setValueByPath(target.Field(index[len(index)-1]), segments[1:], path, value)
+	case reflect.Slice:
+		if !segment.IsIndex || segment.Index < 0 {
+			return notFound
+		}
+
+		if segment.Index >= target.Len() && segment.Index >= MAX_SLICE_LENGTH {
+			return fmt.Errorf("%w: %s", ErrSliceTooLong, path)
+		}
+
+		if missing := segment.Index + 1 - target.Len(); missing > 0 {
+			target.Set(reflect.AppendSlice(target, reflect.MakeSlice(target.Type(), missing, missing)))
+		}
+
+		return setValueByPath(target.Index(segment.Index), segments[1:], path, value)
+	case reflect.Array:
+		if !segment.IsIndex || segment.Index < 0 || segment.Index >= target.Len() {
+			return notFound
+		}
+
+		return setValueByPath(target.Index(segment.Index), segments[1:], path, value)
+	case reflect.Map:
+		name := segment.Name
+		if segment.IsIndex {
+			name = strconv.Itoa(segment.Index)
+		}
+
+		key, err := convertValue(name, target.Type().Key())
+		if err != nil {
+			return notFound
+		}
+
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+
+		// Map entries are not addressable, so the entry is updated through a copy
+		entry := reflect.New(target.Type().Elem()).Elem()
+		if current := target.MapIndex(key); current.IsValid() {
+			entry = EnsureAddressable(current)
+		}
+
+		if err := setValueByPath(entry, segments[1:], path, value); err != nil {
+			return err
+		}
+
+		target.SetMapIndex(key, entry)
+		return nil
+	}
+
+	return notFound
+}
+
+// Returns the index sequence of the exported field of the given struct type whose JSON name matches `name`,
+// following the same rules used by `getAttributes`. The fields of embedded structs are searched as well.
+func jsonFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		if sf.Anonymous {
+			if ft := baseType(sf.Type); ft.Kind() == reflect.Struct {
+				if index, ok := jsonFieldIndex(ft, name); ok {
+					return append([]int{position}, index...), true
+				}
+			}
+
+			continue
+		}
+
+		if sf.IsExported() && GetJSONTagValue(sf) == name {
+			return []int{position}, true
+		}
+	}
+
+	return nil, false
+}
+
+// Converts the value to the given type. See `SetValueByPath`.
+func convertValue(value any, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(t), nil
+		}
+
+		return convertValue(v.Elem().Interface(), t)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		el, err := convertValue(value, t.Elem())
+		if err != nil {
+			return el, err
+		}
+
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(el)
+
+		return ptr, nil
+	}
+
+	invalid := fmt.Errorf("cannot convert %T to %s", value, t)
+
+	// Nullable wrappers (i.e. `sql.NullString`) are scanned from the values they hold,
+	// which are converted to the type of their value field when they cannot be scanned as they are
+	if position, ok := nullableField(t); ok {
+		target := reflect.New(t)
+		if err := target.Interface().(sql.Scanner).Scan(value); err == nil {
+			return target.Elem(), nil
+		}
+
+		inner, err := convertValue(value, t.Field(position).Type)
+		if err != nil {
+			return reflect.Zero(t), invalid
+		}
+
+		if err := target.Interface().(sql.Scanner).Scan(inner.Interface()); err != nil {
+			return reflect.Zero(t), invalid
+		}
+
+		return target.Elem(), nil
+	}
+
+	switch {
+	case isNumericKind(v.Kind()) && isNumericKind(t.Kind()):
+		converted := v.Convert(t)
+
+		// Values that do not fit in the new type are rejected
+		if converted.Convert(v.Type()).Interface() != v.Interface() || (v.CanInt() && v.Int() < 0 && converted.CanUint()) {
+			return converted, invalid
+		}
+
+		return converted, nil
+	case v.Kind() == reflect.String && t.Kind() == reflect.String:
+		return v.Convert(t), nil
+	case v.Kind() == reflect.String && (isNumericKind(t.Kind()) || t.Kind() == reflect.Bool):
+		// Strings are parsed as JSON literals, as in "42" or "true"
+		target := reflect.New(t)
+		if err := json.Unmarshal([]byte(v.String()), target.Interface()); err != nil {
+			return target.Elem(), invalid
+		}
+
+		return target.Elem(), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return v, invalid
+	}
+
+	target := reflect.New(t)
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return target.Elem(), invalid
+	}
+
+	return target.Elem(), nil
 }
 
 // -------------------------------------------------------
@@ -269,7 +815,7 @@ func SetValuesFromBytes(entity any, data []byte) {
 // -------------------------------------------------------
 
 // Fetches all the fields of the given struct.
-func getAttributes(rv reflect.Value, parents []StructAttribute, filterTags, ignoredFields []string, currentIndex int) (attributes []StructAttribute) {
+func getAttributes(rv reflect.Value, parents []StructAttribute, options AttributeOptions, currentIndex int) (attributes []StructAttribute) {
 	if rv.Kind() == reflect.Pointer {
 		rv, _ = PointerElement(rv)
 	}
@@ -278,11 +824,29 @@ func getAttributes(rv reflect.Value, parents []StructAttribute, filterTags, igno
 		return attributes
 	}
 
-	for position := 0; position < rv.NumField(); position++ {
+	attributes = make([]StructAttribute, 0, rv.NumField())
+
+	// Panics are raised again along with the path of the field being walked, unless a nested field already did so
+	position := 0
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(*AttributePanic); !ok {
+				attr := StructAttribute{Field: rv.Type().Field(position), Parents: parents, ListPosition: currentIndex}
+				r = &AttributePanic{Path: attr.FullName(), Value: r}
+			}
+
+			panic(r)
+		}
+	}()
+
+	for ; position < rv.NumField(); position++ {
 		// Concrete value type of the field at this position
 		value := rv.Field(position)
 		value, _ = PointerElement(value)
 
+		// Nullable wrappers (i.e. `sql.NullString`) are handled as pointers to the values they hold
+		value, isNullable := nullableValue(value)
+
 		// Struct field definition
 		rsf := rv.Type().Field(position)
 
@@ -293,100 +857,459 @@ func getAttributes(rv reflect.Value, parents []StructAttribute, filterTags, igno
 			ListPosition: currentIndex,
 		}
 
+		if options.IncludeLayout {
+			sa.Layout = &FieldLayout{Offset: rsf.Offset, Size: rsf.Type.Size(), Align: rsf.Type.Align()}
+		}
+
 		// Do not include an anonymous field at the top level.
 		// Only include its inner fields.
 		if sa.Field.Anonymous {
-			anonValues := getAttributes(value, parents, filterTags, ignoredFields, currentIndex)
+			anonValues := getAttributes(value, parents, options, currentIndex)
 			sa.Children = append(sa.Children, anonValues...)
 			attributes = append(attributes, anonValues...)
 			continue
 		}
 
-		shouldBeIncluded := len(filterTags) == 0
-		for _, tag := range filterTags {
+		shouldBeIncluded := len(options.FilterTags) == 0
+		for _, tag := range options.FilterTags {
 			_, shouldBeIncluded = sa.Field.Tag.Lookup(tag)
 		}
 
-		if !shouldBeIncluded || Contains(ignoredFields, rsf.Name) {
+		if !shouldBeIncluded || Contains(options.IgnoredFields, rsf.Name) {
+			continue
+		}
+
+		// Unexported fields (i.e. the internals of `time.Time` or `sync.Mutex`) are of no use to callers
+		// and their values cannot be accessed safely.
+		if !rsf.IsExported() && !Contains(options.UnexportedFields, rsf.Name) {
 			continue
 		}
 
 		// Save field
 		attributes = append(attributes, sa)
 
+		// Opaque values and nullable wrappers are never processed any further.
+		if isOpaqueType(value) || isNullable {
+			continue
+		}
+
 		// Check if the field needs further processing.
 		switch value.Kind() {
 		case reflect.Struct:
-			nestedAttributes := getAttributes(value, append(parents, sa), filterTags, ignoredFields, -1)
+			nestedAttributes := getAttributes(value, withParent(parents, sa), options, -1)
 			attributes = append(attributes, nestedAttributes...)
 		case reflect.Slice, reflect.Array:
-			isListOfPrimitives := false
-			newParents := append(parents, sa)
+			children, nestedAttributes := getListAttributes(sa, value, sa.Field, options)
+			attributes[len(attributes)-1].Children = children
+			attributes = append(attributes, nestedAttributes...)
+		case reflect.Map:
+			children, nestedAttributes := getMapAttributes(sa, value, sa.Field, options)
+			attributes[len(attributes)-1].Children = children
+			attributes = append(attributes, nestedAttributes...)
+		}
+	}
+
+	return attributes
+}
+
+// Returns the paths (in bracket notation) of the values that correspond to a field of the given type.
+func populatedPaths(value any, t reflect.Type, scope string) (paths []string) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
 
-			if value.Len() > 0 {
-				containsStructs := value.Index(0).Kind() == reflect.Struct
+	if t == nil || t == RawMessageType {
+		return paths
+	}
 
-				// Google's UUID is a special case. Should not be considered a primitive type.
-				isGoogleUUID := value.Type() == reflect.TypeOf(uuid.New())
+	switch node := value.(type) {
+	case map[string]any:
+		if t.Kind() != reflect.Struct {
+			return paths
+		}
 
-				// Primitive types as in int, string, bool, etc
-				isListOfPrimitives = !containsStructs && !isGoogleUUID
+		fields := jsonFields(t)
+		for k, v := range node {
+			ft, ok := fields[k]
+			if !ok {
+				continue
 			}
 
-			// Process each element in slice/array
-			for l := 0; l < value.Len(); l++ {
-				el := value.Index(l)
+			path := strings.TrimPrefix(scope+"."+k, ".")
+			paths = append(paths, path)
+			paths = append(paths, populatedPaths(v, ft, path)...)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return paths
+		}
 
-				if isListOfPrimitives {
-					child := StructAttribute{
-						Value:        el,
-						Parents:      newParents,
-						ListPosition: l,
-						isPrimitive:  true,
-					}
+		for i, v := range node {
+			path := fmt.Sprint(scope, "[", i, "]")
+			paths = append(paths, path)
+			paths = append(paths, populatedPaths(v, t.Elem(), path)...)
+		}
+	}
 
-					// Copy information from parent StructField
-					child.Field = reflect.StructField{
-						Type:    el.Type(),
-						Name:    child.FullName(),
-						Tag:     sa.Field.Tag,
-						PkgPath: sa.Field.PkgPath,
-					}
+	return paths
+}
 
-					// Exclude some predefined validation tag attributes
-					childTag := RemoveValuesFromTag(VALIDATION_TAG_KEYWORD, NON_INHERITABLE_TAG_ATTRIBUTES, sa.Field)
-					child.Field.Tag = reflect.StructTag(childTag)
+type tagCacheKey struct {
+	tag  reflect.StructTag
+	name string
 
-					attributes[len(attributes)-1].Children = append(sa.Children, child)
-					attributes = append(attributes, child)
-					continue
-				}
+	// Whether the values are the rules of a validation tag. See `GetTagRules`.
+	rules bool
+}
 
-				nestedValues := getAttributes(el, newParents, filterTags, ignoredFields, l)
-				if len(attributes) != 0 {
-					attributes[len(attributes)-1].Children = append(sa.Children, nestedValues...)
-				}
+// Returns the attributes of the elements of the slice/array held by `list`:
+// its direct children, followed by all of them (including their descendants) in the order they should be listed.
+//
+// Elements that are slices/arrays themselves, as the rows of a `[][]float64`, are walked recursively.
+// Since the rules of the field are meant for the innermost elements, the intermediate slices/arrays have no tags.
+func getListAttributes(list StructAttribute, value reflect.Value, field reflect.StructField, options AttributeOptions) (children []StructAttribute, attributes []StructAttribute) {
+	if value.Len() == 0 {
+		return children, attributes
+	}
+
+	parents := withParent(list.Parents, list)
+	elemType := baseType(value.Type().Elem())
+
+	// Google's UUID is a special case. Should not be considered a list of primitive types.
+	if (elemType.Kind() == reflect.Struct && !IsNullableWrapper(elemType)) || value.Type() == uuidType {
+		for l := 0; l < value.Len(); l++ {
+			nestedValues := getAttributes(value.Index(l), parents, options, l)
+			children = append(children, nestedValues...)
+			attributes = append(attributes, nestedValues...)
+		}
+
+		return children, attributes
+	}
+
+	isListOfLists := isNestedList(elemType)
+
+	var childTag reflect.StructTag
+	if !isListOfLists {
+		childTag = reflect.StructTag(elementTag(field, options))
+	}
+
+	for l := 0; l < value.Len(); l++ {
+		// As with fields, pointers to elements are dereferenced
+		el, _ := PointerElement(value.Index(l))
+		el, _ = nullableValue(el)
+
+		child := StructAttribute{
+			Value:        el,
+			Parents:      parents,
+			ListPosition: l,
+			isPrimitive:  true,
+		}
+
+		// Copy information from parent StructField
+		child.Field = reflect.StructField{
+			Type:    value.Index(l).Type(),
+			Name:    child.bracketName(),
+			Tag:     childTag,
+			PkgPath: field.PkgPath,
+		}
+
+		if options.IncludeLayout {
+			size := child.Field.Type.Size()
+			child.Layout = &FieldLayout{Offset: uintptr(l) * size, Size: size, Align: child.Field.Type.Align()}
+		}
+
+		var nestedValues []StructAttribute
+		if isListOfLists && el.Kind() != reflect.Pointer {
+			child.Children, nestedValues = getListAttributes(child, el, field, options)
+		}
+
+		children = append(children, child)
+		attributes = append(append(attributes, child), nestedValues...)
+	}
 
-				attributes = append(attributes, nestedValues...)
+	return children, attributes
+}
+
+// Returns the attributes of the entries of the map held by `m`:
+// its direct children, followed by all of them (including their descendants) in the order they should be listed.
+//
+// Entries are named after their keys (as in `settings.theme`) and sorted by `AttributeOptions.MapKeyLess`.
+// As with the elements of a slice/array, they inherit the inheritable rules of the field.
+// Entries holding slices/arrays or maps themselves have no tags, since those rules are meant for the innermost values.
+func getMapAttributes(m StructAttribute, value reflect.Value, field reflect.StructField, options AttributeOptions) (children []StructAttribute, attributes []StructAttribute) {
+	if value.Len() == 0 {
+		return children, attributes
+	}
+
+	parents := withParent(m.Parents, m)
+	elemType := baseType(value.Type().Elem())
+
+	var childTag reflect.StructTag
+	if !isNestedList(elemType) && elemType.Kind() != reflect.Map {
+		childTag = reflect.StructTag(elementTag(field, options))
+	}
+
+	keys := make(map[string]reflect.Value, value.Len())
+	names := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		name := fmt.Sprint(key.Interface())
+		keys[name] = key
+		names = append(names, name)
+	}
+
+	less := options.MapKeyLess
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+
+	sort.SliceStable(names, func(i, j int) bool { return less(names[i], names[j]) })
+
+	for _, name := range names {
+		// As with fields, pointers to values are dereferenced
+		el, _ := PointerElement(value.MapIndex(keys[name]))
+		el, isNullable := nullableValue(el)
+
+		entry := StructAttribute{
+			Value:        el,
+			Parents:      parents,
+			ListPosition: -1,
+			isMapEntry:   true,
+			Field: reflect.StructField{
+				Type:    value.Type().Elem(),
+				Name:    name,
+				Tag:     childTag,
+				PkgPath: field.PkgPath,
+			},
+		}
+
+		var nestedValues []StructAttribute
+		if !isOpaqueType(el) && !isNullable {
+			switch el.Kind() {
+			case reflect.Struct:
+				nestedValues = getAttributes(el, withParent(parents, entry), options, -1)
+			case reflect.Slice, reflect.Array:
+				entry.Children, nestedValues = getListAttributes(entry, el, field, options)
+			case reflect.Map:
+				entry.Children, nestedValues = getMapAttributes(entry, el, field, options)
 			}
 		}
+
+		children = append(children, entry)
+		attributes = append(append(attributes, entry), nestedValues...)
 	}
 
-	return attributes
+	return children, attributes
+}
+
+// Returns the attributes with the fields of each struct sorted by their JSON names, keeping every attribute
+// followed by its descendants. Elements of slices/arrays and entries of maps keep their order. See `PATH_ORDER`.
+func sortedByPath(attrs StructAttributes) StructAttributes {
+	subtrees := []StructAttributes{}
+	for pos := 0; pos < len(attrs); pos = attrs.NextSibling(pos) {
+		subtrees = append(subtrees, attrs[pos:attrs.NextSibling(pos)])
+	}
+
+	sort.SliceStable(subtrees, func(i, j int) bool {
+		a, b := subtrees[i][0], subtrees[j][0]
+
+		// The fields of the elements of a slice/array of structs are siblings
+		if a.ListPosition != b.ListPosition {
+			return a.ListPosition < b.ListPosition
+		}
+
+		if a.isPrimitive || a.isMapEntry || b.isPrimitive || b.isMapEntry {
+			return false
+		}
+
+		return GetJSONTagValue(a.Field) < GetJSONTagValue(b.Field)
+	})
+
+	sorted := make(StructAttributes, 0, len(attrs))
+	for _, subtree := range subtrees {
+		sorted = append(sorted, subtree[0])
+		sorted = append(sorted, sortedByPath(subtree[1:])...)
+	}
+
+	return sorted
+}
+
+// Reports whether the elements of a slice/array (of the given type, once dereferenced) are slices/arrays themselves.
+// UUIDs and byte slices are treated as single values.
+func isNestedList(elemType reflect.Type) bool {
+	switch elemType.Kind() {
+	case reflect.Slice, reflect.Array:
+		return elemType != uuidType && elemType.Elem().Kind() != reflect.Uint8
+	}
+
+	return false
+}
+
+// Returns the type with all of its pointer layers removed, as in `Author` for `**Author`.
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// Returns a copy of the parents list with the given attribute appended to it.
+// A new backing array is always allocated, so siblings never share (and overwrite) each other's parents.
+func withParent(parents []StructAttribute, parent StructAttribute) []StructAttribute {
+	newParents := make([]StructAttribute, len(parents), len(parents)+1)
+	copy(newParents, parents)
+
+	return append(newParents, parent)
+}
+
+// Returns `true` if the value should be treated as a leaf whose contents are unknown, like `json.RawMessage`.
+func isOpaqueType(rv reflect.Value) bool {
+	if !rv.IsValid() {
+		return false
+	}
+
+	t := rv.Type()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t == RawMessageType
 }
 
+// Removes the given attributes from the value of the specified tag and returns the resulting struct tag.
+// Other tags are left untouched.
+//
+// Usage:
+//
+//	field := reflect.StructField{Tag: `json:"id" check:"min=1,max=255,required"`}
+//	RemoveValuesFromTag("check", []string{"min", "max"}, field) // -> `json:"id" check:"required"`
 func RemoveValuesFromTag(tag string, removeList []string, field reflect.StructField) string {
-	result := string(field.Tag)
+	value, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return string(field.Tag)
+	}
+
+	values := Filter(strings.Split(value, ","), func(_ int, v string) bool {
+		return !Contains(removeList, strings.SplitN(v, "=", 2)[0])
+	})
+
+	return replaceTagValue(field, tag, strings.Join(values, ","))
+}
+
+// Returns the tags the elements of a slice/array field should have.
+//
+// For each of the validation tags, if the field declares rules prefixed by `EACH_RULE_PREFIX`,
+// only those rules are kept (without the prefix).
+// Otherwise, all the rules are inherited, except for the non-inheritable ones.
+func elementTag(field reflect.StructField, options AttributeOptions) string {
+	for _, keyword := range append([]string{VALIDATION_TAG_KEYWORD}, options.AdditionalValidationTags...) {
+		field.Tag = reflect.StructTag(elementRulesTag(field, keyword, options))
+	}
+
+	return string(field.Tag)
+}
+
+func elementRulesTag(field reflect.StructField, keyword string, options AttributeOptions) string {
+	rules := tagRules(field, keyword)
+
+	elementRules := Map(
+		Filter(rules, func(_ int, rule string) bool { return strings.HasPrefix(rule, EACH_RULE_PREFIX) }),
+		func(_ int, rule string) string { return strings.TrimPrefix(rule, EACH_RULE_PREFIX) },
+	)
+
+	if len(elementRules) == 0 {
+		elementRules = Filter(rules, func(_ int, rule string) bool {
+			return !Contains(options.nonInheritableTagAttributes(), strings.SplitN(rule, "=", 2)[0])
+		})
+	}
+
+	return replaceTagValue(field, keyword, strings.Join(elementRules, ","))
+}
+
+// Replaces the value of the specified tag and returns the resulting struct tag.
+func replaceTagValue(field reflect.StructField, tag string, value string) string {
+	if _, ok := field.Tag.Lookup(tag); !ok {
+		return string(field.Tag)
+	}
+
+	pairs, rest := tagPairs(field.Tag)
+	values := make([]string, 0, len(pairs)+1)
+
+	replaced := false
+	for _, pair := range pairs {
+		if pair.key == tag && !replaced {
+			pair.value, replaced = value, true
+		}
+
+		values = append(values, pair.key+":"+strconv.Quote(pair.value))
+	}
+
+	if rest != "" {
+		values = append(values, rest)
+	}
+
+	return strings.Join(values, " ")
+}
+
+// A key and its (unquoted) value, as found in a struct tag.
+type tagPair struct {
+	key   string
+	value string
+}
+
+// Parses the struct tag into its key/value pairs, in the order they appear, following the conventions of `reflect.StructTag.Lookup`.
+// Parsing stops at the first malformed pair, which is returned along with the rest of the tag.
+func tagPairs(tag reflect.StructTag) (pairs []tagPair, rest string) {
+
+	for tag != "" {
+		// Skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
 
-	t := GetTag(field, tag)
-	for _, i := range removeList {
-		if v, ok := t[i]; ok {
-			pattern := regexp.MustCompile(fmt.Sprintf(`,?%v=?%v?,?`, i, v))
-			result = pattern.ReplaceAllString(result, "")
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return pairs, string(tag)
 		}
+
+		key := string(tag[:i])
+		value := tag[i+1:]
+
+		// Scan quoted string to find value
+		i = 1
+		for i < len(value) && value[i] != '"' {
+			if value[i] == '\\' {
+				i++
+			}
+
+			i++
+		}
+
+		if i >= len(value) {
+			return pairs, string(tag)
+		}
+
+		unquoted, err := strconv.Unquote(string(value[:i+1]))
+		if err != nil {
+			return pairs, string(tag)
+		}
+
+		pairs = append(pairs, tagPair{key: key, value: unquoted})
+		tag = value[i+1:]
 	}
 
-	return result
+	return pairs, ""
 }
 
 func matchingFields(rv reflect.Value, parents []string, tag string, requiredKeywords []string) (fields []string) {
@@ -400,7 +1323,6 @@ func matchingFields(rv reflect.Value, parents []string, tag string, requiredKeyw
 
 	for position := 0; position < rv.NumField(); position++ {
 		f := rv.Type().Field(position)
-		value := rv.Field(position)
 
 		prefix := strings.Join(parents, ".")
 		fieldName := strings.TrimPrefix(strings.Join([]string{prefix, GetTagValue(f, "json")}, "."), ".")
@@ -408,11 +1330,11 @@ func matchingFields(rv reflect.Value, parents []string, tag string, requiredKeyw
 			fields = append(fields, fieldName)
 		}
 
-		switch value.Kind() {
+		switch ft := baseType(f.Type); ft.Kind() {
 		case reflect.Array, reflect.Slice:
 			newParents := append(parents, fieldName)
 
-			t := reflect.New(value.Type().Elem())
+			t := reflect.New(baseType(ft.Elem()))
 			fields = append(fields, matchingFields(t, newParents, tag, requiredKeywords)...)
 		}
 	}
@@ -1,8 +1,12 @@
 package structs
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 type Identifiable struct {
@@ -384,6 +388,24 @@ func Test_GetAttributesWithSpecificTags(t *testing.T) {
 	}
 }
 
+func Test_GetAttributes_RawMessage(t *testing.T) {
+	type Envelope struct {
+		Type    string           `json:"type"`
+		Data    json.RawMessage  `json:"data"`
+		Headers *json.RawMessage `json:"headers"`
+	}
+
+	headers := json.RawMessage(`[1, 2]`)
+	envelope := Envelope{Type: "created", Data: json.RawMessage(`{"id": 1}`), Headers: &headers}
+
+	attributes := GetAttributes(reflect.ValueOf(envelope), []string{})
+	got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+	if want := []string{"type", "data", "headers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAttributes() = %v, want %v", got, want)
+	}
+}
+
 func Test_GetTagValues(t *testing.T) {
 	var field reflect.StructField = reflect.StructField{
 		Tag: `json:"id,omitempty" db:"_id"`,
@@ -407,6 +429,43 @@ func Test_GetTagValues(t *testing.T) {
 	}
 }
 
+func Test_GetTagRules(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		want []string
+	}{
+		{name: "no parentheses", tag: `validate:"min=1,max=3"`, want: []string{"min=1", "max=3"}},
+		{name: "comma in parentheses", tag: `validate:"regex(^\\d{1,3}$),max=3"`, want: []string{`regex(^\d{1,3}$)`, "max=3"}},
+		{name: "nested parentheses", tag: `validate:"regex(^(a|b,c)=d$),min=1"`, want: []string{"regex(^(a|b,c)=d$)", "min=1"}},
+		{name: "escaped parenthesis", tag: `validate:"regex(^\\)+,$),min=1"`, want: []string{`regex(^\)+,$)`, "min=1"}},
+		{name: "parenthesis in brackets", tag: `validate:"regex(^[)(,]$),min=1"`, want: []string{"regex(^[)(,]$)", "min=1"}},
+		{name: "empty values", tag: `validate:",min=1,"`, want: []string{"", "min=1", ""}},
+		{name: "quoted value", tag: `validate:"in='a,b|c',min=1"`, want: []string{"in='a,b|c'", "min=1"}},
+		{name: "escaped quote", tag: `validate:"in='it\\',s',min=1"`, want: []string{`in='it\',s'`, "min=1"}},
+		{name: "escaped comma", tag: `validate:"in=a\\,b,min=1"`, want: []string{`in=a\,b`, "min=1"}},
+		{name: "quote within value", tag: `validate:"in=it's,min=1"`, want: []string{"in=it's", "min=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetTagRules(reflect.StructField{Tag: tt.tag}, "validate"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTagRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The values of other tags are still split on every comma
+	field := reflect.StructField{Tag: `validate:"in='a,b'" orm:"default=(a,b)"`}
+	if got := GetTagValues(field, "orm"); !reflect.DeepEqual(got, []string{"default=(a", "b)"}) {
+		t.Errorf("GetTagValues() = %q, want %q", got, []string{"default=(a", "b)"})
+	}
+
+	if got := GetTagValues(field, "validate"); !reflect.DeepEqual(got, []string{"in='a", "b'"}) {
+		t.Errorf("GetTagValues() = %q, want %q", got, []string{"in='a", "b'"})
+	}
+}
+
 func Test_GetTag(t *testing.T) {
 	var field reflect.StructField = reflect.StructField{
 		Tag: `json:"id,omitempty" db:"_id" validate:"min=1,max=255,required"`,
@@ -623,9 +682,10 @@ func Test_SetValuesFromMap(t *testing.T) {
 	}
 
 	tests := []struct {
-		name string
-		args args
-		want any
+		name          string
+		args          args
+		want          any
+		wantPopulated []string
 	}{
 		{
 			name: "example - 1",
@@ -633,7 +693,8 @@ func Test_SetValuesFromMap(t *testing.T) {
 				model:  &Person{},
 				values: map[string]any{"name": "Leonardo", "IsActive": true, "emails": []string{"leo@example.com"}},
 			},
-			want: &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: []string{"leo@example.com"}},
+			want:          &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: []string{"leo@example.com"}},
+			wantPopulated: []string{"IsActive", "emails", "name"},
 		},
 		{
 			name: "example - 2",
@@ -641,7 +702,8 @@ func Test_SetValuesFromMap(t *testing.T) {
 				model:  &Person{},
 				values: map[string]any{"name": 45, "IsActive": 32, "emails": []string{"leo@example.com"}},
 			},
-			want: &Person{Name: nil, IsActive: nil, Emails: []string{"leo@example.com"}},
+			want:          &Person{Name: nil, IsActive: nil, Emails: []string{"leo@example.com"}},
+			wantPopulated: []string{"emails"},
 		},
 		{
 			name: "example - 3",
@@ -649,13 +711,21 @@ func Test_SetValuesFromMap(t *testing.T) {
 				model:  &Person{},
 				values: map[string]any{"name": "Leonardo", "IsActive": true, "emails": 2},
 			},
-			want: &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: nil},
+			want:          &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: nil},
+			wantPopulated: []string{"IsActive", "name"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			SetValuesFromMap(tt.args.model, tt.args.values)
+			populated, err := SetValuesFromMap(tt.args.model, tt.args.values)
+			if err != nil {
+				t.Fatalf(`unexpected error: %v`, err)
+			}
+
+			if !reflect.DeepEqual(populated, tt.wantPopulated) {
+				t.Errorf(`expected populated paths to be %v, but got %v`, tt.wantPopulated, populated)
+			}
 
 			if !reflect.DeepEqual(tt.args.model, tt.want) {
 				t.Errorf(`expected structs to be equal, but got %v != %v`, tt.args.model, tt.want)
@@ -671,9 +741,10 @@ func Test_SetValuesFromBytes(t *testing.T) {
 	}
 
 	tests := []struct {
-		name string
-		args args
-		want any
+		name          string
+		args          args
+		want          any
+		wantPopulated []string
 	}{
 		{
 			name: "example - 1",
@@ -681,7 +752,8 @@ func Test_SetValuesFromBytes(t *testing.T) {
 				model:  &Person{},
 				values: []byte(`{"name": "Leonardo", "IsActive": true, "emails": ["leo@example.com"]}`),
 			},
-			want: &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: []string{"leo@example.com"}},
+			want:          &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: []string{"leo@example.com"}},
+			wantPopulated: []string{"IsActive", "emails", "emails[0]", "name"},
 		},
 		{
 			name: "example - 2",
@@ -689,7 +761,8 @@ func Test_SetValuesFromBytes(t *testing.T) {
 				model:  &Person{},
 				values: []byte(`{"name": 45, "IsActive": 32, "emails": ["leo@example.com"]}`),
 			},
-			want: &Person{Name: nil, IsActive: nil, Emails: []string{"leo@example.com"}},
+			want:          &Person{Name: nil, IsActive: nil, Emails: []string{"leo@example.com"}},
+			wantPopulated: []string{"emails", "emails[0]"},
 		},
 		{
 			name: "example - 3",
@@ -697,13 +770,21 @@ func Test_SetValuesFromBytes(t *testing.T) {
 				model:  &Person{},
 				values: []byte(`{"name": "Leonardo", "IsActive": true, "emails": 2}`),
 			},
-			want: &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: nil},
+			want:          &Person{Name: stringPointer("Leonardo"), IsActive: boolPointer(true), Emails: nil},
+			wantPopulated: []string{"IsActive", "name"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			SetValuesFromBytes(tt.args.model, tt.args.values)
+			populated, err := SetValuesFromBytes(tt.args.model, tt.args.values)
+			if err != nil {
+				t.Fatalf(`unexpected error: %v`, err)
+			}
+
+			if !reflect.DeepEqual(populated, tt.wantPopulated) {
+				t.Errorf(`expected populated paths to be %v, but got %v`, tt.wantPopulated, populated)
+			}
 
 			if !reflect.DeepEqual(tt.args.model, tt.want) {
 				t.Errorf(`expected structs to be equal, but got %v != %v`, tt.args.model, tt.want)
@@ -712,6 +793,14 @@ func Test_SetValuesFromBytes(t *testing.T) {
 	}
 }
 
+func Test_SetValuesFromMap_InvalidModel(t *testing.T) {
+	for _, model := range []any{nil, 0, Person{}, (*Person)(nil), &[]string{}} {
+		if _, err := SetValuesFromMap(model, map[string]any{"name": "Leonardo"}); err != ErrInvalidModel {
+			t.Errorf(`expected %v for model %#v, but got %v`, ErrInvalidModel, model, err)
+		}
+	}
+}
+
 func Test_RemoveValuesFromTag(t *testing.T) {
 	type args struct {
 		tag        string
@@ -751,6 +840,60 @@ func Test_RemoveValuesFromTag(t *testing.T) {
 			},
 			want: `json:"id,omitempty" check:"min=1,max=255"`,
 		},
+		{
+			name: "remove from the middle",
+			args: args{
+				tag:        "validate",
+				removeList: []string{"min"},
+				field:      reflect.StructField{Tag: `json:"tags" validate:"email,min=1,uuid" check:"min=1"`},
+			},
+			want: `json:"tags" validate:"email,uuid" check:"min=1"`,
+		},
+		{
+			name: "values with regex metacharacters",
+			args: args{
+				tag:        "validate",
+				removeList: []string{"max"},
+				field:      reflect.StructField{Tag: `validate:"in=A|B.*,max=3,regex(\\d+)"`},
+			},
+			want: `validate:"in=A|B.*,regex(\\d+)"`,
+		},
+		{
+			name: "missing tag",
+			args: args{
+				tag:        "validate",
+				removeList: []string{"max"},
+				field:      reflect.StructField{Tag: `json:"id"`},
+			},
+			want: `json:"id"`,
+		},
+		{
+			name: "escaped values",
+			args: args{
+				tag:        "validate",
+				removeList: []string{"max"},
+				field:      reflect.StructField{Tag: `validate:"in=caf\u00e9|x,max=3" json:"name"`},
+			},
+			want: `validate:"in=café|x" json:"name"`,
+		},
+		{
+			name: "repeated keys and extra spaces",
+			args: args{
+				tag:        "check",
+				removeList: []string{"min"},
+				field:      reflect.StructField{Tag: `json:"id"   check:"min=1,required" check:"min=2"`},
+			},
+			want: `json:"id" check:"required" check:"min=2"`,
+		},
+		{
+			name: "malformed tail",
+			args: args{
+				tag:        "check",
+				removeList: []string{"min"},
+				field:      reflect.StructField{Tag: `check:"min=1,required" json:id`},
+			},
+			want: `check:"required" json:id`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -761,3 +904,709 @@ func Test_RemoveValuesFromTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetAttributes_SiblingParents(t *testing.T) {
+	type Leaf struct {
+		X string `json:"x"`
+	}
+
+	type Branch struct {
+		P Leaf `json:"p"`
+		Q Leaf `json:"q"`
+	}
+
+	type Trunk struct {
+		C struct {
+			B struct {
+				Branch Branch `json:"branch"`
+			} `json:"b"`
+		} `json:"c"`
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(Trunk{}), []string{})
+	got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+	want := []string{"c", "c.b", "c.b.branch", "c.b.branch.p", "c.b.branch.p.x", "c.b.branch.q", "c.b.branch.q.x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAttributes() = %v, want %v", got, want)
+	}
+}
+
+// MARK: Benchmarks
+
+type benchmarkAddress struct {
+	Street string `json:"street" validate:"min=3"`
+	City   string `json:"city"`
+	Zip    string `json:"zip"`
+}
+
+type benchmarkModel struct {
+	Id        string             `json:"id" validate:"uuid"`
+	Name      string             `json:"name" validate:"min=2,max=8"`
+	Emails    []string           `json:"emails" validate:"min=1,email"`
+	Address   benchmarkAddress   `json:"address"`
+	Addresses []benchmarkAddress `json:"addresses"`
+	Tags      []string           `json:"tags"`
+}
+
+func newBenchmarkModel() benchmarkModel {
+	return benchmarkModel{
+		Id:        "2b852002-f19d-11ec-8ea0-0242ac120002",
+		Name:      "Leonardo",
+		Emails:    []string{"leo@example.com", "leo@example.org"},
+		Address:   benchmarkAddress{Street: "Main St", City: "Boston", Zip: "02110"},
+		Addresses: []benchmarkAddress{{Street: "1st Ave"}, {Street: "2nd Ave"}, {Street: "3rd Ave"}},
+		Tags:      []string{"a", "b", "c", "d", "e"},
+	}
+}
+
+func Test_GetTagValue_Allocations(t *testing.T) {
+	sf := reflect.StructField{Name: "Emails", Tag: `json:"emails,omitempty" validate:"min=1,email"`}
+
+	if allocs := testing.AllocsPerRun(100, func() { GetTagValue(sf, "json") }); allocs != 0 {
+		t.Errorf("GetTagValue() allocated %v times per run, want 0", allocs)
+	}
+
+	// At most the copy of the cached values is allocated
+	if allocs := testing.AllocsPerRun(100, func() { GetTagValues(sf, "validate") }); allocs > 1 {
+		t.Errorf("GetTagValues() allocated %v times per run, want at most 1", allocs)
+	}
+}
+
+func Test_GetTagValues_Copies(t *testing.T) {
+	sf := reflect.StructField{Name: "Emails", Tag: `json:"emails,omitempty" validate:"min=1,email"`}
+
+	values := GetTagValues(sf, "json")
+	values[0] = "changed"
+
+	rules := GetTagRules(sf, "validate")
+	rules[0] = "changed"
+
+	if got := GetTagValues(sf, "json"); !reflect.DeepEqual(got, []string{"emails", "omitempty"}) {
+		t.Errorf("GetTagValues() = %q, want %q", got, []string{"emails", "omitempty"})
+	}
+
+	if got := GetTagRules(sf, "validate"); !reflect.DeepEqual(got, []string{"min=1", "email"}) {
+		t.Errorf("GetTagRules() = %q, want %q", got, []string{"min=1", "email"})
+	}
+}
+
+func Test_GetAttributes_AllocationBudget(t *testing.T) {
+	allocsPerAttribute := func(model benchmarkModel) float64 {
+		rv := reflect.ValueOf(model)
+		allocs := testing.AllocsPerRun(100, func() { GetAttributes(rv, []string{}) })
+
+		return allocs / float64(len(GetAttributes(rv, []string{})))
+	}
+
+	baseline := allocsPerAttribute(newBenchmarkModel())
+
+	// Allocations grow (at most) linearly with the number of attributes
+	model := newBenchmarkModel()
+	for i := 0; i < 5; i++ {
+		model.Addresses = append(model.Addresses, model.Addresses...)
+		model.Tags = append(model.Tags, model.Tags...)
+	}
+
+	if allocs := allocsPerAttribute(model); allocs > baseline {
+		t.Errorf("GetAttributes() allocated %v times per attribute, baseline is %v", allocs, baseline)
+	}
+}
+
+func Benchmark_GetAttributes(b *testing.B) {
+	model := reflect.ValueOf(newBenchmarkModel())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetAttributes(model, []string{})
+	}
+}
+
+func Benchmark_GetAttributes_FilterTags(b *testing.B) {
+	model := reflect.ValueOf(newBenchmarkModel())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetAttributes(model, []string{"validate"})
+	}
+}
+
+func Benchmark_FullName(b *testing.B) {
+	attributes := GetAttributes(reflect.ValueOf(newBenchmarkModel()), []string{})
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, attr := range attributes {
+			attr.FullName()
+		}
+	}
+}
+
+func Benchmark_GetTagValue(b *testing.B) {
+	sf := reflect.StructField{Name: "Emails", Tag: `json:"emails,omitempty" validate:"min=1,email"`}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetTagValue(sf, "json")
+	}
+}
+
+func Benchmark_GetTagValues(b *testing.B) {
+	sf := reflect.StructField{Name: "Emails", Tag: `json:"emails,omitempty" validate:"min=1,email"`}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetTagValues(sf, "validate")
+	}
+}
+
+func Test_GetAttributes_IncludeLayout(t *testing.T) {
+	type Record struct {
+		Flag  bool     `json:"flag"`
+		Count int32    `json:"count"`
+		Codes [2]int32 `json:"codes"`
+	}
+
+	attributes := GetAttributesWithOptions(reflect.ValueOf(Record{}), AttributeOptions{IncludeLayout: true})
+
+	want := map[string]FieldLayout{
+		"flag":     {Offset: 0, Size: 1, Align: 1},
+		"count":    {Offset: 4, Size: 4, Align: 4},
+		"codes":    {Offset: 8, Size: 8, Align: 4},
+		"codes[0]": {Offset: 0, Size: 4, Align: 4},
+		"codes[1]": {Offset: 4, Size: 4, Align: 4},
+	}
+
+	if len(attributes) != len(want) {
+		t.Fatalf("expected exactly %v values, but got %v", len(want), len(attributes))
+	}
+
+	for _, attr := range attributes {
+		if attr.Layout == nil || *attr.Layout != want[attr.FullName()] {
+			t.Errorf("%v.Layout = %+v, want %+v", attr.FullName(), attr.Layout, want[attr.FullName()])
+		}
+	}
+
+	for _, attr := range GetAttributes(reflect.ValueOf(Record{}), []string{}) {
+		if attr.Layout != nil {
+			t.Errorf("%v.Layout = %+v, want nil", attr.FullName(), attr.Layout)
+		}
+	}
+}
+
+func Test_GetAttributes_UnexportedFields(t *testing.T) {
+	type audit struct {
+		CreatedBy string `json:"created_by"`
+		revision  int
+	}
+
+	type Document struct {
+		sync.Mutex
+		audit
+		Title     string     `json:"title"`
+		CreatedAt time.Time  `json:"created_at"`
+		Expires   *time.Time `json:"expires"`
+		Tags      []string   `json:"tags"`
+		secret    string
+		history   []time.Time
+	}
+
+	now := time.Now()
+	doc := &Document{
+		audit:     audit{CreatedBy: "leo", revision: 3},
+		Title:     "Report",
+		CreatedAt: now,
+		Expires:   &now,
+		Tags:      []string{"draft"},
+		secret:    "s3cr3t",
+		history:   []time.Time{now},
+	}
+
+	tests := []struct {
+		name    string
+		options AttributeOptions
+		want    []string
+	}{
+		{
+			name: "skipped by default",
+			want: []string{"created_by", "title", "created_at", "expires", "tags", "tags[0]"},
+		},
+		{
+			name:    "allowed",
+			options: AttributeOptions{UnexportedFields: []string{"secret", "revision", "history"}},
+			want:    []string{"created_by", "revision", "title", "created_at", "expires", "tags", "tags[0]", "secret", "history"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := GetAttributesWithOptions(reflect.ValueOf(doc), tt.options)
+			got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAttributesWithOptions() = %v, want %v", got, tt.want)
+			}
+
+			// Values of exported fields can be accessed safely
+			for _, attr := range attributes {
+				if attr.Field.IsExported() && attr.Value.IsValid() && !attr.Value.CanInterface() {
+					t.Errorf("expected value of %v to be accessible", attr.FullName())
+				}
+			}
+		})
+	}
+}
+
+func Test_GetAttributes_NestedLists(t *testing.T) {
+	type Point struct {
+		X int `json:"x"`
+	}
+
+	type Shape struct {
+		Grid  [][]float64 `json:"grid" validate:"min=1,each:max=9"`
+		Paths [][]Point   `json:"paths"`
+		Keys  [][]byte    `json:"keys"`
+	}
+
+	shape := Shape{
+		Grid:  [][]float64{{1, 2}, {3, 4, 5}},
+		Paths: [][]Point{{}, {{X: 1}, {X: 2}}},
+		Keys:  [][]byte{[]byte("key")},
+	}
+
+	attributes := StructAttributes(GetAttributes(reflect.ValueOf(shape), []string{}))
+	got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+	want := []string{
+		"grid", "grid[0]", "grid[0][0]", "grid[0][1]", "grid[1]", "grid[1][0]", "grid[1][1]", "grid[1][2]",
+		"paths", "paths[0]", "paths[1]", "paths[1][0].x", "paths[1][1].x",
+		"keys", "keys[0]",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetAttributes() = %v, want %v", got, want)
+	}
+
+	children := map[string]int{"grid": 2, "grid[1]": 3, "paths[1]": 2, "keys[0]": 0}
+	for _, attr := range attributes {
+		if n, ok := children[attr.FullName()]; ok && len(attr.Children) != n {
+			t.Errorf("len(%v.Children) = %v, want %v", attr.FullName(), len(attr.Children), n)
+		}
+	}
+
+	// Rules of the field are only applied to the innermost elements
+	tags := map[string]reflect.StructTag{"grid[1]": ``, "grid[1][2]": `json:"grid" validate:"max=9"`}
+	for _, attr := range attributes {
+		if tag, ok := tags[attr.FullName()]; ok && attr.Field.Tag != tag {
+			t.Errorf("%v.Field.Tag = %v, want %v", attr.FullName(), attr.Field.Tag, tag)
+		}
+	}
+}
+
+func Test_GetAttributes_Pointers(t *testing.T) {
+	type Book struct {
+		Authors *[]*Author `json:"authors"`
+		Aliases []*string  `json:"aliases"`
+		Matrix  *[][]*int  `json:"matrix"`
+	}
+
+	alias, n := "leo", 7
+	book := &Book{
+		Authors: &[]*Author{{Id: "1"}, nil},
+		Aliases: []*string{&alias, nil},
+		Matrix:  &[][]*int{{&n}},
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(book), []string{})
+
+	got := map[string]reflect.Kind{}
+	names := []string{}
+	for _, attr := range attributes {
+		names = append(names, attr.FullName())
+		got[attr.FullName()] = attr.Value.Kind()
+	}
+
+	want := map[string]reflect.Kind{
+		"authors":       reflect.Slice,
+		"authors[0].id": reflect.String,
+		"aliases":       reflect.Slice,
+		"aliases[0]":    reflect.String,
+		"aliases[1]":    reflect.Pointer,
+		"matrix":        reflect.Slice,
+		"matrix[0]":     reflect.Slice,
+		"matrix[0][0]":  reflect.Int,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAttributes() = %v, want %v", names, want)
+	}
+}
+
+func Test_SetValuesFromBytes_Pointers(t *testing.T) {
+	type Book struct {
+		Authors *[]*Author `json:"authors"`
+		Alias   **string   `json:"alias"`
+	}
+
+	var book Book
+	populated, err := SetValuesFromBytes(&book, []byte(`{"authors": [{"id": "1"}], "alias": "leo"}`))
+	if err != nil {
+		t.Fatalf("SetValuesFromBytes() error = %v", err)
+	}
+
+	if want := []string{"alias", "authors", "authors[0]", "authors[0].id"}; !reflect.DeepEqual(populated, want) {
+		t.Errorf("SetValuesFromBytes() = %v, want %v", populated, want)
+	}
+
+	if book.Authors == nil || len(*book.Authors) != 1 || (*book.Authors)[0].Id != "1" {
+		t.Errorf("expected authors to be set, but got %v", book.Authors)
+	}
+
+	if book.Alias == nil || **book.Alias != "leo" {
+		t.Errorf("expected alias to be set, but got %v", book.Alias)
+	}
+}
+
+func Test_GetAttributes_Maps(t *testing.T) {
+	type Limit struct {
+		Max int `json:"max"`
+	}
+
+	type Service struct {
+		Settings map[string]string   `json:"settings" validate:"max=3,each:email"`
+		Limits   map[string]*Limit   `json:"limits"`
+		Ports    map[string][]int    `json:"ports"`
+		Empty    map[string]string   `json:"empty"`
+		Nested   map[int]map[int]int `json:"nested"`
+	}
+
+	service := Service{
+		Settings: map[string]string{"b": "b@example.com", "a": "a@example.com", "c": "c@example.com"},
+		Limits:   map[string]*Limit{"cpu": {Max: 2}},
+		Ports:    map[string][]int{"http": {80, 8080}},
+		Nested:   map[int]map[int]int{2: {1: 1}},
+	}
+
+	tests := []struct {
+		name    string
+		options AttributeOptions
+		want    []string
+	}{
+		{
+			name:    "alphabetical order",
+			options: AttributeOptions{},
+			want: []string{
+				"settings", "settings.a", "settings.b", "settings.c",
+				"limits", "limits.cpu", "limits.cpu.max",
+				"ports", "ports.http", "ports.http[0]", "ports.http[1]",
+				"empty",
+				"nested", "nested.2", "nested.2.1",
+			},
+		},
+		{
+			name:    "custom order",
+			options: AttributeOptions{MapKeyLess: func(a, b string) bool { return a > b }},
+			want: []string{
+				"settings", "settings.c", "settings.b", "settings.a",
+				"limits", "limits.cpu", "limits.cpu.max",
+				"ports", "ports.http", "ports.http[0]", "ports.http[1]",
+				"empty",
+				"nested", "nested.2", "nested.2.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := GetAttributesWithOptions(reflect.ValueOf(service), tt.options)
+			got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAttributesWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	attributes := GetAttributes(reflect.ValueOf(service), []string{})
+
+	children := map[string]int{"settings": 3, "limits": 1, "ports.http": 2, "empty": 0}
+	for _, attr := range attributes {
+		if n, ok := children[attr.FullName()]; ok && len(attr.Children) != n {
+			t.Errorf("len(%v.Children) = %v, want %v", attr.FullName(), len(attr.Children), n)
+		}
+	}
+
+	// Entries only inherit the rules meant for them
+	if tag := attributes[1].Field.Tag; tag != `json:"settings" validate:"email"` {
+		t.Errorf("settings.a.Field.Tag = %v, want %v", tag, `json:"settings" validate:"email"`)
+	}
+
+	if value := attributes[5].Value; value.Kind() != reflect.Struct {
+		t.Errorf("limits.cpu.Value.Kind() = %v, want %v", value.Kind(), reflect.Struct)
+	}
+
+	// Panics identify the attribute being walked
+	defer func() {
+		if p, ok := recover().(*AttributePanic); !ok || p.Path != "settings" || p.Value != "boom" {
+			t.Errorf("GetAttributesWithOptions() panic = %v, want an AttributePanic for settings", p)
+		}
+	}()
+
+	GetAttributesWithOptions(reflect.ValueOf(service), AttributeOptions{MapKeyLess: func(a, b string) bool { panic("boom") }})
+	t.Errorf("GetAttributesWithOptions() did not panic")
+}
+
+func Test_GetAttributeByPath(t *testing.T) {
+	type Article struct {
+		Identifiable
+		Title   string    `json:"title" validate:"min=1"`
+		Authors []*Author `json:"authors"`
+	}
+
+	type Library struct {
+		Name     string              `json:"name"`
+		Articles []Article           `json:"articles"`
+		Grid     [][]int             `json:"grid" validate:"each:max=9"`
+		Labels   map[string][]string `json:"labels" validate:"each:min=2"`
+		Shelves  map[string]*Article `json:"shelves"`
+		Codes    map[int]string      `json:"codes"`
+		Raw      json.RawMessage     `json:"raw"`
+		secret   string
+	}
+
+	library := &Library{
+		Name: "City",
+		Articles: []Article{
+			{Identifiable: Identifiable{UUID: "1"}, Title: "First"},
+			{Title: "Second", Authors: []*Author{{Id: "7"}, nil}},
+		},
+		Grid:    [][]int{{1, 2}, {3}},
+		Labels:  map[string][]string{"genre": {"sci-fi", "drama"}},
+		Shelves: map[string]*Article{"top": {Title: "Third"}},
+		Codes:   map[int]string{2: "two"},
+		Raw:     json.RawMessage(`{"a": 1}`),
+		secret:  "s3cr3t",
+	}
+
+	// Every attribute returned by `GetAttributes` can be found by its path
+	for _, want := range GetAttributes(reflect.ValueOf(library), []string{}) {
+		got, err := GetAttributeByPath(library, want.FullName())
+		if err != nil {
+			t.Errorf("GetAttributeByPath(%v) error = %v", want.FullName(), err)
+			continue
+		}
+
+		if got.FullName() != want.FullName() || got.Field.Name != want.Field.Name || got.Field.Tag != want.Field.Tag || len(got.Parents) != len(want.Parents) {
+			t.Errorf("GetAttributeByPath(%v) = %+v, want %+v", want.FullName(), got, want)
+		}
+
+		if got.Value.CanInterface() && !reflect.DeepEqual(got.Value.Interface(), want.Value.Interface()) {
+			t.Errorf("GetAttributeByPath(%v).Value = %v, want %v", want.FullName(), got.Value, want.Value)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		want  any
+		found bool
+	}{
+		{name: "nested field", path: "articles[1].authors[0].id", want: "7", found: true},
+		{name: "embedded field", path: "articles[0].id", want: "1", found: true},
+		{name: "dot notation", path: "articles.1.title", want: "Second", found: true},
+		{name: "json pointer", path: "/grid/0/1", want: 2, found: true},
+		{name: "map entry", path: "labels.genre[1]", want: "drama", found: true},
+		{name: "map of structs", path: "shelves.top.title", want: "Third", found: true},
+		{name: "numeric map key", path: "codes.2", want: "two", found: true},
+		{name: "element of a list of structs", path: "articles[1]", found: false},
+		{name: "out of range", path: "articles[9].title", found: false},
+		{name: "negative index", path: "articles[-1].title", found: false},
+		{name: "negative index of a list", path: "/grid/0/-1", found: false},
+		{name: "nil element", path: "articles[1].authors[1].id", found: false},
+		{name: "unknown field", path: "articles[0].summary", found: false},
+		{name: "unknown map key", path: "labels.mood", found: false},
+		{name: "unexported field", path: "secret", found: false},
+		{name: "opaque value", path: "raw.a", found: false},
+		{name: "index of a struct", path: "name[0]", found: false},
+		{name: "empty path", path: "", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAttributeByPath(library, tt.path)
+
+			if !tt.found {
+				if !errors.Is(err, ErrAttributeNotFound) {
+					t.Errorf("GetAttributeByPath() error = %v, want %v", err, ErrAttributeNotFound)
+				}
+
+				return
+			}
+
+			if err != nil || !reflect.DeepEqual(got.Value.Interface(), tt.want) {
+				t.Errorf("GetAttributeByPath() = %v, %v, want %v", got.Value, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SetValueByPath(t *testing.T) {
+	type Contact struct {
+		Emails []string `json:"emails"`
+		Phone  *string  `json:"phone"`
+	}
+
+	type Account struct {
+		*Identifiable
+		Age      int               `json:"age"`
+		Level    uint8             `json:"level"`
+		Contact  *Contact          `json:"contact"`
+		Authors  []Author          `json:"authors"`
+		Scores   [2]float64        `json:"scores"`
+		Settings map[string]string `json:"settings"`
+		Limits   map[int]*Contact  `json:"limits"`
+		Joined   time.Time         `json:"joined"`
+		secret   string
+	}
+
+	phone := "555-0100"
+
+	tests := []struct {
+		name    string
+		path    string
+		value   any
+		want    Account
+		wantErr error
+	}{
+		{name: "top-level field", path: "age", value: 42, want: Account{Age: 42}},
+		{name: "numeric conversion", path: "age", value: 42.0, want: Account{Age: 42}},
+		{name: "parsed string", path: "age", value: "42", want: Account{Age: 42}},
+		{name: "nil value", path: "age", value: nil, want: Account{}},
+		{name: "embedded pointer", path: "id", value: "1", want: Account{Identifiable: &Identifiable{UUID: "1"}}},
+		{name: "grown slice", path: "contact.emails[2]", value: "leo@example.com", want: Account{Contact: &Contact{Emails: []string{"", "", "leo@example.com"}}}},
+		{name: "pointer field", path: "contact.phone", value: phone, want: Account{Contact: &Contact{Phone: &phone}}},
+		{name: "dot notation", path: "authors.1.id", value: "7", want: Account{Authors: []Author{{}, {Id: "7"}}}},
+		{name: "array element", path: "scores[1]", value: 2, want: Account{Scores: [2]float64{0, 2}}},
+		{name: "map entry", path: "settings.theme", value: "dark", want: Account{Settings: map[string]string{"theme": "dark"}}},
+		{name: "numeric map key", path: "limits.2.emails[0]", value: "a@example.com", want: Account{Limits: map[int]*Contact{2: {Emails: []string{"a@example.com"}}}}},
+		{name: "json conversion", path: "authors", value: []any{map[string]any{"id": "1"}}, want: Account{Authors: []Author{{Id: "1"}}}},
+		{name: "time", path: "joined", value: "2024-01-02T00:00:00Z", want: Account{Joined: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		{name: "overflow", path: "level", value: 300, want: Account{}, wantErr: errors.New("level: cannot convert int to uint8")},
+		{name: "negative unsigned", path: "level", value: -1, want: Account{}, wantErr: errors.New("level: cannot convert int to uint8")},
+		{name: "fraction", path: "age", value: 2.5, want: Account{}, wantErr: errors.New("age: cannot convert float64 to int")},
+		{name: "invalid string", path: "age", value: "old", want: Account{}, wantErr: errors.New("age: cannot convert string to int")},
+		{name: "unknown field", path: "contact.fax", value: "1", want: Account{Contact: &Contact{}}, wantErr: ErrAttributeNotFound},
+		{name: "unexported field", path: "secret", value: "1", want: Account{}, wantErr: ErrAttributeNotFound},
+		{name: "array out of range", path: "scores[2]", value: 1, want: Account{}, wantErr: ErrAttributeNotFound},
+		{name: "negative index", path: "contact.emails[-1]", value: "1", want: Account{Contact: &Contact{}}, wantErr: ErrAttributeNotFound},
+		{name: "negative array index", path: "scores[-1]", value: 1, want: Account{}, wantErr: ErrAttributeNotFound},
+		{name: "slice too long", path: "contact.emails[50000000]", value: "1", want: Account{Contact: &Contact{}}, wantErr: errors.New("slice length exceeds the maximum: contact.emails[50000000]")},
+		{name: "index of a struct", path: "contact[0]", value: 1, want: Account{Contact: &Contact{}}, wantErr: ErrAttributeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var account Account
+			err := SetValueByPath(&account, tt.path, tt.value)
+
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Errorf("SetValueByPath() error = %v", err)
+			case errors.Is(tt.wantErr, ErrAttributeNotFound) && !errors.Is(err, ErrAttributeNotFound):
+				t.Errorf("SetValueByPath() error = %v, want %v", err, tt.wantErr)
+			case tt.wantErr != nil && !errors.Is(tt.wantErr, ErrAttributeNotFound) && (err == nil || err.Error() != tt.wantErr.Error()):
+				t.Errorf("SetValueByPath() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(account, tt.want) {
+				t.Errorf("SetValueByPath() = %+v, want %+v", account, tt.want)
+			}
+		})
+	}
+
+	if err := SetValueByPath(Account{}, "age", 1); !errors.Is(err, ErrInvalidModel) {
+		t.Errorf("SetValueByPath() error = %v, want %v", err, ErrInvalidModel)
+	}
+}
+
+func Test_GetAttributes_Order(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+
+	type Audit struct {
+		UpdatedAt string `json:"updated_at"`
+		CreatedAt string `json:"created_at"`
+	}
+
+	type Person struct {
+		Name      string            `json:"name"`
+		Emails    []string          `json:"emails"`
+		Addresses []Address         `json:"addresses"`
+		Labels    map[string]string `json:"labels"`
+		Audit
+		Age int `json:"age"`
+	}
+
+	person := Person{
+		Name:      "Leo",
+		Emails:    []string{"b@example.com", "a@example.com"},
+		Addresses: []Address{{Street: "Main St", City: "Lisbon"}, {Street: "High St", City: "Porto"}},
+		Labels:    map[string]string{"team": "core", "role": "admin"},
+	}
+
+	tests := []struct {
+		name    string
+		options AttributeOptions
+		want    []string
+	}{
+		{
+			name:    "declaration order",
+			options: AttributeOptions{},
+			want: []string{
+				"name",
+				"emails", "emails[0]", "emails[1]",
+				"addresses", "addresses[0].street", "addresses[0].city", "addresses[1].street", "addresses[1].city",
+				"labels", "labels.role", "labels.team",
+				"updated_at", "created_at",
+				"age",
+			},
+		},
+		{
+			name:    "path order",
+			options: AttributeOptions{Order: PATH_ORDER},
+			want: []string{
+				"addresses", "addresses[0].city", "addresses[0].street", "addresses[1].city", "addresses[1].street",
+				"age",
+				"created_at",
+				"emails", "emails[0]", "emails[1]",
+				"labels", "labels.role", "labels.team",
+				"name",
+				"updated_at",
+			},
+		},
+		{
+			name:    "path order with custom map order",
+			options: AttributeOptions{Order: PATH_ORDER, MapKeyLess: func(a, b string) bool { return a > b }, IgnoredFields: []string{"Addresses"}},
+			want: []string{
+				"age",
+				"created_at",
+				"emails", "emails[0]", "emails[1]",
+				"labels", "labels.team", "labels.role",
+				"name",
+				"updated_at",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The order never changes between calls
+			for i := 0; i < 3; i++ {
+				attributes := GetAttributesWithOptions(reflect.ValueOf(person), tt.options)
+				got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("GetAttributesWithOptions() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package structs
+
+import (
+	"strconv"
+	"strings"
+)
+
+type PathNotation string
+
+const (
+	// Slice positions are wrapped in brackets. This is the default notation.
+	//
+	// Example: `contact.emails[0]`
+	BRACKET_NOTATION PathNotation = "bracket"
+
+	// Slice positions are treated as any other path segment.
+	// Paths in this notation are ambiguous when map keys contain `.` or `[` (i.e. `labels.a.b` for the key `a.b`),
+	// or are numeric. Use `POINTER_NOTATION` when map keys are not known in advance.
+	//
+	// Example: `contact.emails.0`
+	DOT_NOTATION PathNotation = "dot"
+
+	// Paths are formatted as JSON pointers (RFC 6901).
+	//
+	// Example: `/contact/emails/0`
+	POINTER_NOTATION PathNotation = "pointer"
+)
+
+var (
+	// The notation used when formatting attribute paths, such as the ones returned by
+	// `StructAttribute.FullName()` and the keys of the validations returned by `Decode`.
+	//
+	// This setting is read every time a path is formatted and is shared by all goroutines, so it may only be set
+	// during initialization (i.e. in an `init` function), before any struct is walked or decoded.
+	SliceIndexNotation = BRACKET_NOTATION
+)
+
+// A single component of an attribute path.
+// A segment is either the name of a field or the position of an element in a slice/array.
+type PathSegment struct {
+	Name    string
+	Index   int
+	IsIndex bool
+}
+
+// Splits an attribute path into its segments.
+//
+// Paths in bracket and dot notations are supported, as well as JSON pointers (paths starting with `/`).
+// Numeric segments are treated as slice positions. Negative numbers are not positions, so they are kept as names.
+//
+// Usage:
+//
+//	ParsePath("articles[1].authors[0].id")
+//	// -> [{Name: articles}, {Index: 1, IsIndex: true}, {Name: authors}, {Index: 0, IsIndex: true}, {Name: id}]
+//
+//	ParsePath("/articles/1/authors/0/id") // -> same as above
+func ParsePath(path string) (segments []PathSegment) {
+	if strings.HasPrefix(path, "/") {
+		unescape := strings.NewReplacer("~1", "/", "~0", "~")
+
+		for _, part := range strings.Split(path[1:], "/") {
+			segments = append(segments, newPathSegment(unescape.Replace(part)))
+		}
+
+		return segments
+	}
+
+	for _, part := range strings.Split(path, ".") {
+		for part != "" {
+			open := strings.IndexByte(part, '[')
+
+			if open == -1 {
+				segments = append(segments, newPathSegment(part))
+				break
+			}
+
+			if open > 0 {
+				segments = append(segments, PathSegment{Name: part[:open]})
+			}
+
+			end := strings.IndexByte(part[open:], ']')
+			if end == -1 {
+				segments = append(segments, PathSegment{Name: part[open:]})
+				break
+			}
+
+			index, err := strconv.Atoi(part[open+1 : open+end])
+			if err != nil || index < 0 {
+				segments = append(segments, PathSegment{Name: part[open : open+end+1]})
+			} else {
+				segments = append(segments, PathSegment{Index: index, IsIndex: true})
+			}
+
+			part = part[open+end+1:]
+		}
+	}
+
+	return segments
+}
+
+// Joins the given segments using the provided notation.
+//
+// Usage:
+//
+//	segments := ParsePath("articles[1].id")
+//
+//	FormatPath(segments, BRACKET_NOTATION) // -> "articles[1].id"
+//	FormatPath(segments, DOT_NOTATION)     // -> "articles.1.id"
+//	FormatPath(segments, POINTER_NOTATION) // -> "/articles/1/id"
+func FormatPath(segments []PathSegment, notation PathNotation) string {
+	var sb strings.Builder
+
+	for position, segment := range segments {
+		value := segment.Name
+		if segment.IsIndex {
+			value = strconv.Itoa(segment.Index)
+		}
+
+		switch notation {
+		case POINTER_NOTATION:
+			sb.WriteByte('/')
+			sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(value))
+		case DOT_NOTATION:
+			if position > 0 {
+				sb.WriteByte('.')
+			}
+
+			sb.WriteString(value)
+		default:
+			if segment.IsIndex {
+				sb.WriteString("[" + value + "]")
+				continue
+			}
+
+			if position > 0 {
+				sb.WriteByte('.')
+			}
+
+			sb.WriteString(value)
+		}
+	}
+
+	return sb.String()
+}
+
+func newPathSegment(value string) PathSegment {
+	if index, err := strconv.Atoi(value); err == nil && index >= 0 {
+		return PathSegment{Index: index, IsIndex: true}
+	}
+
+	return PathSegment{Name: value}
+}
+
+// Converts a path in bracket notation to the notation set in `SliceIndexNotation`.
+func notatedPath(path string) string {
+	if SliceIndexNotation == BRACKET_NOTATION || SliceIndexNotation == "" {
+		return path
+	}
+
+	return FormatPath(ParsePath(path), SliceIndexNotation)
+}
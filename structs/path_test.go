@@ -0,0 +1,123 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_SliceIndexNotation(t *testing.T) {
+	type Contact struct {
+		Emails []string `json:"emails"`
+	}
+
+	type Person struct {
+		Name     string    `json:"name"`
+		Contacts []Contact `json:"contacts"`
+	}
+
+	person := Person{
+		Name:     "Leonardo",
+		Contacts: []Contact{{Emails: []string{"leo@example.com"}}},
+	}
+
+	tests := []struct {
+		name     string
+		notation PathNotation
+		want     []string
+	}{
+		{
+			name:     "bracket",
+			notation: BRACKET_NOTATION,
+			want:     []string{"name", "contacts", "contacts[0].emails", "contacts[0].emails[0]"},
+		},
+		{
+			name:     "dot",
+			notation: DOT_NOTATION,
+			want:     []string{"name", "contacts", "contacts.0.emails", "contacts.0.emails.0"},
+		},
+		{
+			name:     "pointer",
+			notation: POINTER_NOTATION,
+			want:     []string{"/name", "/contacts", "/contacts/0/emails", "/contacts/0/emails/0"},
+		},
+	}
+
+	// The notation is a package-level setting, so these cases must not run in parallel with other tests
+	defer func() { SliceIndexNotation = BRACKET_NOTATION }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SliceIndexNotation = tt.notation
+
+			attributes := GetAttributes(reflect.ValueOf(person), []string{})
+			got := Map(attributes, func(_ int, attr StructAttribute) string { return attr.FullName() })
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FullName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("decoder", func(t *testing.T) {
+		SliceIndexNotation = POINTER_NOTATION
+
+		var model Person
+		got := Decode([]byte(`{"name": 1}`), &model, DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE}})
+
+		want := map[string][]string{"/name": {"INVALID_TYPE"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Decode() = %v, want %v", got, want)
+		}
+	})
+}
+
+func Test_FormatPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		notation PathNotation
+		want     string
+	}{
+		{name: "bracket", path: "articles[1].authors[0].id", notation: BRACKET_NOTATION, want: "articles[1].authors[0].id"},
+		{name: "dot", path: "articles[1].authors[0].id", notation: DOT_NOTATION, want: "articles.1.authors.0.id"},
+		{name: "pointer", path: "articles[1].authors[0].id", notation: POINTER_NOTATION, want: "/articles/1/authors/0/id"},
+		{name: "pointer - escaping", path: "a/b.c~d", notation: POINTER_NOTATION, want: "/a~1b/c~0d"},
+		{name: "matrix", path: "grid[1][2]", notation: DOT_NOTATION, want: "grid.1.2"},
+		{name: "from dot", path: "articles.1.id", notation: BRACKET_NOTATION, want: "articles[1].id"},
+		{name: "from pointer", path: "/a~1b/1/c~0d", notation: BRACKET_NOTATION, want: "a/b[1].c~d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPath(ParsePath(tt.path), tt.notation); got != tt.want {
+				t.Errorf("FormatPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParsePath(t *testing.T) {
+	want := []PathSegment{
+		{Name: "articles"},
+		{Index: 1, IsIndex: true},
+		{Name: "authors"},
+		{Index: 0, IsIndex: true},
+		{Name: "id"},
+	}
+
+	for _, path := range []string{"articles[1].authors[0].id", "articles.1.authors.0.id", "/articles/1/authors/0/id"} {
+		if got := ParsePath(path); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParsePath(%v) = %v, want %v", path, got, want)
+		}
+	}
+
+	// Negative numbers are not positions
+	negative := []PathSegment{{Name: "articles"}, {Name: "[-1]"}, {Name: "id"}}
+	if got := ParsePath("articles[-1].id"); !reflect.DeepEqual(got, negative) {
+		t.Errorf("ParsePath() = %v, want %v", got, negative)
+	}
+
+	if got := ParsePath(""); len(got) != 0 {
+		t.Errorf("ParsePath() = %v, want empty", got)
+	}
+}
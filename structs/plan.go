@@ -0,0 +1,435 @@
+package structs
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// The version of the format of the plans produced by `NewAttributePlan`.
+const ATTRIBUTE_PLAN_VERSION int = 1
+
+// The fields of a model, in the order they are walked by `GetAttributes`, along with their tags.
+// Fields ignored by encoding/json (`json:"-"`) are left out, since they are never found in JSON documents.
+// A plan can be exported to JSON and loaded back by services that do not have access to the Go type,
+// so that they can extract (and validate) the attributes of JSON documents following the same contract.
+//
+// Usage:
+//
+//	data, _ := json.Marshal(NewAttributePlan(Person{}))
+//	...
+//	plan, _ := LoadAttributePlan(data)
+//	attributes := plan.Attributes(document, AttributeOptions{})
+type AttributePlan struct {
+	Version int `json:"version"`
+
+	// The name of the Go type the plan was created from, as in `main.Person`.
+	Type string `json:"type"`
+
+	Fields []PlannedField `json:"fields"`
+}
+
+// A single field of an `AttributePlan`.
+type PlannedField struct {
+	// The path of the field. List elements are represented by `[]`, as in `addresses[].street`. See `FieldDoc`.
+	Path string `json:"path"`
+
+	// The name of the field in the Go type.
+	Name string `json:"name"`
+
+	// The kind of the field, once pointers are dereferenced, as in `string` or `slice`.
+	// Types encoding themselves are planned with the kinds of their JSON values:
+	// `encoding.TextMarshaler` types (as `time.Time`) and `[]byte` are strings, and other `json.Marshaler` types are `interface`.
+	Kind string `json:"kind"`
+
+	// Whether the field is a pointer and accepts `null`.
+	Nullable bool `json:"nullable,omitempty"`
+
+	// The full struct tag of the field.
+	Tag string `json:"tag,omitempty"`
+}
+
+// Zero values of the kinds a field can have, used for the fields missing from a document.
+var plannedKindZeroValues = func() map[string]reflect.Value {
+	values := map[string]reflect.Value{}
+	for _, v := range []any{
+		false, "", 0, int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), float32(0), float64(0),
+		map[string]any{}, []any{},
+	} {
+		values[reflect.TypeOf(v).Kind().String()] = reflect.ValueOf(v)
+	}
+
+	values[reflect.Array.String()] = values[reflect.Slice.String()]
+	values[reflect.Struct.String()] = values[reflect.Map.String()]
+
+	return values
+}()
+
+// A nil pointer, used for nullable fields that are missing from a document or are `null`.
+var nilPlannedValue = reflect.Zero(reflect.TypeOf((*any)(nil)))
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Returns the plan of the given model (a struct or a pointer to one).
+// Recursive types are only planned once, so their fields are not extracted past the first level of recursion.
+func NewAttributePlan(model any) AttributePlan {
+	plan := AttributePlan{Version: ATTRIBUTE_PLAN_VERSION, Fields: []PlannedField{}}
+
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return plan
+	}
+
+	plan.Type = baseType(t).String()
+	plan.Fields = plannedFields(t, "", map[reflect.Type]bool{})
+
+	return plan
+}
+
+// Loads a plan exported as JSON. See `NewAttributePlan`.
+func LoadAttributePlan(data []byte) (AttributePlan, error) {
+	var plan AttributePlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return plan, err
+	}
+
+	if plan.Version != ATTRIBUTE_PLAN_VERSION {
+		return plan, errors.New("unsupported attribute plan version")
+	}
+
+	return plan, nil
+}
+
+// Extracts the attributes of a JSON document (decoded into a map) following the plan.
+// The attributes are the ones `GetAttributes` would return for the Go type the plan was created from,
+// except that their values are the ones found in the document (i.e. numbers are `float64` and structs are maps).
+//
+// Fields missing from the document are assigned the zero values of their kinds, or a nil pointer if they are nullable.
+// Values not described by the plan are ignored, and so are the values that do not have the kinds of their fields.
+// See `CheckedAttributes`.
+func (plan AttributePlan) Attributes(document map[string]any, options AttributeOptions) StructAttributes {
+	attributes, _ := plan.CheckedAttributes(document, options)
+	return attributes
+}
+
+// Extracts the attributes of a JSON document following the plan, like `Attributes`, along with the attributes
+// of the values that do not have the kinds of their fields (i.e. a string found in an `int` field).
+// Those values, and whatever they hold, are left out of the attributes, since the tags of their fields do not apply to them.
+//
+// Usage:
+//
+//	attributes, mismatched := plan.CheckedAttributes(map[string]any{"age": "old"}, AttributeOptions{})
+//	mismatched[0].FullName() // -> age
+func (plan AttributePlan) CheckedAttributes(document map[string]any, options AttributeOptions) (attributes StructAttributes, mismatched StructAttributes) {
+	scopes := map[string][]PlannedField{}
+	for _, field := range plan.Fields {
+		scope := plannedScope(field.Path)
+		scopes[scope] = append(scopes[scope], field)
+	}
+
+	attributes = plannedAttributes(document, "", []StructAttribute{}, 0, scopes, options, &mismatched)
+
+	return attributes, mismatched
+}
+
+func plannedFields(t reflect.Type, scope string, visiting map[reflect.Type]bool) (fields []PlannedField) {
+	t = baseType(t)
+
+	// Recursive types are only planned once
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return fields
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		if sf.Anonymous {
+			fields = append(fields, plannedFields(sf.Type, scope, visiting)...)
+			continue
+		}
+
+		name := GetJSONTagValue(sf)
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+
+		ft := baseType(sf.Type)
+		path := strings.TrimPrefix(scope+"."+name, ".")
+
+		field := PlannedField{
+			Path:     path,
+			Name:     sf.Name,
+			Kind:     ft.Kind().String(),
+			Nullable: sf.Type.Kind() == reflect.Pointer,
+			Tag:      string(sf.Tag),
+		}
+
+		// Nullable wrappers are planned as pointers to the values they hold,
+		// whose kind is only known in advance for wrappers as `sql.NullString`
+		isNullable := IsNullableWrapper(ft)
+		if position, ok := nullableField(ft); ok {
+			field.Kind = baseType(ft.Field(position).Type).Kind().String()
+		} else if isNullable {
+			field.Kind = reflect.Interface.String()
+		}
+
+		field.Nullable = field.Nullable || isNullable
+
+		// Types encoding themselves are planned with the kinds of their JSON values
+		marshalsJSON, marshalsText := reflect.PointerTo(ft).Implements(jsonMarshalerType), reflect.PointerTo(ft).Implements(textMarshalerType)
+		isBytes := ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Uint8
+
+		encodesItself := !isNullable && (marshalsJSON || marshalsText || isBytes)
+		if encodesItself && (marshalsText || !marshalsJSON) {
+			field.Kind = reflect.String.String()
+		} else if encodesItself {
+			field.Kind = reflect.Interface.String()
+		}
+
+		fields = append(fields, field)
+
+		if ft == RawMessageType || ft == uuidType || isNullable || encodesItself {
+			continue
+		}
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			fields = append(fields, plannedFields(ft, path, visiting)...)
+		case reflect.Slice, reflect.Array:
+			elem, elemScope := baseType(ft.Elem()), path+"[]"
+			for isNestedList(elem) {
+				elem, elemScope = baseType(elem.Elem()), elemScope+"[]"
+			}
+
+			fields = append(fields, plannedFields(elem, elemScope, visiting)...)
+		}
+	}
+
+	return fields
+}
+
+// Returns the attributes of the fields planned for the given scope, found in an object of the document.
+// The attributes of the values that do not have the kinds of their fields are added to `mismatched` instead.
+func plannedAttributes(object map[string]any, scope string, parents []StructAttribute, currentIndex int, scopes map[string][]PlannedField, options AttributeOptions, mismatched *StructAttributes) (attributes []StructAttribute) {
+	for _, field := range scopes[scope] {
+		raw := object[strings.TrimPrefix(field.Path, plannedScope(field.Path)+".")]
+		value := field.value(raw)
+
+		sa := StructAttribute{
+			Value:        value,
+			Field:        reflect.StructField{Name: field.Name, Type: value.Type(), Tag: reflect.StructTag(field.Tag)},
+			Parents:      parents,
+			ListPosition: currentIndex,
+		}
+
+		shouldBeIncluded := len(options.FilterTags) == 0
+		for _, tag := range options.FilterTags {
+			_, shouldBeIncluded = sa.Field.Tag.Lookup(tag)
+		}
+
+		if !shouldBeIncluded || Contains(options.IgnoredFields, field.Name) {
+			continue
+		}
+
+		if !field.accepts(raw) {
+			*mismatched = append(*mismatched, sa)
+			continue
+		}
+
+		attributes = append(attributes, sa)
+		position := len(attributes) - 1
+
+		switch v := value.Interface().(type) {
+		case map[string]any:
+			if field.Kind == reflect.Struct.String() {
+				attributes = append(attributes, plannedAttributes(v, field.Path, withParent(parents, sa), -1, scopes, options, mismatched)...)
+			}
+
+			if field.Kind == reflect.Map.String() {
+				childTag := reflect.StructTag(elementTag(sa.Field, options))
+
+				children, nestedValues := plannedMapAttributes(v, withParent(parents, sa), childTag, options)
+				attributes[position].Children = children
+				attributes = append(attributes, nestedValues...)
+			}
+		case []any:
+			if field.Kind == reflect.Slice.String() || field.Kind == reflect.Array.String() {
+				childTag := reflect.StructTag(elementTag(sa.Field, options))
+
+				children, nestedValues := plannedListAttributes(v, field.Path+"[]", withParent(parents, sa), childTag, scopes, options, mismatched)
+				attributes[position].Children = children
+				attributes = append(attributes, nestedValues...)
+			}
+		}
+	}
+
+	return attributes
+}
+
+// Returns the attributes of the elements of a list: its direct children, followed by all of them (including their descendants).
+// See `getListAttributes`.
+func plannedListAttributes(list []any, scope string, parents []StructAttribute, childTag reflect.StructTag, scopes map[string][]PlannedField, options AttributeOptions, mismatched *StructAttributes) (children []StructAttribute, attributes []StructAttribute) {
+	for l, item := range list {
+		if object, ok := item.(map[string]any); ok {
+			nestedValues := plannedAttributes(object, scope, parents, l, scopes, options, mismatched)
+			children = append(children, nestedValues...)
+			attributes = append(attributes, nestedValues...)
+			continue
+		}
+
+		el := reflect.ValueOf(item)
+		if item == nil {
+			el = nilPlannedValue
+		}
+
+		child := StructAttribute{
+			Value:        el,
+			Parents:      parents,
+			ListPosition: l,
+			isPrimitive:  true,
+		}
+
+		// Rows of nested lists have no tags of their own. See `getListAttributes`.
+		items, isList := item.([]any)
+
+		child.Field = reflect.StructField{Type: el.Type(), Name: child.bracketName()}
+		if !isList {
+			child.Field.Tag = childTag
+		}
+
+		// Lists of structs only hold objects
+		if item != nil && len(scopes[scope]) != 0 {
+			*mismatched = append(*mismatched, child)
+			continue
+		}
+
+		var nestedValues []StructAttribute
+		if isList {
+			child.Children, nestedValues = plannedListAttributes(items, scope+"[]", withParent(parents, child), childTag, scopes, options, mismatched)
+		}
+
+		children = append(children, child)
+		attributes = append(append(attributes, child), nestedValues...)
+	}
+
+	return children, attributes
+}
+
+// Returns the attributes of the entries of a map: its direct children, followed by all of them (including their descendants).
+// Plans do not describe the values of maps, so entries holding objects are walked as nested maps.
+// See `getMapAttributes`.
+func plannedMapAttributes(object map[string]any, parents []StructAttribute, childTag reflect.StructTag, options AttributeOptions) (children []StructAttribute, attributes []StructAttribute) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	less := options.MapKeyLess
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+
+	for _, key := range keys {
+		el := reflect.ValueOf(object[key])
+		if object[key] == nil {
+			el = nilPlannedValue
+		}
+
+		entry := StructAttribute{
+			Value:        el,
+			Field:        reflect.StructField{Type: el.Type(), Name: key},
+			Parents:      parents,
+			ListPosition: -1,
+			isMapEntry:   true,
+		}
+
+		// Entries holding lists or objects have no tags of their own. See `getMapAttributes`.
+		var nestedValues []StructAttribute
+		switch v := object[key].(type) {
+		case map[string]any:
+			entry.Children, nestedValues = plannedMapAttributes(v, withParent(parents, entry), childTag, options)
+		case []any:
+			entry.Children, nestedValues = plannedListAttributes(v, "", withParent(parents, entry), childTag, nil, options, new(StructAttributes))
+		default:
+			entry.Field.Tag = childTag
+		}
+
+		children = append(children, entry)
+		attributes = append(append(attributes, entry), nestedValues...)
+	}
+
+	return children, attributes
+}
+
+// Returns the value of the field found in a document, or the value it should have if it is missing or `null`.
+func (field PlannedField) value(raw any) reflect.Value {
+	if raw != nil {
+		return reflect.ValueOf(raw)
+	}
+
+	if zero, ok := plannedKindZeroValues[field.Kind]; ok && !field.Nullable {
+		return zero
+	}
+
+	return nilPlannedValue
+}
+
+// Reports whether a value found in a document (or `null`) has the kind of the field.
+// Numbers are accepted by all numeric kinds, since documents do not tell integers and floats apart.
+func (field PlannedField) accepts(raw any) bool {
+	if raw == nil {
+		return true
+	}
+
+	switch field.Kind {
+	case reflect.String.String():
+		_, ok := raw.(string)
+		return ok
+	case reflect.Bool.String():
+		_, ok := raw.(bool)
+		return ok
+	case reflect.Struct.String(), reflect.Map.String():
+		_, ok := raw.(map[string]any)
+		return ok
+	case reflect.Slice.String(), reflect.Array.String():
+		_, ok := raw.([]any)
+		return ok
+	}
+
+	if _, ok := plannedKindZeroValues[field.Kind]; !ok {
+		return true
+	}
+
+	if _, ok := raw.(json.Number); ok {
+		return true
+	}
+
+	switch reflect.ValueOf(raw).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// Returns the path of the struct containing the field with the given path.
+func plannedScope(path string) string {
+	if end := strings.LastIndexByte(path, '.'); end != -1 {
+		return path[:end]
+	}
+
+	return ""
+}
@@ -0,0 +1,157 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type planPoint struct {
+	X int `json:"x" validate:"min=0"`
+}
+
+type planShape struct {
+	Identifiable
+	Name    string            `json:"name" validate:"min=2"`
+	Tags    []string          `json:"tags" validate:"max=3,each:min=1"`
+	Origin  *planPoint        `json:"origin"`
+	Paths   [][]planPoint     `json:"paths"`
+	Grid    [][]float64       `json:"grid"`
+	Labels  map[string]string `json:"labels" validate:"each:min=1"`
+	Secret  string            `json:"-"`
+	private string
+}
+
+func Test_NewAttributePlan(t *testing.T) {
+	plan := NewAttributePlan(&planShape{})
+
+	want := AttributePlan{
+		Version: ATTRIBUTE_PLAN_VERSION,
+		Type:    "structs.planShape",
+		Fields: []PlannedField{
+			{Path: "id", Name: "UUID", Kind: "string", Tag: `json:"id"`},
+			{Path: "name", Name: "Name", Kind: "string", Tag: `json:"name" validate:"min=2"`},
+			{Path: "tags", Name: "Tags", Kind: "slice", Tag: `json:"tags" validate:"max=3,each:min=1"`},
+			{Path: "origin", Name: "Origin", Kind: "struct", Nullable: true, Tag: `json:"origin"`},
+			{Path: "origin.x", Name: "X", Kind: "int", Tag: `json:"x" validate:"min=0"`},
+			{Path: "paths", Name: "Paths", Kind: "slice", Tag: `json:"paths"`},
+			{Path: "paths[][].x", Name: "X", Kind: "int", Tag: `json:"x" validate:"min=0"`},
+			{Path: "grid", Name: "Grid", Kind: "slice", Tag: `json:"grid"`},
+			{Path: "labels", Name: "Labels", Kind: "map", Tag: `json:"labels" validate:"each:min=1"`},
+		},
+	}
+
+	if !reflect.DeepEqual(plan, want) {
+		t.Fatalf("NewAttributePlan() = %+v, want %+v", plan, want)
+	}
+
+	data, _ := json.Marshal(plan)
+	loaded, err := LoadAttributePlan(data)
+	if err != nil || !reflect.DeepEqual(loaded, plan) {
+		t.Errorf("LoadAttributePlan() = %+v, %v, want %+v", loaded, err, plan)
+	}
+
+	if _, err := LoadAttributePlan([]byte(`{"version": 99}`)); err == nil {
+		t.Errorf("LoadAttributePlan() expected an error for an unsupported version")
+	}
+}
+
+func Test_AttributePlan_Attributes(t *testing.T) {
+	shape := planShape{
+		Identifiable: Identifiable{UUID: "1"},
+		Name:         "Square",
+		Tags:         []string{"a", "b"},
+		Origin:       &planPoint{X: 1},
+		Paths:        [][]planPoint{{{X: 1}}, {{X: 2}, {X: 3}}},
+		Grid:         [][]float64{{1, 2}},
+		Labels:       map[string]string{"b": "2", "a": "1"},
+	}
+
+	data, _ := json.Marshal(shape)
+
+	var document map[string]any
+	_ = json.Unmarshal(data, &document)
+
+	summarize := func(attributes []StructAttribute) (names []string, tags []reflect.StructTag) {
+		for _, attr := range attributes {
+			names = append(names, attr.FullName())
+			tags = append(tags, attr.Field.Tag)
+		}
+
+		return names, tags
+	}
+
+	gotNames, gotTags := summarize(NewAttributePlan(shape).Attributes(document, AttributeOptions{}))
+	// Fields ignored by encoding/json are not planned
+	wantNames, wantTags := summarize(GetAttributes(reflect.ValueOf(shape), []string{}, "Secret"))
+
+	if !reflect.DeepEqual(gotNames, wantNames) {
+		t.Errorf("Attributes() = %v, want %v", gotNames, wantNames)
+	}
+
+	if !reflect.DeepEqual(gotTags, wantTags) {
+		t.Errorf("Attributes() tags = %v, want %v", gotTags, wantTags)
+	}
+}
+
+func Test_AttributePlan_Attributes_MissingValues(t *testing.T) {
+	attributes := NewAttributePlan(planShape{}).Attributes(map[string]any{"name": nil}, AttributeOptions{})
+
+	got := map[string]any{}
+	for _, attr := range attributes {
+		if attr.Value.Kind() == reflect.Pointer {
+			got[attr.FullName()] = nil
+			continue
+		}
+
+		got[attr.FullName()] = attr.Value.Interface()
+	}
+
+	want := map[string]any{"id": "", "name": "", "tags": []any{}, "origin": nil, "paths": []any{}, "grid": []any{}, "labels": map[string]any{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Attributes() = %v, want %v", got, want)
+	}
+}
+
+func Test_AttributePlan_CheckedAttributes(t *testing.T) {
+	type Event struct {
+		At      time.Time       `json:"at"`
+		Payload json.RawMessage `json:"payload"`
+		Digest  []byte          `json:"digest"`
+	}
+
+	if got, want := NewAttributePlan(Event{}).Fields, []PlannedField{
+		{Path: "at", Name: "At", Kind: "string", Tag: `json:"at"`},
+		{Path: "payload", Name: "Payload", Kind: "interface", Tag: `json:"payload"`},
+		{Path: "digest", Name: "Digest", Kind: "string", Tag: `json:"digest"`},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewAttributePlan() fields = %+v, want %+v", got, want)
+	}
+
+	document := map[string]any{
+		"name":   1.0,
+		"tags":   map[string]any{"a": "b"},
+		"origin": map[string]any{"x": "1"},
+		"paths":  []any{[]any{map[string]any{"x": 1.0}, "a"}},
+		"labels": map[string]any{"a": "1"},
+	}
+
+	attributes, mismatched := NewAttributePlan(planShape{}).CheckedAttributes(document, AttributeOptions{})
+
+	names := func(attributes []StructAttribute) (names []string) {
+		for _, attr := range attributes {
+			names = append(names, attr.FullName())
+		}
+
+		return names
+	}
+
+	if got, want := names(mismatched), []string{"name", "tags", "origin.x", "paths[0][1]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckedAttributes() mismatched = %v, want %v", got, want)
+	}
+
+	if got, want := names(attributes), []string{"id", "origin", "paths", "paths[0]", "paths[0][0].x", "grid", "labels", "labels.a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckedAttributes() = %v, want %v", got, want)
+	}
+}
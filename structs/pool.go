@@ -0,0 +1,44 @@
+package structs
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Scratch buffers reused across calls in order to reduce allocations in the decoding path.
+// JSON schemas are not pooled: they are marshaled once per model type and options, and cached. See `cachedSchema`.
+var (
+	bufferPool = sync.Pool{
+		New: func() any { return new(bytes.Buffer) },
+	}
+
+	valuesPool = sync.Pool{
+		New: func() any { return map[string]any{} },
+	}
+)
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	// Very large buffers are not worth keeping around
+	if buf.Cap() > 1<<16 {
+		return
+	}
+
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+func getValues() map[string]any {
+	return valuesPool.Get().(map[string]any)
+}
+
+func putValues(values map[string]any) {
+	for k := range values {
+		delete(values, k)
+	}
+
+	valuesPool.Put(values)
+}
@@ -0,0 +1,210 @@
+package structs
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// The literal name of the tag marking a field as sortable.
+	//
+	// Example:
+	//
+	//	type User struct {
+	//		CreatedAt time.Time `json:"created_at" db:"created_at" sort:""`
+	//	}
+	SORT_TAG_KEYWORD string = "sort"
+
+	// The literal name of the tag marking a field as filterable, listing the operators that are allowed.
+	// When no operator is listed, only `eq` is allowed.
+	//
+	// Example:
+	//
+	//	type User struct {
+	//		Name string `json:"name" db:"full_name" filter:"eq,like"`
+	//		Age  int    `json:"age" db:"age" filter:"eq,gt,lt"`
+	//	}
+	FILTER_TAG_KEYWORD string = "filter"
+
+	// The operator used by filters that do not specify one.
+	DEFAULT_FILTER_OPERATOR string = "eq"
+)
+
+type (
+	// A field that can be used for filtering.
+	FilterField struct {
+		// The database column of the field, taken from the `db` tag (or the JSON name when there's no such tag).
+		Column string
+
+		// The operators allowed for this field.
+		Operators []string
+	}
+
+	// A sort criterion requested by the client.
+	SortField struct {
+		Field      string
+		Column     string
+		Descending bool
+	}
+
+	// A filter condition requested by the client.
+	FilterCondition struct {
+		Field    string
+		Column   string
+		Operator string
+		Value    string
+	}
+)
+
+// Returns the fields of the model containing the `sort` tag, mapping their JSON names to their database columns.
+//
+// Usage:
+//
+//	Sortable(User{}) // -> {"created_at": "created_at"}
+func Sortable(model any) map[string]string {
+	fields := map[string]string{}
+
+	for _, sf := range queryFields(model, SORT_TAG_KEYWORD) {
+		fields[GetJSONTagValue(sf)] = queryColumn(sf)
+	}
+
+	return fields
+}
+
+// Returns the fields of the model containing the `filter` tag, keyed by their JSON names.
+//
+// Usage:
+//
+//	Filterable(User{})
+//	// -> {"name": {Column: full_name, Operators: [eq like]}, "age": {Column: age, Operators: [eq gt lt]}}
+func Filterable(model any) map[string]FilterField {
+	fields := map[string]FilterField{}
+
+	for _, sf := range queryFields(model, FILTER_TAG_KEYWORD) {
+		operators := Filter(GetTagValues(sf, FILTER_TAG_KEYWORD), func(_ int, op string) bool { return op != "" })
+		if len(operators) == 0 {
+			operators = []string{DEFAULT_FILTER_OPERATOR}
+		}
+
+		fields[GetJSONTagValue(sf)] = FilterField{Column: queryColumn(sf), Operators: operators}
+	}
+
+	return fields
+}
+
+// Parses the `?sort=` query parameter, checking each field against the sortable fields of the model.
+// Fields are separated by commas and prefixed by `-` when the order should be descending, as in `-created_at,name`.
+//
+// Returns an `INVALID_SORT_FIELD` error under the `sort` key if any of the fields is not sortable.
+//
+// Usage:
+//
+//	ParseSort(User{}, "-created_at") // -> [{Field: created_at, Column: created_at, Descending: true}], {}
+//	ParseSort(User{}, "password")    // -> [], {"sort": ["INVALID_SORT_FIELD"]}
+func ParseSort(model any, sortParam string) ([]SortField, map[string][]string) {
+	validations := map[string][]string{}
+	fields := []SortField{}
+
+	sortable := Sortable(model)
+
+	for _, field := range strings.Split(sortParam, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		descending := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		column, ok := sortable[field]
+		if !ok {
+			validations["sort"] = []string{DecodingErrors["invalid_sort"]}
+			continue
+		}
+
+		fields = append(fields, SortField{Field: field, Column: column, Descending: descending})
+	}
+
+	return fields, validations
+}
+
+// Parses the filter parameters found in the query string, checking each of them against the filterable fields of the model.
+// Filters use the format `filter[field]=value` or `filter[field][operator]=value`. Other parameters are ignored.
+// Conditions are sorted by field and operator.
+//
+// Returns an `INVALID_FILTER` error, keyed by the offending parameter, for filters whose field or operator is not allowed.
+//
+// Usage:
+//
+//	query, _ := url.ParseQuery("filter[age][gt]=18&filter[password]=123")
+//	ParseFilter(User{}, query)
+//	// -> [{Field: age, Column: age, Operator: gt, Value: 18}], {"filter[password]": ["INVALID_FILTER"]}
+func ParseFilter(model any, query url.Values) ([]FilterCondition, map[string][]string) {
+	validations := map[string][]string{}
+	conditions := []FilterCondition{}
+
+	filterable := Filterable(model)
+
+	for param, values := range query {
+		if !strings.HasPrefix(param, FILTER_TAG_KEYWORD+"[") || !strings.HasSuffix(param, "]") {
+			continue
+		}
+
+		parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(param, FILTER_TAG_KEYWORD+"["), "]"), "][")
+
+		field, operator := parts[0], DEFAULT_FILTER_OPERATOR
+		if len(parts) == 2 {
+			operator = parts[1]
+		}
+
+		f, ok := filterable[field]
+		if !ok || len(parts) > 2 || !Contains(f.Operators, operator) {
+			validations[param] = []string{DecodingErrors["invalid_filter"]}
+			continue
+		}
+
+		for _, value := range values {
+			conditions = append(conditions, FilterCondition{Field: field, Column: f.Column, Operator: operator, Value: value})
+		}
+	}
+
+	sort.SliceStable(conditions, func(i, j int) bool {
+		if conditions[i].Field != conditions[j].Field {
+			return conditions[i].Field < conditions[j].Field
+		}
+
+		return conditions[i].Operator < conditions[j].Operator
+	})
+
+	return conditions, validations
+}
+
+// Returns the exported fields of the model (including the ones of embedded structs) containing the given tag.
+func queryFields(model any, tag string) (fields []reflect.StructField) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if _, ok := sf.Tag.Lookup(tag); ok && sf.IsExported() && !sf.Anonymous {
+			fields = append(fields, sf)
+		}
+	}
+
+	return fields
+}
+
+func queryColumn(sf reflect.StructField) string {
+	if _, ok := sf.Tag.Lookup("db"); ok {
+		return GetTagValue(sf, "db")
+	}
+
+	return GetJSONTagValue(sf)
+}
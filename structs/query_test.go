@@ -0,0 +1,94 @@
+package structs
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type queryUser struct {
+	Identifiable `sort:""`
+	Name         string `json:"name" db:"full_name" sort:"" filter:"eq,like"`
+	Age          int    `json:"age" filter:"eq,gt,lt"`
+	Active       bool   `json:"active" filter:""`
+	Password     string `json:"password"`
+}
+
+func Test_Sortable(t *testing.T) {
+	want := map[string]string{"name": "full_name"}
+
+	if got := Sortable(&queryUser{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sortable() = %v, want %v", got, want)
+	}
+}
+
+func Test_Filterable(t *testing.T) {
+	want := map[string]FilterField{
+		"name":   {Column: "full_name", Operators: []string{"eq", "like"}},
+		"age":    {Column: "age", Operators: []string{"eq", "gt", "lt"}},
+		"active": {Column: "active", Operators: []string{"eq"}},
+	}
+
+	if got := Filterable(queryUser{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Filterable() = %v, want %v", got, want)
+	}
+}
+
+func Test_ParseSort(t *testing.T) {
+	tests := []struct {
+		name   string
+		param  string
+		want   []SortField
+		errors map[string][]string
+	}{
+		{
+			name:   "sort - 1",
+			param:  "-name",
+			want:   []SortField{{Field: "name", Column: "full_name", Descending: true}},
+			errors: map[string][]string{},
+		},
+		{
+			name:   "sort - 2",
+			param:  "name,password,",
+			want:   []SortField{{Field: "name", Column: "full_name"}},
+			errors: map[string][]string{"sort": {"INVALID_SORT_FIELD"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ParseSort(queryUser{}, tt.param)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(errs, tt.errors) {
+				t.Errorf("ParseSort() errors = %v, want %v", errs, tt.errors)
+			}
+		})
+	}
+}
+
+func Test_ParseFilter(t *testing.T) {
+	query, _ := url.ParseQuery("filter[age][gt]=18&filter[name]=Leo&filter[password]=123&filter[name][lt]=L&filter[age][gt][x]=1&page=2")
+
+	got, errs := ParseFilter(queryUser{}, query)
+
+	want := []FilterCondition{
+		{Field: "age", Column: "age", Operator: "gt", Value: "18"},
+		{Field: "name", Column: "full_name", Operator: "eq", Value: "Leo"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFilter() = %v, want %v", got, want)
+	}
+
+	wantErrs := map[string][]string{
+		"filter[password]":   {"INVALID_FILTER"},
+		"filter[name][lt]":   {"INVALID_FILTER"},
+		"filter[age][gt][x]": {"INVALID_FILTER"},
+	}
+
+	if !reflect.DeepEqual(errs, wantErrs) {
+		t.Errorf("ParseFilter() errors = %v, want %v", errs, wantErrs)
+	}
+}
@@ -0,0 +1,159 @@
+package structs
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A set of models registered by name and version, along with the options used for decoding them.
+// It is safe for concurrent use.
+//
+// Usage:
+//
+//	registry := NewRegistry()
+//	registry.Register("user", "v1", UserV1{}, DecoderOptions{})
+//	registry.Register("user", "v2", UserV2{}, DecoderOptions{Rules: []SchemaValidationRule{ADDITIONAL_PROPERTY}})
+//
+//	model, errs := registry.Decode("user", "v2", data)
+//	user := model.(*UserV2)
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[registryKey]registryEntry
+
+	// Options used for reporting models that are not registered.
+	options DecoderOptions
+}
+
+type registryKey struct {
+	name    string
+	version string
+}
+
+type registryEntry struct {
+	model   reflect.Type
+	options DecoderOptions
+}
+
+// Returns an empty registry.
+func NewRegistry() *Registry {
+	return NewRegistryWithOptions(DecoderOptions{})
+}
+
+// Returns an empty registry which reports the models that are not registered using the given options,
+// so their error code can be replaced using `ErrorCodes` and their errors are passed to the `AfterHook`.
+// Registered models are still decoded using the options they were registered with.
+//
+// Usage:
+//
+//	registry := NewRegistryWithOptions(DecoderOptions{ErrorCodes: map[string]string{"unregistered": "UNKNOWN_VERSION"}})
+//	registry.Decode("user", "v3", data) // -> nil, {"_": ["UNKNOWN_VERSION"]}
+func NewRegistryWithOptions(options DecoderOptions) *Registry {
+	return &Registry{entries: map[registryKey]registryEntry{}, options: options}
+}
+
+// Registers the model (a struct or a pointer to one) under the given name and version.
+// Registering the same name and version again replaces the previous model.
+func (r *Registry) Register(name string, version string, model any, options DecoderOptions) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[registryKey{name, version}] = registryEntry{model: t, options: options}
+}
+
+// Decodes the payload into a new instance of the model registered under the given name and version,
+// using the options it was registered with. See `Decode`.
+//
+// If no model is registered, the returned model is nil and an `UNREGISTERED_MODEL` error is reported under the `_` key.
+// See `NewRegistryWithOptions`.
+func (r *Registry) Decode(name string, version string, data []byte) (any, map[string][]string) {
+	r.mu.RLock()
+	entry, ok := r.entries[registryKey{name, version}]
+	r.mu.RUnlock()
+
+	if !ok || entry.model == nil {
+		return nil, r.options.payloadError("unregistered")
+	}
+
+	model := reflect.New(entry.model).Interface()
+
+	return model, Decode(data, model, entry.options)
+}
+
+// Returns the versions registered under the given name, in ascending order.
+// Numbers found in the versions are compared by their values, so `v2` comes before `v10`, and `1.9` before `1.10`.
+func (r *Registry) Versions(name string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := []string{}
+	for key := range r.entries {
+		if key.name == name {
+			versions = append(versions, key.version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+
+	return versions
+}
+
+// Compares two versions, returning -1, 0 or 1. See `Registry.Versions`.
+//
+// Versions are compared one segment at a time, where a segment is either a run of digits or of other characters.
+// Segments of digits are compared numerically, and other segments alphabetically.
+// Versions that only differ by leading zeros (i.e. `v01` and `v1`) are compared alphabetically.
+func compareVersions(a, b string) int {
+	x, y := a, b
+
+	for a != "" && b != "" {
+		sa, sb := versionSegment(a), versionSegment(b)
+		a, b = a[len(sa):], b[len(sb):]
+
+		na, errA := strconv.ParseUint(sa, 10, 64)
+		nb, errB := strconv.ParseUint(sb, 10, 64)
+
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+
+			return 1
+		case (errA != nil || errB != nil) && sa != sb:
+			if sa < sb {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+
+	return strings.Compare(x, y)
+}
+
+// Returns the leading segment of the version: its leading digits, or the characters preceding its first digit.
+func versionSegment(version string) string {
+	isDigit := version[0] >= '0' && version[0] <= '9'
+
+	end := 1
+	for end < len(version) && (version[end] >= '0' && version[end] <= '9') == isDigit {
+		end++
+	}
+
+	return version[:end]
+}
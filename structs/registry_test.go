@@ -0,0 +1,127 @@
+package structs
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func Test_Registry(t *testing.T) {
+	type UserV1 struct {
+		Name string `json:"name"`
+	}
+
+	type UserV2 struct {
+		FirstName string `json:"first_name" jsonschema:"required"`
+	}
+
+	registry := NewRegistry()
+	registry.Register("user", "v1", UserV1{}, DecoderOptions{})
+	registry.Register("user", "v2", &UserV2{}, DecoderOptions{Rules: []SchemaValidationRule{REQUIRED_ATTRIBUTE}})
+
+	tests := []struct {
+		name    string
+		version string
+		data    string
+		model   any
+		want    map[string][]string
+	}{
+		{
+			name:    "user",
+			version: "v1",
+			data:    `{"name": "Leonardo"}`,
+			model:   &UserV1{Name: "Leonardo"},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "user",
+			version: "v2",
+			data:    `{"name": "Leonardo"}`,
+			model:   &UserV2{},
+			want:    map[string][]string{"first_name": {"REQUIRED_ATTRIBUTE_MISSING"}},
+		},
+		{
+			name:    "user",
+			version: "v3",
+			data:    `{}`,
+			model:   nil,
+			want:    map[string][]string{"_": {"UNREGISTERED_MODEL"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" - "+tt.version, func(t *testing.T) {
+			model, got := registry.Decode(tt.name, tt.version, []byte(tt.data))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Registry.Decode() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.model) {
+				t.Errorf("Registry.Decode() model = %+v, want %+v", model, tt.model)
+			}
+		})
+	}
+
+	if got := registry.Versions("user"); !reflect.DeepEqual(got, []string{"v1", "v2"}) {
+		t.Errorf("Registry.Versions() = %v, want %v", got, []string{"v1", "v2"})
+	}
+}
+
+func Test_Registry_Versions(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	registry := NewRegistry()
+	for _, version := range []string{"v10", "v2", "v1.10", "v1.9", "v1", "beta", "v01", "v1-rc1"} {
+		registry.Register("user", version, User{}, DecoderOptions{})
+	}
+
+	want := []string{"beta", "v01", "v1", "v1-rc1", "v1.9", "v1.10", "v2", "v10"}
+	if got := registry.Versions("user"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Registry.Versions() = %v, want %v", got, want)
+	}
+
+	if got := registry.Versions("account"); len(got) != 0 {
+		t.Errorf("Registry.Versions() = %v, want no versions", got)
+	}
+}
+
+func Test_NewRegistryWithOptions(t *testing.T) {
+	registry := NewRegistryWithOptions(DecoderOptions{
+		ErrorCodes: map[string]string{"unregistered": "UNKNOWN_VERSION"},
+		AfterHook: func(validations map[string][]string) map[string][]string {
+			validations["version"] = []string{"v3"}
+			return validations
+		},
+	})
+
+	want := map[string][]string{"_": {"UNKNOWN_VERSION"}, "version": {"v3"}}
+	if model, got := registry.Decode("user", "v3", []byte(`{}`)); model != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Registry.Decode() = %v, %v, want nil, %v", model, got, want)
+	}
+}
+
+func Test_Registry_Concurrency(t *testing.T) {
+	type Item struct {
+		Id int `json:"id"`
+	}
+
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			registry.Register("item", "v1", Item{}, DecoderOptions{})
+		}()
+
+		go func() {
+			defer wg.Done()
+			registry.Decode("item", "v1", []byte(`{"id": 1}`))
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,182 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// The kind of transformation applied to a value while decoding a payload. See `DryRunDecode`.
+type TransformationKind string
+
+const (
+	// A value of the payload was removed by `DecoderOptions.BeforeHook` (i.e. by `TrimUnknown`).
+	TRIMMED_VALUE TransformationKind = "trimmed"
+
+	// A value of the payload was changed by `DecoderOptions.BeforeHook`.
+	NORMALIZED_VALUE TransformationKind = "normalized"
+
+	// A value of the payload was stored in a different form, as in a value normalized by its `json.Unmarshaler`.
+	COERCED_VALUE TransformationKind = "coerced"
+
+	// A value of the payload was not stored, because the model has no field for it or its type is incompatible.
+	DISCARDED_VALUE TransformationKind = "discarded"
+
+	// A value missing from the payload was filled in by the model.
+	DEFAULT_VALUE TransformationKind = "default"
+)
+
+// A transformation applied to a single value while decoding a payload.
+type Transformation struct {
+	// The path of the value, as in `contact.emails[0]`.
+	Path string `json:"path"`
+
+	Kind TransformationKind `json:"kind"`
+
+	// The value found in the payload, or nil if it was missing.
+	Sent any `json:"sent"`
+
+	// The value stored in the model (or the payload, for `TRIMMED_VALUE` and `NORMALIZED_VALUE`), or nil if there is none.
+	Stored any `json:"stored"`
+}
+
+// Decodes a payload like `Decode`, but into a copy of the model, leaving the model itself untouched.
+// Alongside the errors, it returns every transformation applied to the payload, sorted by path,
+// which explains why the stored data differs from the data that was sent.
+//
+// The model is copied through its JSON representation, so that the values it already holds (i.e. defaults)
+// are taken into account. Values are compared through their JSON representations as well.
+// No transformations are returned if the payload (or the model) is invalid.
+//
+// Usage:
+//
+//	errs, report := DryRunDecode([]byte(`{"name": "Leo", "age": 2.5, "extra": 1}`), &Person{Country: "BR"}, DecoderOptions{BeforeHook: TrimUnknown})
+//	// -> [{Path: age, Kind: discarded, Sent: 2.5}, {Path: country, Kind: default, Stored: BR}, {Path: extra, Kind: trimmed, Sent: 1}]
+func DryRunDecode(data []byte, model any, options DecoderOptions) (map[string][]string, []Transformation) {
+	transformations := []Transformation{}
+
+	if err := ValidateModel(model); err != nil {
+		return Decode(data, model, options), transformations
+	}
+
+	sample := reflect.New(reflect.TypeOf(model).Elem()).Interface()
+	if defaults, err := json.Marshal(model); err == nil {
+		_ = json.Unmarshal(defaults, sample)
+	}
+
+	hooked := data
+	if beforeHook := options.BeforeHook; beforeHook != nil {
+		options.BeforeHook = func(data []byte, model any) []byte {
+			hooked = beforeHook(data, model)
+			return hooked
+		}
+	}
+
+	validations := Decode(data, sample, options)
+	if _, ok := validations["_"]; ok {
+		return validations, transformations
+	}
+
+	sent, received, stored := jsonLeaves(data), jsonLeaves(hooked), map[string]any{}
+	if encoded, err := json.Marshal(sample); err == nil {
+		stored = jsonLeaves(encoded)
+	}
+
+	for path, value := range sent {
+		if hookedValue, ok := received[path]; !ok {
+			transformations = append(transformations, Transformation{Path: path, Kind: TRIMMED_VALUE, Sent: value})
+		} else if !reflect.DeepEqual(value, hookedValue) {
+			transformations = append(transformations, Transformation{Path: path, Kind: NORMALIZED_VALUE, Sent: value, Stored: hookedValue})
+		}
+	}
+
+	for path, value := range received {
+		storedValue, ok := stored[path]
+
+		switch {
+		case !ok || isZeroJSONValue(storedValue) && !isZeroJSONValue(value):
+			transformations = append(transformations, Transformation{Path: path, Kind: DISCARDED_VALUE, Sent: value})
+		case isZeroJSONValue(value) && !isZeroJSONValue(storedValue):
+			transformations = append(transformations, Transformation{Path: path, Kind: DEFAULT_VALUE, Sent: value, Stored: storedValue})
+		case !reflect.DeepEqual(value, storedValue):
+			transformations = append(transformations, Transformation{Path: path, Kind: COERCED_VALUE, Sent: value, Stored: storedValue})
+		}
+	}
+
+	for path, value := range stored {
+		if _, ok := received[path]; !ok && !isZeroJSONValue(value) {
+			transformations = append(transformations, Transformation{Path: path, Kind: DEFAULT_VALUE, Stored: value})
+		}
+	}
+
+	sort.SliceStable(transformations, func(i, j int) bool {
+		if transformations[i].Path != transformations[j].Path {
+			return transformations[i].Path < transformations[j].Path
+		}
+
+		return transformations[i].Kind < transformations[j].Kind
+	})
+
+	for i := range transformations {
+		transformations[i].Path = notatedPath(transformations[i].Path)
+	}
+
+	return validations, transformations
+}
+
+// Returns the scalar values (and empty objects/arrays) of a JSON payload, keyed by their paths.
+// Invalid payloads have no values.
+func jsonLeaves(data []byte) map[string]any {
+	leaves := map[string]any{}
+
+	var document any
+	if err := json.Unmarshal(data, &document); err == nil {
+		collectJSONLeaves(document, "", leaves)
+	}
+
+	return leaves
+}
+
+func collectJSONLeaves(value any, path string, leaves map[string]any) {
+	switch node := value.(type) {
+	case map[string]any:
+		if len(node) == 0 && path != "" {
+			leaves[path] = node
+		}
+
+		for k, v := range node {
+			if path != "" {
+				k = path + "." + k
+			}
+
+			collectJSONLeaves(v, k, leaves)
+		}
+	case []any:
+		if len(node) == 0 {
+			leaves[path] = node
+		}
+
+		for i, v := range node {
+			collectJSONLeaves(v, path+"["+strconv.Itoa(i)+"]", leaves)
+		}
+	default:
+		if path != "" {
+			leaves[path] = node
+		}
+	}
+}
+
+// Reports whether a decoded JSON value is `null`, `false`, `0`, an empty string or an empty object/array.
+func isZeroJSONValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+
+	return reflect.ValueOf(value).IsZero()
+}
@@ -0,0 +1,114 @@
+package structs
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A code that is stored in lowercase, regardless of how it is sent.
+type reportCode string
+
+func (c *reportCode) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*c = reportCode(strings.ToLower(s))
+	return nil
+}
+
+func Test_DryRunDecode(t *testing.T) {
+	type Contact struct {
+		Emails []string `json:"emails"`
+	}
+
+	type Person struct {
+		Name     string     `json:"name"`
+		Age      int        `json:"age"`
+		Country  string     `json:"country"`
+		Born     time.Time  `json:"born"`
+		Code     reportCode `json:"code"`
+		Contact  Contact    `json:"contact"`
+		Internal string     `json:"-"`
+	}
+
+	born := time.Date(1990, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		data    string
+		model   *Person
+		options DecoderOptions
+		want    map[string][]string
+		report  []Transformation
+	}{
+		{
+			name:    "untouched",
+			data:    `{"name": "Leo", "age": 30, "country": "BR", "born": "1990-01-02T03:00:00Z", "contact": {"emails": ["leo@example.com"]}}`,
+			model:   &Person{},
+			options: DecoderOptions{},
+			want:    map[string][]string{},
+			report:  []Transformation{},
+		},
+		{
+			name:  "transformed",
+			data:  `{"name": "Leo", "age": 2.5, "born": "1990-01-02T03:00:00Z", "code": "AB-1", "Internal": "x", "contact": {"emails": ["leo@example.com"], "phone": "555"}}`,
+			model: &Person{Country: "BR", Born: born},
+			options: DecoderOptions{BeforeHook: func(data []byte, model any) []byte {
+				return bytes.Replace(TrimUnknown(data, model), []byte(`"Leo"`), []byte(`"LEO"`), 1)
+			}},
+			want: map[string][]string{},
+			report: []Transformation{
+				{Path: "Internal", Kind: TRIMMED_VALUE, Sent: "x"},
+				{Path: "age", Kind: DISCARDED_VALUE, Sent: 2.5},
+				{Path: "code", Kind: COERCED_VALUE, Sent: "AB-1", Stored: "ab-1"},
+				{Path: "contact.phone", Kind: TRIMMED_VALUE, Sent: "555"},
+				{Path: "country", Kind: DEFAULT_VALUE, Stored: "BR"},
+				{Path: "name", Kind: NORMALIZED_VALUE, Sent: "Leo", Stored: "LEO"},
+			},
+		},
+		{
+			name:    "null defaults",
+			data:    `{"country": null, "extra": true}`,
+			model:   &Person{Country: "BR"},
+			options: DecoderOptions{},
+			want:    map[string][]string{},
+			report: []Transformation{
+				{Path: "born", Kind: DEFAULT_VALUE, Stored: "0001-01-01T00:00:00Z"},
+				{Path: "country", Kind: DEFAULT_VALUE, Stored: "BR"},
+				{Path: "extra", Kind: DISCARDED_VALUE, Sent: true},
+			},
+		},
+		{
+			name:    "invalid payload",
+			data:    `{"name": `,
+			model:   &Person{},
+			options: DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE}},
+			want:    map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			report:  []Transformation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := *tt.model
+
+			got, report := DryRunDecode([]byte(tt.data), tt.model, tt.options)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DryRunDecode() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(report, tt.report) {
+				t.Errorf("DryRunDecode() report = %+v, want %+v", report, tt.report)
+			}
+
+			if !reflect.DeepEqual(*tt.model, before) {
+				t.Errorf("DryRunDecode() modified the model: %+v", *tt.model)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package structs
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Decodes the body of an HTTP request into a Go struct, picking the decoder registered (see `RegisterFormat`)
+// for the format of its `Content-Type`:
+//   - JSON (`application/json`, `application/*+json` or no content type): see `Decode`.
+//   - YAML (`application/yaml`, `application/x-yaml`, `text/yaml` or `application/*+yaml`): see `DecodeYAML`.
+//   - XML (`application/xml`, `text/xml` or `application/*+xml`): see `DecodeXML`.
+//   - Forms (`application/x-www-form-urlencoded` or `multipart/form-data`): fields are bound by their JSON names. See `BindParams`.
+//     The hooks, `Recover`, `AtomicPopulate` and `Audiences` behave as in `Decode`, but the schema rules are not checked.
+//   - Any other media type is decoded by the format named after its subtype (or suffix), as in `application/msgpack`
+//     or `application/vnd.api+msgpack` for `msgpack`. Decoders registered by `RegisterFormat` do not receive the options,
+//     so only `AfterHook` is run on their errors.
+//
+// At most `DecoderOptions.MaxBytes` bytes are read, as in `DecodeReader`.
+// Media types without a registered decoder are reported as `UNSUPPORTED_MEDIA_TYPE` under the `_` key.
+//
+// Usage:
+//
+//	func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+//		var user User
+//		if errs := DecodeRequest(r, &user, DecoderOptions{MaxBytes: 1 << 20}); len(errs) != 0 {
+//			...
+//		}
+//	}
+func DecodeRequest(r *http.Request, model any, options DecoderOptions) map[string][]string {
+	mediaType := "application/json"
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return options.payloadError("unsupported_media_type")
+		}
+	}
+
+	format := requestFormat(mediaType)
+
+	// Forms are not registered formats, since they cannot be parsed without the parameters of their content type
+	var decoder Decoder
+	if format != "form" {
+		if decoder = registeredDecoder(format); decoder == nil {
+			return options.payloadError("unsupported_media_type")
+		}
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if r.Body != nil {
+		if key := options.readPayload(r.Body, buf); key != "" {
+			return options.payloadError(key)
+		}
+	}
+
+	switch decoder := decoder.(type) {
+	case nil:
+		return options.decode(buf.Bytes(), model, options.populateForm(r, mediaType), nil).Validations
+	case optionsDecoderFunc:
+		return decoder(buf.Bytes(), model, options)
+	}
+
+	validations := decoder.Decode(buf.Bytes(), model)
+	if validations == nil {
+		validations = map[string][]string{}
+	}
+
+	return options.afterHook(validations)
+}
+
+// Returns the name of the format of the given media type (`json`, `yaml`, `xml` or `form`),
+// or the subtype of any other media type, as in `msgpack` for `application/x-msgpack` or `application/vnd.api+msgpack`.
+func requestFormat(mediaType string) string {
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return "json"
+	case mediaType == "application/yaml" || mediaType == "application/x-yaml" || mediaType == "text/yaml" || strings.HasSuffix(mediaType, "+yaml"):
+		return "yaml"
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return "xml"
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		return "form"
+	}
+
+	subtype := mediaType[strings.IndexByte(mediaType, '/')+1:]
+	if i := strings.LastIndexByte(subtype, '+'); i != -1 {
+		subtype = subtype[i+1:]
+	}
+
+	return strings.TrimPrefix(subtype, "x-")
+}
+
+// Returns a function populating a model with the form held by a payload sent in the given request. See `DecodeRequest`.
+// The paths of the fields bound are sorted alphabetically, and fields restricted to other audiences are left out.
+func (options DecoderOptions) populateForm(r *http.Request, mediaType string) populateFunc {
+	return func(data []byte, model any) ([]string, map[string][]string) {
+		form := r.Clone(r.Context())
+		form.Body = io.NopCloser(bytes.NewReader(data))
+
+		if err := parseRequestForm(form, mediaType, int64(len(data))); err != nil {
+			return nil, map[string][]string{"_": {options.errorCode("invalid_payload")}}
+		}
+
+		t := reflect.TypeOf(model).Elem()
+
+		params := map[string][]string{}
+		populated := []string{}
+
+		for name, values := range form.PostForm {
+			index, ok := jsonFieldIndex(t, name)
+			if !ok || !isAudienceAllowed(t.FieldByIndex(index), options.Audiences) {
+				continue
+			}
+
+			params[name] = values
+			populated = append(populated, name)
+		}
+
+		sort.Strings(populated)
+
+		return populated, BindParams(model, "json", params)
+	}
+}
+
+// Parses the form held by the body of the request, whose size is given.
+func parseRequestForm(r *http.Request, mediaType string, size int64) error {
+	if mediaType == "multipart/form-data" {
+		// Files are kept in memory, since the body has already been read into memory anyway
+		return r.ParseMultipartForm(size + 1)
+	}
+
+	return r.ParseForm()
+}
@@ -0,0 +1,183 @@
+package structs
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_DecodeRequest(t *testing.T) {
+	type User struct {
+		Name string   `json:"name" xml:"name" jsonschema:"required"`
+		Age  int      `json:"age" xml:"age"`
+		Tags []string `json:"tags" xml:"tag"`
+	}
+
+	var multipartBody bytes.Buffer
+	writer := multipart.NewWriter(&multipartBody)
+	writer.WriteField("name", "Leo")
+	writer.WriteField("age", "30")
+	writer.Close()
+
+	rules := []SchemaValidationRule{REQUIRED_ATTRIBUTE, INVALID_TYPE}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		options     DecoderOptions
+		want        map[string][]string
+		wantModel   User
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name": "Leo", "age": 30, "tags": ["a"]}`,
+			options:     DecoderOptions{Rules: rules},
+			want:        map[string][]string{},
+			wantModel:   User{Name: "Leo", Age: 30, Tags: []string{"a"}},
+		},
+		{
+			name:      "no content type",
+			body:      `{"age": "30"}`,
+			options:   DecoderOptions{Rules: rules},
+			want:      map[string][]string{"name": {"REQUIRED_ATTRIBUTE_MISSING"}, "age": {"INVALID_TYPE"}},
+			wantModel: User{},
+		},
+		{
+			name:        "yaml",
+			contentType: "application/yaml",
+			body:        "name: Leo\nage: 30\ntags: [a]\n",
+			options:     DecoderOptions{Rules: rules},
+			want:        map[string][]string{},
+			wantModel:   User{Name: "Leo", Age: 30, Tags: []string{"a"}},
+		},
+		{
+			name:        "xml",
+			contentType: "application/vnd.user+xml",
+			body:        `<user><name>Leo</name><age>30</age><tag>a</tag></user>`,
+			options:     DecoderOptions{Rules: rules},
+			want:        map[string][]string{},
+			wantModel:   User{Name: "Leo", Age: 30, Tags: []string{"a"}},
+		},
+		{
+			name:        "invalid xml",
+			contentType: "text/xml",
+			body:        `<user><age>thirty</age></user>`,
+			options:     DecoderOptions{},
+			want:        map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel:   User{},
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=Leo&age=thirty&tags=a&tags=b",
+			options:     DecoderOptions{},
+			want:        map[string][]string{"age": {"INVALID_TYPE"}},
+			wantModel:   User{Name: "Leo", Tags: []string{"a", "b"}},
+		},
+		{
+			name:        "multipart form",
+			contentType: writer.FormDataContentType(),
+			body:        multipartBody.String(),
+			options:     DecoderOptions{},
+			want:        map[string][]string{},
+			wantModel:   User{Name: "Leo", Age: 30},
+		},
+		{
+			name:        "too large",
+			contentType: "application/json",
+			body:        `{"name": "Leonardo"}`,
+			options:     DecoderOptions{MaxBytes: 10},
+			want:        map[string][]string{"_": {"PAYLOAD_TOO_LARGE"}},
+			wantModel:   User{},
+		},
+		{
+			name:        "unsupported",
+			contentType: "text/csv",
+			body:        "name\nLeo",
+			options:     DecoderOptions{},
+			want:        map[string][]string{"_": {"UNSUPPORTED_MEDIA_TYPE"}},
+			wantModel:   User{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var model User
+
+			if got := DecodeRequest(r, &model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeRequest() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.wantModel) {
+				t.Errorf("DecodeRequest() model = %+v, want %+v", model, tt.wantModel)
+			}
+		})
+	}
+}
+
+func Test_DecodeRequest_Pipeline(t *testing.T) {
+	type User struct {
+		Name string `json:"name" xml:"name"`
+		Age  int    `json:"age" xml:"age"`
+		Role string `json:"role" xml:"role" audience:"internal"`
+	}
+
+	request := func(contentType string, body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		return r
+	}
+
+	// Forms run the hooks and are populated atomically
+	var populated []string
+	user := User{Name: "Ana"}
+	options := DecoderOptions{AtomicPopulate: true, PopulateHook: func(paths []string) { populated = paths }}
+
+	if errs := DecodeRequest(request("application/x-www-form-urlencoded", "name=Leo&age=thirty&role=admin"), &user, options); !reflect.DeepEqual(errs, map[string][]string{"age": {"INVALID_TYPE"}}) {
+		t.Errorf("DecodeRequest() = %v", errs)
+	}
+
+	if !reflect.DeepEqual(user, User{Name: "Ana"}) || !reflect.DeepEqual(populated, []string{"age", "name"}) {
+		t.Errorf("DecodeRequest() model = %+v, populated = %v", user, populated)
+	}
+
+	// XML payloads run the hooks
+	options = DecoderOptions{
+		BeforeHook: func(data []byte, model any) []byte { return []byte(strings.ReplaceAll(string(data), "Ana", "Leo")) },
+	}
+
+	if errs := DecodeRequest(request("application/xml", "<user><name>Ana</name><age>30</age></user>"), &user, options); len(errs) != 0 || user.Name != "Leo" || user.Age != 30 {
+		t.Errorf("DecodeRequest() = %v, model = %+v", errs, user)
+	}
+
+	options = DecoderOptions{Recover: true, BeforeHook: func(data []byte, model any) []byte { panic("boom") }}
+	if errs := DecodeRequest(request("text/xml", "<user/>"), &user, options); !reflect.DeepEqual(errs, map[string][]string{"_": {"UNEXPECTED_ERROR"}}) {
+		t.Errorf("DecodeRequest() = %v", errs)
+	}
+
+	// Other media types are decoded by the format named after them
+	RegisterFormat("csv", nil, DecoderFunc(func(data []byte, model any) map[string][]string {
+		model.(*User).Name = string(data)
+		return nil
+	}))
+	defer func() {
+		formatsMu.Lock()
+		delete(formats, "csv")
+		formatsMu.Unlock()
+	}()
+
+	user = User{}
+	if errs := DecodeRequest(request("application/vnd.users+csv", "Leo"), &user, DecoderOptions{}); len(errs) != 0 || user.Name != "Leo" {
+		t.Errorf("DecodeRequest() = %v, model = %+v", errs, user)
+	}
+}
@@ -0,0 +1,163 @@
+package structs
+
+import (
+	"sort"
+	"strings"
+)
+
+// A map of attribute paths to the list of error codes reported for them.
+//
+// It has the same underlying type as the maps returned by `Decode` and `validators.Validate`,
+// so any of those results can be converted without copying:
+//
+//	result := Result(Decode(data, &model, options))
+//	result.HasErrors() // -> true
+type Result map[string][]string
+
+// Returns `true` if at least one attribute has been reported.
+func (r Result) HasErrors() bool {
+	return len(r) != 0
+}
+
+// Returns the errors reported for the given attribute path.
+//
+// Usage:
+//
+//	result.Field("contact.emails[0]") // -> ["INVALID_FORMAT"]
+func (r Result) Field(path string) []string {
+	return r[path]
+}
+
+// Returns the first reported attribute (in alphabetical order) and its first error.
+// Both values are empty if there are no errors.
+//
+// Usage:
+//
+//	result := Result{"name": {"INVALID_LENGTH"}, "id": {"INVALID_FORMAT"}}
+//	result.First() // -> "id", "INVALID_FORMAT"
+func (r Result) First() (path string, err string) {
+	keys := r.Keys()
+	if len(keys) == 0 {
+		return path, err
+	}
+
+	path = keys[0]
+	if errs := r[path]; len(errs) != 0 {
+		err = errs[0]
+	}
+
+	return path, err
+}
+
+// Returns the number of attributes with errors.
+func (r Result) Len() int {
+	return len(r)
+}
+
+// Returns the attribute paths in alphabetical order.
+func (r Result) Keys() []string {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns a new result containing the errors of both results.
+// Errors reported for the same path are appended to one another (see `MergeValidations`).
+func (r Result) Merge(other Result) Result {
+	merged := MergeValidations(make(Result, len(r)+len(other)), r)
+	return MergeValidations(merged, other)
+}
+
+// Returns a new result containing only the errors for the given path and its descendants.
+//
+// Usage:
+//
+//	result := Result{"contact": {...}, "contact.emails[0]": {...}, "contacts": {...}}
+//	result.Filter("contact") // -> {"contact": {...}, "contact.emails[0]": {...}}
+func (r Result) Filter(prefix string) Result {
+	filtered := make(Result)
+
+	for k, v := range r {
+		if k == prefix ||
+			strings.HasPrefix(k, prefix+".") ||
+			strings.HasPrefix(k, prefix+"[") ||
+			prefix == "" {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
+
+// Appends the errors in `src` to the errors in `dst` and returns `dst`.
+// Errors already reported for a path are not duplicated. A nil `dst` is allowed.
+//
+// Usage:
+//
+//	dst := map[string][]string{"name": {"INVALID_TYPE"}}
+//	src := map[string][]string{"name": {"INVALID_TYPE", "INVALID_LENGTH"}}
+//	MergeValidations(dst, src) // -> {"name": ["INVALID_TYPE", "INVALID_LENGTH"]}
+func MergeValidations(dst, src map[string][]string) map[string][]string {
+	if dst == nil {
+		dst = make(map[string][]string, len(src))
+	}
+
+	for k, errs := range src {
+		if _, ok := dst[k]; !ok {
+			dst[k] = make([]string, 0, len(errs))
+		}
+
+		for _, err := range errs {
+			if !Contains(dst[k], err) {
+				dst[k] = append(dst[k], err)
+			}
+		}
+	}
+
+	return dst
+}
+
+// The errors that changed between two validations of the same model. See `DiffResults`.
+type ResultDiff struct {
+	// Errors reported by the latest validation that were not reported by the previous one.
+	Added Result
+
+	// Errors reported by the previous validation that are no longer reported.
+	Resolved Result
+}
+
+// Compares two validations of the same model (i.e. before and after an edit) and returns the errors
+// introduced and the errors fixed by the latest one. Errors are compared per path and error code.
+//
+// Usage:
+//
+//	before := map[string][]string{"name": {"INVALID_LENGTH"}, "email": {"INVALID_FORMAT"}}
+//	after := map[string][]string{"email": {"INVALID_FORMAT"}, "age": {"INVALID_VALUE"}}
+//	DiffResults(before, after) // -> {Added: {"age": ["INVALID_VALUE"]}, Resolved: {"name": ["INVALID_LENGTH"]}}
+func DiffResults(a, b map[string][]string) ResultDiff {
+	return ResultDiff{Added: subtractValidations(b, a), Resolved: subtractValidations(a, b)}
+}
+
+// Returns `true` if no errors were added or resolved.
+func (d ResultDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Resolved) == 0
+}
+
+// Returns the errors in `a` that are not in `b`.
+func subtractValidations(a, b map[string][]string) Result {
+	difference := make(Result)
+
+	for path, errs := range a {
+		for _, err := range errs {
+			if !Contains(b[path], err) && !Contains(difference[path], err) {
+				difference[path] = append(difference[path], err)
+			}
+		}
+	}
+
+	return difference
+}
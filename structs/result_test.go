@@ -0,0 +1,143 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Result(t *testing.T) {
+	result := Result{
+		"name":              {"INVALID_LENGTH"},
+		"contact.emails[0]": {"INVALID_FORMAT"},
+		"contact":           {"INVALID_VALUE"},
+		"contacts":          {"INVALID_LENGTH"},
+	}
+
+	if !result.HasErrors() {
+		t.Errorf("Result.HasErrors() = false, want true")
+	}
+
+	if (Result{}).HasErrors() {
+		t.Errorf("Result.HasErrors() = true, want false")
+	}
+
+	if got := result.Len(); got != 4 {
+		t.Errorf("Result.Len() = %v, want %v", got, 4)
+	}
+
+	if got := result.Field("contact.emails[0]"); !reflect.DeepEqual(got, []string{"INVALID_FORMAT"}) {
+		t.Errorf("Result.Field() = %v, want %v", got, []string{"INVALID_FORMAT"})
+	}
+
+	if path, err := result.First(); path != "contact" || err != "INVALID_VALUE" {
+		t.Errorf("Result.First() = %v, %v, want %v, %v", path, err, "contact", "INVALID_VALUE")
+	}
+
+	if path, err := (Result{}).First(); path != "" || err != "" {
+		t.Errorf("Result.First() = %v, %v, want empty values", path, err)
+	}
+
+	want := Result{
+		"contact.emails[0]": {"INVALID_FORMAT"},
+		"contact":           {"INVALID_VALUE"},
+	}
+
+	if got := result.Filter("contact"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Result.Filter() = %v, want %v", got, want)
+	}
+}
+
+func Test_Result_Merge(t *testing.T) {
+	a := Result{"name": {"INVALID_LENGTH"}}
+	b := Result{"name": {"INVALID_TYPE"}, "id": {"REQUIRED_ATTRIBUTE_MISSING"}}
+
+	want := Result{
+		"name": {"INVALID_LENGTH", "INVALID_TYPE"},
+		"id":   {"REQUIRED_ATTRIBUTE_MISSING"},
+	}
+
+	if got := a.Merge(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Result.Merge() = %v, want %v", got, want)
+	}
+
+	if !reflect.DeepEqual(a, Result{"name": {"INVALID_LENGTH"}}) {
+		t.Errorf("Result.Merge() modified the receiver: %v", a)
+	}
+}
+
+func Test_MergeValidations(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  map[string][]string
+		src  map[string][]string
+		want map[string][]string
+	}{
+		{
+			name: "nil destination",
+			dst:  nil,
+			src:  map[string][]string{"id": {"INVALID_FORMAT"}},
+			want: map[string][]string{"id": {"INVALID_FORMAT"}},
+		},
+		{
+			name: "append and de-duplicate",
+			dst:  map[string][]string{"name": {"INVALID_TYPE"}},
+			src:  map[string][]string{"name": {"INVALID_TYPE", "INVALID_LENGTH"}, "id": {"INVALID_FORMAT"}},
+			want: map[string][]string{"name": {"INVALID_TYPE", "INVALID_LENGTH"}, "id": {"INVALID_FORMAT"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeValidations(tt.dst, tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeValidations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DiffResults(t *testing.T) {
+	tests := []struct {
+		name string
+		a    map[string][]string
+		b    map[string][]string
+		want ResultDiff
+	}{
+		{
+			name: "unchanged",
+			a:    map[string][]string{"name": {"INVALID_LENGTH"}},
+			b:    map[string][]string{"name": {"INVALID_LENGTH"}},
+			want: ResultDiff{Added: Result{}, Resolved: Result{}},
+		},
+		{
+			name: "added and resolved",
+			a:    map[string][]string{"name": {"INVALID_LENGTH"}, "email": {"INVALID_FORMAT"}},
+			b:    map[string][]string{"email": {"INVALID_FORMAT"}, "age": {"INVALID_VALUE"}},
+			want: ResultDiff{Added: Result{"age": {"INVALID_VALUE"}}, Resolved: Result{"name": {"INVALID_LENGTH"}}},
+		},
+		{
+			name: "codes of the same path",
+			a:    map[string][]string{"name": {"INVALID_TYPE", "INVALID_LENGTH"}},
+			b:    map[string][]string{"name": {"INVALID_LENGTH", "INVALID_FORMAT"}},
+			want: ResultDiff{Added: Result{"name": {"INVALID_FORMAT"}}, Resolved: Result{"name": {"INVALID_TYPE"}}},
+		},
+		{
+			name: "nil",
+			a:    nil,
+			b:    map[string][]string{"id": {"INVALID_FORMAT"}},
+			want: ResultDiff{Added: Result{"id": {"INVALID_FORMAT"}}, Resolved: Result{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffResults(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffResults() = %v, want %v", got, tt.want)
+			}
+
+			if got.IsEmpty() != (tt.name == "unchanged") {
+				t.Errorf("ResultDiff.IsEmpty() = %v", got.IsEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returned by `ParseRules` and `ParseRule` when a rule expression is malformed.
+var ErrInvalidRuleExpression = errors.New("invalid rule expression")
+
+// A single rule of a rule expression, as in `min=2` or `each:regex(^\d+$)`.
+type Rule struct {
+	// The name of the rule, as in `min`. Aliases are not resolved.
+	Name string
+
+	// The value of the rule, without the quotes and escapes used to write it:
+	// `2` for `min=2`, `a,b|c` for `in='a,b|c'` and `^\d+$` for `regex(^\d+$)`.
+	Value string
+
+	// Whether the rule is prefixed by `EACH_RULE_PREFIX`, meaning it applies to the elements of a slice/array.
+	Each bool
+}
+
+// Returns the rule as it would be written in a tag. Values containing commas, quotes or parentheses are quoted.
+//
+// Usage:
+//
+//	Rule{Name: "in", Value: "a,b|c"}.String()  // -> "in='a,b|c'"
+//	Rule{Name: "min", Value: "1", Each: true}.String() // -> "each:min=1"
+func (r Rule) String() string {
+	rule := r.Name
+	if r.Each {
+		rule = EACH_RULE_PREFIX + rule
+	}
+
+	if r.Value == "" {
+		return rule
+	}
+
+	if strings.ContainsAny(r.Value, ",'()") {
+		return rule + "='" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(r.Value) + "'"
+	}
+
+	return rule + "=" + r.Value
+}
+
+// Parses a rule expression, as found in validation tags (i.e. `required,min=2,in=A|B`), into its rules.
+//
+// The grammar of an expression is:
+//
+//	expression = [ rule ] { "," [ rule ] }
+//	rule       = [ "each:" ] name [ "(" group ")" | "=" value ]
+//	value      = quoted | { character | "\," | "(" group ")" }
+//	quoted     = "'" { character | "\'" | "\\" } "'"
+//
+// That is:
+//   - Rules are separated by commas. Empty rules are left out.
+//   - Parentheses group characters that are taken literally, including commas, which allows values like `regex(^\d{1,3}$)`.
+//     Within parentheses, escaped characters and bracket expressions (i.e. `[(,]`) are taken literally as well.
+//   - Values wrapped in single quotes are taken literally, as in `in='a,b|c'`, except for `\'` and `\\`,
+//     which stand for a quote and a backslash.
+//   - In other values, `\,` stands for a comma.
+//
+// Returns `ErrInvalidRuleExpression` if a parenthesis or a quote is left open, or if a rule has no name.
+//
+// Usage:
+//
+//	ParseRules("required,min=2,in='A,B|C'")
+//	// -> [{Name: required}, {Name: min, Value: 2}, {Name: in, Value: A,B|C}]
+//
+//	ParseRules(`each:regex(^\d{1,3}$)`)
+//	// -> [{Name: regex, Value: ^\d{1,3}$, Each: true}]
+func ParseRules(expression string) ([]Rule, error) {
+	parts, err := splitRules(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(parts))
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		rule, err := ParseRule(part)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Parses a single rule of a rule expression (see `ParseRules`), as returned by `GetTagRules`.
+// Commas are not treated as separators, so `in=a,b` is read as the value `a,b`.
+//
+// Usage:
+//
+//	ParseRule("min=2")            // -> {Name: min, Value: 2}
+//	ParseRule(`regex(^a=b$)`)     // -> {Name: regex, Value: ^a=b$}
+//	ParseRule(`in='it\'s|other'`) // -> {Name: in, Value: it's|other}
+func ParseRule(rule string) (Rule, error) {
+	invalid := func(reason string) (Rule, error) {
+		return Rule{}, fmt.Errorf("%w: %s: %q", ErrInvalidRuleExpression, reason, rule)
+	}
+
+	parsed := Rule{Each: strings.HasPrefix(rule, EACH_RULE_PREFIX)}
+
+	body := strings.TrimPrefix(rule, EACH_RULE_PREFIX)
+	end := strings.IndexAny(body, "=(")
+	if end == -1 {
+		end = len(body)
+	}
+
+	if parsed.Name = body[:end]; parsed.Name == "" {
+		return invalid("missing rule name")
+	}
+
+	switch rest := body[end:]; {
+	case rest == "":
+	case rest[0] == '(':
+		closing, err := groupEnd(rest, 0)
+		if err != nil || closing != len(rest)-1 {
+			return invalid("unbalanced parentheses")
+		}
+
+		parsed.Value = rest[1:closing]
+	case len(rest) > 1 && rest[1] == '\'':
+		value, ok := unquoteRuleValue(rest[1:])
+		if !ok {
+			return invalid("unterminated quote")
+		}
+
+		parsed.Value = value
+	default:
+		value := rest[1:]
+
+		// Parentheses in values are checked, but taken literally
+		for i := 0; i < len(value); i++ {
+			switch value[i] {
+			case '\\':
+				i++
+			case '(':
+				closing, err := groupEnd(value, i)
+				if err != nil {
+					return invalid("unbalanced parentheses")
+				}
+
+				i = closing
+			}
+		}
+
+		parsed.Value = strings.ReplaceAll(value, `\,`, ",")
+	}
+
+	return parsed, nil
+}
+
+// Splits a rule expression on the commas that are not enclosed in parentheses or quotes, nor escaped.
+// Empty parts are kept. See `ParseRules`.
+func splitRules(expression string) ([]string, error) {
+	parts := []string{}
+
+	start, inValue := 0, false
+	for i := 0; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case c == '\\':
+			i++
+		case c == '(':
+			closing, err := groupEnd(expression, i)
+			if err != nil {
+				return append(parts, expression[start:]), err
+			}
+
+			i = closing
+		case c == '=' && !inValue:
+			inValue = true
+
+			// Quotes are only recognized at the start of values
+			if i+1 < len(expression) && expression[i+1] == '\'' {
+				closing := quoteEnd(expression, i+1)
+				if closing == -1 {
+					return append(parts, expression[start:]), fmt.Errorf("%w: unterminated quote: %q", ErrInvalidRuleExpression, expression[start:])
+				}
+
+				i = closing
+			}
+		case c == ',':
+			parts = append(parts, expression[start:i])
+			start, inValue = i+1, false
+		}
+	}
+
+	return append(parts, expression[start:]), nil
+}
+
+// Returns the position of the parenthesis closing the one found at the given position.
+// Within parentheses, escaped characters and bracket expressions (i.e. `[(,]`) are taken literally.
+func groupEnd(expression string, open int) (int, error) {
+	depth, inBrackets := 0, false
+	for i := open; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case c == '\\':
+			i++
+		case inBrackets:
+			inBrackets = c != ']'
+		case c == '[':
+			inBrackets = true
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, fmt.Errorf("%w: unbalanced parentheses: %q", ErrInvalidRuleExpression, expression[open:])
+}
+
+// Returns the position of the quote closing the one found at the given position, or -1 if the quote is left open.
+func quoteEnd(expression string, open int) int {
+	for i := open + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case '\'':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Removes the quotes wrapping a value and the escapes within it.
+// Returns `false` if the quote is left open or if anything follows the closing quote.
+func unquoteRuleValue(quoted string) (string, bool) {
+	if closing := quoteEnd(quoted, 0); closing != len(quoted)-1 {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(quoted)-1; i++ {
+		if quoted[i] == '\\' && (quoted[i+1] == '\'' || quoted[i+1] == '\\') {
+			i++
+		}
+
+		sb.WriteByte(quoted[i])
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,134 @@
+package structs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_ParseRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []Rule
+		wantErr    bool
+	}{
+		{
+			name:       "names and values",
+			expression: "required,min=2,in=A|B",
+			want:       []Rule{{Name: "required"}, {Name: "min", Value: "2"}, {Name: "in", Value: "A|B"}},
+		},
+		{
+			name:       "element rules",
+			expression: "min=1,each:max=3",
+			want:       []Rule{{Name: "min", Value: "1"}, {Name: "max", Value: "3", Each: true}},
+		},
+		{
+			name:       "parentheses",
+			expression: `regex(^\d{1,3}$),each:regex(^(a|b,c)=d$)`,
+			want:       []Rule{{Name: "regex", Value: `^\d{1,3}$`}, {Name: "regex", Value: "^(a|b,c)=d$", Each: true}},
+		},
+		{
+			name:       "parentheses in values",
+			expression: "required_if=(Country US,Type company),min=1",
+			want:       []Rule{{Name: "required_if", Value: "(Country US,Type company)"}, {Name: "min", Value: "1"}},
+		},
+		{
+			name:       "quoted values",
+			expression: `in='a,b|c',in='it\'s|a\\b',regex='^\d+$'`,
+			want:       []Rule{{Name: "in", Value: "a,b|c"}, {Name: "in", Value: `it's|a\b`}, {Name: "regex", Value: `^\d+$`}},
+		},
+		{
+			name:       "escaped commas",
+			expression: `in=a\,b|c,min=1`,
+			want:       []Rule{{Name: "in", Value: "a,b|c"}, {Name: "min", Value: "1"}},
+		},
+		{
+			name:       "empty rules and values",
+			expression: ",min=,",
+			want:       []Rule{{Name: "min"}},
+		},
+		{
+			name:       "empty",
+			expression: "",
+			want:       []Rule{},
+		},
+		{name: "unbalanced parentheses", expression: "regex(^a,min=1", wantErr: true},
+		{name: "unterminated quote", expression: "in='a,b", wantErr: true},
+		{name: "content after the quote", expression: "in='a'b", wantErr: true},
+		{name: "missing name", expression: "min=1,=2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRules(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if !errors.Is(err, ErrInvalidRuleExpression) {
+					t.Errorf("ParseRules() error = %v, want %v", err, ErrInvalidRuleExpression)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    Rule
+		wantErr bool
+	}{
+		{rule: "min=2", want: Rule{Name: "min", Value: "2"}},
+		{rule: "regex(^a=b$)", want: Rule{Name: "regex", Value: "^a=b$"}},
+		{rule: "in=a,b", want: Rule{Name: "in", Value: "a,b"}},
+		{rule: "in=it's", want: Rule{Name: "in", Value: "it's"}},
+		{rule: "each:uuid", want: Rule{Name: "uuid", Each: true}},
+		{rule: "regex(^a)b", wantErr: true},
+		{rule: "in=(a", wantErr: true},
+		{rule: "each:", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Rule_String(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		want string
+	}{
+		{rule: Rule{Name: "required"}, want: "required"},
+		{rule: Rule{Name: "min", Value: "1", Each: true}, want: "each:min=1"},
+		{rule: Rule{Name: "in", Value: "a,b|c"}, want: "in='a,b|c'"},
+		{rule: Rule{Name: "regex", Value: `^(it's)\d$`}, want: `regex='^(it\'s)\\d$'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.rule.String(); got != tt.want {
+				t.Errorf("Rule.String() = %v, want %v", got, tt.want)
+			}
+
+			// Rules can be parsed back from their string representations
+			if parsed, err := ParseRule(tt.rule.String()); err != nil || parsed != tt.rule {
+				t.Errorf("ParseRule() = %+v, %v, want %+v", parsed, err, tt.rule)
+			}
+		})
+	}
+}
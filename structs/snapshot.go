@@ -0,0 +1,128 @@
+package structs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// The key under which `Snapshot` records the time the snapshot was taken.
+	SNAPSHOT_TIMESTAMP_KEY string = "_captured_at"
+)
+
+// Captures the values of all the fields containing the given tag into a flat map,
+// keyed by the full name of each attribute.
+// The time (in UTC) the snapshot was taken is recorded under `SNAPSHOT_TIMESTAMP_KEY`.
+//
+// Only leaf attributes are recorded, meaning a slice of strings will produce
+// one entry per element instead of an entry for the slice itself.
+//
+// Usage:
+//
+// Imagine you have the struct:
+//
+//	type Account struct {
+//		Email  string   `json:"email" audit:""`
+//		Roles  []string `json:"roles" audit:""`
+//		Secret string   `json:"secret"`
+//	}
+//
+// Capturing all the fields tagged with `audit`:
+//
+//	Snapshot(Account{Email: "leo@example.com", Roles: []string{"ADMIN"}}, "audit")
+//	// -> {"email": "leo@example.com", "roles[0]": "ADMIN", "_captured_at": time.Time{...}}
+func Snapshot(model any, tag string) map[string]any {
+	snapshot := map[string]any{}
+
+	filterTags := []string{}
+	if tag != "" {
+		filterTags = append(filterTags, tag)
+	}
+
+	for _, attr := range leafAttributes(reflect.ValueOf(model), AttributeOptions{FilterTags: filterTags}) {
+		snapshot[attr.FullName()] = attributeValue(attr.Value)
+	}
+
+	snapshot[SNAPSHOT_TIMESTAMP_KEY] = time.Now().UTC()
+
+	return snapshot
+}
+
+// Computes a stable hash (hex-encoded SHA-256) of the values of all the fields containing the given tag.
+// The fingerprint is suitable for ETags and optimistic-locking tokens: it changes whenever one of the values changes.
+//
+// As in `Snapshot`, only leaf attributes are considered. They are hashed in the order of their full names
+// (with their values encoded as JSON), so reordering the fields of the struct does not change the fingerprint.
+// An empty tag includes all fields.
+//
+// Usage:
+//
+//	etag := Fingerprint(account, "audit")
+//	// -> "5d41402abc4b2a76b9719d911017c592..."
+//
+//	if r.Header.Get("If-Match") != etag {
+//		// The account was modified since it was last read
+//	}
+func Fingerprint(model any, tag string) string {
+	filterTags := []string{}
+	if tag != "" {
+		filterTags = append(filterTags, tag)
+	}
+
+	attributes := leafAttributes(reflect.ValueOf(model), AttributeOptions{FilterTags: filterTags})
+
+	entries := make([]string, 0, len(attributes))
+	for _, attr := range attributes {
+		value, err := json.Marshal(attributeValue(attr.Value))
+		if err != nil {
+			value = []byte(fmt.Sprintf("%#v", attributeValue(attr.Value)))
+		}
+
+		entries = append(entries, strconv.Quote(attr.FullName())+"="+string(value))
+	}
+
+	sort.Strings(entries)
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		hash.Write([]byte(entry + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Returns the attributes of the given struct that are not parents of any other attribute.
+func leafAttributes(rv reflect.Value, options AttributeOptions) []StructAttribute {
+	attributes := GetAttributesWithOptions(rv, options)
+
+	scopes := map[string]bool{}
+	for _, attr := range attributes {
+		if len(attr.Parents) != 0 {
+			parent := attr.Parents[len(attr.Parents)-1]
+			scopes[parent.FullName()] = true
+		}
+	}
+
+	return Filter(attributes, func(_ int, attr StructAttribute) bool {
+		return !scopes[attr.FullName()]
+	})
+}
+
+// Returns the underlying value of the given reflected value or nil if it cannot be accessed.
+func attributeValue(rv reflect.Value) any {
+	if !rv.IsValid() || !rv.CanInterface() {
+		return nil
+	}
+
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+
+	return rv.Interface()
+}
@@ -0,0 +1,93 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Snapshot(t *testing.T) {
+	type Owner struct {
+		Name string `json:"name" audit:""`
+	}
+
+	type Account struct {
+		Email  string   `json:"email" audit:""`
+		Roles  []string `json:"roles" audit:""`
+		Owner  Owner    `json:"owner" audit:""`
+		Alias  *string  `json:"alias" audit:""`
+		Secret string   `json:"secret"`
+	}
+
+	account := Account{
+		Email:  "leo@example.com",
+		Roles:  []string{"ADMIN", "GUEST"},
+		Owner:  Owner{Name: "Leonardo"},
+		Secret: "hunter2",
+	}
+
+	got := Snapshot(account, "audit")
+
+	capturedAt, ok := got[SNAPSHOT_TIMESTAMP_KEY].(time.Time)
+	if !ok || capturedAt.IsZero() {
+		t.Errorf("Snapshot() is missing the %v key", SNAPSHOT_TIMESTAMP_KEY)
+	}
+
+	delete(got, SNAPSHOT_TIMESTAMP_KEY)
+
+	want := map[string]any{
+		"email":      "leo@example.com",
+		"roles[0]":   "ADMIN",
+		"roles[1]":   "GUEST",
+		"owner.name": "Leonardo",
+		"alias":      nil,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+}
+
+func Test_Fingerprint(t *testing.T) {
+	type Account struct {
+		Email  string   `json:"email" audit:""`
+		Roles  []string `json:"roles" audit:""`
+		Secret string   `json:"secret"`
+	}
+
+	// Same fields as `Account`, declared in a different order
+	type ReorderedAccount struct {
+		Secret string   `json:"secret"`
+		Roles  []string `json:"roles" audit:""`
+		Email  string   `json:"email" audit:""`
+	}
+
+	account := Account{Email: "leo@example.com", Roles: []string{"ADMIN"}, Secret: "s3cr3t"}
+	fingerprint := Fingerprint(account, "audit")
+
+	if len(fingerprint) != 64 {
+		t.Fatalf("Fingerprint() = %v, want a hex-encoded SHA-256 hash", fingerprint)
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		tag   string
+		same  bool
+	}{
+		{name: "same values", model: Account{Email: "leo@example.com", Roles: []string{"ADMIN"}, Secret: "s3cr3t"}, tag: "audit", same: true},
+		{name: "reordered fields", model: ReorderedAccount{Email: "leo@example.com", Roles: []string{"ADMIN"}}, tag: "audit", same: true},
+		{name: "untagged field changed", model: Account{Email: "leo@example.com", Roles: []string{"ADMIN"}, Secret: "other"}, tag: "audit", same: true},
+		{name: "pointer to model", model: &account, tag: "audit", same: true},
+		{name: "tagged field changed", model: Account{Email: "leo@example.org", Roles: []string{"ADMIN"}}, tag: "audit", same: false},
+		{name: "element added", model: Account{Email: "leo@example.com", Roles: []string{"ADMIN", "GUEST"}}, tag: "audit", same: false},
+		{name: "all fields", model: account, tag: "", same: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fingerprint(tt.model, tt.tag); (got == fingerprint) != tt.same {
+				t.Errorf("Fingerprint() = %v, want same = %v (%v)", got, tt.same, fingerprint)
+			}
+		})
+	}
+}
@@ -14,10 +14,101 @@ type StructAttribute struct {
 	Children     []StructAttribute
 	ListPosition int
 	isPrimitive  bool
+	isMapEntry   bool
+
+	// Memory layout of the field. Only set when `AttributeOptions.IncludeLayout` is enabled.
+	Layout *FieldLayout
+}
+
+// Describes where a field is placed in memory, as reported by the `reflect` package.
+type FieldLayout struct {
+	// Offset of the field (in bytes) from the start of the struct containing it.
+	// For the elements of a slice/array, this is the offset from the first element.
+	Offset uintptr
+
+	// Number of bytes needed to store a value of the field's type.
+	Size uintptr
+
+	// Alignment (in bytes) of a value of the field's type.
+	Align int
 }
 
 type StructAttributes []StructAttribute
 
+// The value of the panics raised while walking the attributes of a struct (see `GetAttributesWithOptions`),
+// identifying the attribute that was being walked. The original value is kept in `Value`.
+//
+// Usage:
+//
+//	defer func() {
+//		if p, ok := recover().(*AttributePanic); ok {
+//			p.Path // -> "contacts.emails"
+//		}
+//	}()
+type AttributePanic struct {
+	// The full name of the attribute. See `StructAttribute.FullName()`.
+	Path string
+
+	// The value the walk panicked with.
+	Value any
+}
+
+func (p *AttributePanic) Error() string {
+	return fmt.Sprintf("panic while walking %s: %v", p.Path, p.Value)
+}
+
+// The order in which `GetAttributesWithOptions` lists attributes. See `AttributeOptions.Order`.
+type AttributeOrder string
+
+const (
+	// Fields are listed in the order they are declared, each followed by its descendants,
+	// and the elements of slices/arrays in index order. This is the default order.
+	//
+	// Example: `name`, `emails`, `emails[0]`, `emails[1]`, `address`, `address.street`
+	DECLARATION_ORDER AttributeOrder = "declaration"
+
+	// Fields are listed alphabetically (by their JSON names) among the other fields of the same struct,
+	// each followed by its descendants. Elements of slices/arrays are still listed in index order
+	// and the entries of maps in the order set by `AttributeOptions.MapKeyLess`.
+	//
+	// Example: `address`, `address.street`, `emails`, `emails[0]`, `emails[1]`, `name`
+	PATH_ORDER AttributeOrder = "path"
+)
+
+type AttributeOptions struct {
+	// When set, any fields not containing at least one of these tags will be ignored.
+	FilterTags []string
+
+	// When set, any fields contained in this list will be ignored.
+	// Note that the name of the field should be the one defined in the struct.
+	IgnoredFields []string
+
+	// Unexported fields are skipped, unless they are contained in this list.
+	// Note that the name of the field should be the one defined in the struct.
+	// The values of these fields are read-only: calling `Interface()` on them panics.
+	UnexportedFields []string
+
+	// Attributes of the validation tag that are not inherited by the elements of a slice/array.
+	// Defaults to `NON_INHERITABLE_TAG_ATTRIBUTES` when nil.
+	// An empty (non-nil) list means all attributes are inherited.
+	NonInheritableTagAttributes []string
+
+	// Tags whose rules are handled the same way as the ones of the validation tag (see `VALIDATION_TAG_KEYWORD`)
+	// when they are inherited by the elements of a slice/array. For example: `binding`.
+	AdditionalValidationTags []string
+
+	// When set, the memory layout of each attribute is included in `StructAttribute.Layout`.
+	// This could be used by tools generating binary or fixed-width representations of a struct.
+	IncludeLayout bool
+
+	// Orders the keys of the maps found in a struct, which are listed as attributes in that order.
+	// Keys are compared in their string form. Defaults to alphabetical order when nil.
+	MapKeyLess func(a, b string) bool
+
+	// The order in which attributes are listed. Defaults to `DECLARATION_ORDER` when empty.
+	Order AttributeOrder
+}
+
 // Returns the name of the field properly scoped under its parents.
 //
 // Usage:
@@ -32,12 +123,19 @@ type StructAttributes []StructAttribute
 //	}
 //
 //	sa.FullName() // -> "parentA.listB[i].attribute_name"
+//
+// The notation used for slice positions can be changed with `SliceIndexNotation`.
 func (sa *StructAttribute) FullName() (name string) {
+	return notatedPath(sa.bracketName())
+}
+
+// Returns the full name of the field in bracket notation.
+func (sa *StructAttribute) bracketName() string {
 	if len(sa.Parents) == 0 {
 		return GetJSONTagValue(sa.Field)
 	}
 
-	scope := sa.Parents[len(sa.Parents)-1].FullName()
+	scope := sa.Parents[len(sa.Parents)-1].bracketName()
 
 	// Adds the array notation to the slice/array field
 	if sa.ListPosition >= 0 {
@@ -48,12 +146,29 @@ func (sa *StructAttribute) FullName() (name string) {
 		return scope
 	}
 
+	// The entries of a map are named after their keys
+	if sa.isMapEntry {
+		return strings.TrimPrefix(scope+"."+sa.Field.Name, ".")
+	}
+
 	fullName := strings.Join([]string{scope, GetJSONTagValue(sa.Field)}, ".")
 
 	// Ensures field name is never prefixed by a dot (.)
 	return strings.TrimSuffix(strings.TrimPrefix(fullName, "."), ".")
 }
 
+func (options AttributeOptions) nonInheritableTagAttributes() []string {
+	if options.NonInheritableTagAttributes == nil {
+		return NON_INHERITABLE_TAG_ATTRIBUTES
+	}
+
+	return options.NonInheritableTagAttributes
+}
+
+// Returns the number of attributes that should be skipped in order to move past the children of this attribute.
+//
+// Deprecated: the returned value does not match the layout of the attributes returned by `GetAttributes`.
+// Use `StructAttributes.NextSibling` or `StructAttributes.Subtree` instead.
 func (sa *StructAttribute) SkipsPastLastChild() int {
 	if len(sa.Children) == 0 {
 		return 0
@@ -66,3 +181,57 @@ func (sa *StructAttribute) SkipsPastLastChild() int {
 
 	return n
 }
+
+// Returns the descendants of the attribute at position `i`.
+//
+// Attributes are listed in depth-first order, with every attribute appearing before its descendants.
+// This means the descendants of an attribute always form a contiguous block right after it,
+// and every one of them has more parents than the attribute itself.
+//
+// The returned list shares its backing array with `attrs`.
+// An empty list is returned when `i` is out of range or the attribute has no descendants.
+//
+// Usage:
+//
+//	attrs := StructAttributes(GetAttributes(reflect.ValueOf(person), []string{}))
+//	attrs.Subtree(1) // -> [emails[0], emails[1]]
+func (attrs StructAttributes) Subtree(i int) StructAttributes {
+	if i < 0 || i >= len(attrs) {
+		return StructAttributes{}
+	}
+
+	return attrs[i+1 : attrs.NextSibling(i)]
+}
+
+// Returns the position of the first attribute after `i` that is not one of its descendants.
+// This is `len(attrs)` when no such attribute exists.
+//
+// It is always true that `i < NextSibling(i) <= len(attrs)` for any valid position,
+// which makes it safe to use for skipping the descendants of an attribute while iterating:
+//
+//	for pos := 0; pos < len(attrs); {
+//		if skip(attrs[pos]) {
+//			pos = attrs.NextSibling(pos)
+//			continue
+//		}
+//
+//		pos++
+//	}
+func (attrs StructAttributes) NextSibling(i int) int {
+	if i < 0 {
+		return 0
+	}
+
+	if i >= len(attrs) {
+		return len(attrs)
+	}
+
+	depth := len(attrs[i].Parents)
+
+	next := i + 1
+	for next < len(attrs) && len(attrs[next].Parents) > depth {
+		next++
+	}
+
+	return next
+}
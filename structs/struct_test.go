@@ -1,8 +1,11 @@
 package structs
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/quick"
 )
 
 func Test_StructAttribute_SkipsPastLastChild(t *testing.T) {
@@ -50,3 +53,95 @@ func Test_StructAttribute_SkipsPastLastChild(t *testing.T) {
 		})
 	}
 }
+
+func Test_StructAttributes_Subtree(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+	}
+
+	type Model struct {
+		Name      string    `json:"name"`
+		Emails    []string  `json:"emails"`
+		Addresses []Address `json:"addresses"`
+		Address   Address   `json:"address"`
+	}
+
+	model := Model{
+		Emails:    []string{"leo@example.com", "leo@example.org"},
+		Addresses: []Address{{}, {}},
+	}
+
+	attrs := StructAttributes(GetAttributes(reflect.ValueOf(model), []string{}))
+
+	tests := []struct {
+		position int
+		want     []string
+		next     int
+	}{
+		{position: 0, want: []string{}, next: 1},
+		{position: 1, want: []string{"emails[0]", "emails[1]"}, next: 4},
+		{position: 2, want: []string{}, next: 3},
+		{position: 4, want: []string{"addresses[0].street", "addresses[1].street"}, next: 7},
+		{position: 7, want: []string{"address.street"}, next: 9},
+		{position: 8, want: []string{}, next: 9},
+		{position: -1, want: []string{}, next: 0},
+		{position: 9, want: []string{}, next: 9},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint("position - ", tt.position), func(t *testing.T) {
+			got := Map(attrs.Subtree(tt.position), func(_ int, attr StructAttribute) string { return attr.FullName() })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructAttributes.Subtree() = %v, want %v", got, tt.want)
+			}
+
+			if got := attrs.NextSibling(tt.position); got != tt.next {
+				t.Errorf("StructAttributes.NextSibling() = %v, want %v", got, tt.next)
+			}
+		})
+	}
+}
+
+func Test_StructAttributes_Subtree_Invariants(t *testing.T) {
+	type Leaf struct {
+		Values []string `json:"values"`
+	}
+
+	type Node struct {
+		Leaf
+		Name   string  `json:"name"`
+		Leaves []Leaf  `json:"leaves"`
+		Next   *Leaf   `json:"next"`
+		Scores [3]int  `json:"scores"`
+		Nested []*Leaf `json:"nested"`
+	}
+
+	// Every attribute must be followed by its descendants, and only by them.
+	property := func(node Node) bool {
+		attrs := StructAttributes(GetAttributes(reflect.ValueOf(node), []string{}))
+
+		for i, attr := range attrs {
+			next := attrs.NextSibling(i)
+			if next <= i || next > len(attrs) {
+				return false
+			}
+
+			for _, child := range attrs.Subtree(i) {
+				if !strings.HasPrefix(child.FullName(), attr.FullName()) {
+					return false
+				}
+			}
+
+			for _, other := range attrs[next:] {
+				if strings.HasPrefix(other.FullName(), attr.FullName()+".") || strings.HasPrefix(other.FullName(), attr.FullName()+"[") {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,168 @@
+package structs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Describes the shape of a model, as it would be returned by `GetAttributes`.
+type AttributeSummary struct {
+	// Total number of attributes.
+	Total int
+
+	// Number of attributes of each kind.
+	Kinds map[reflect.Kind]int
+
+	// Number of attributes at each depth, where top-level attributes have a depth of 0.
+	Depths map[int]int
+
+	// The deepest level containing at least one attribute.
+	MaxDepth int
+
+	// The length of the longest slice/array found in the model.
+	MaxSliceLength int
+
+	// The length of the longest slice/array found under each path.
+	// Paths use the JSON names of the fields without list positions, so `addresses[0].tags`
+	// and `addresses[1].tags` are both recorded under `addresses.tags`.
+	SliceLengths map[string]int
+}
+
+// Computes the shape of the given model without building its attributes.
+// This allows callers to cheaply reject models that are too large or too deeply nested before processing them.
+//
+// The counts always match the attributes returned by `GetAttributes(reflect.ValueOf(model), []string{})`.
+//
+// Usage:
+//
+//	type Person struct {
+//		Name   string   `json:"name"`
+//		Emails []string `json:"emails"`
+//	}
+//
+//	summary := Summary(Person{Emails: []string{"leo@example.com", "leo@example.org"}})
+//	summary.Total          // -> 4
+//	summary.Depths         // -> {0: 2, 1: 2}
+//	summary.MaxSliceLength // -> 2
+func Summary(model any) AttributeSummary {
+	summary := AttributeSummary{
+		Kinds:        map[reflect.Kind]int{},
+		Depths:       map[int]int{},
+		SliceLengths: map[string]int{},
+	}
+
+	summarize(reflect.ValueOf(model), &summary, "", 0)
+
+	return summary
+}
+
+// Records the fields of the given struct following the same rules used by `getAttributes`.
+func summarize(rv reflect.Value, summary *AttributeSummary, scope string, depth int) {
+	if rv.Kind() == reflect.Pointer {
+		rv, _ = PointerElement(rv)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	for position := 0; position < rv.NumField(); position++ {
+		value, _ := PointerElement(rv.Field(position))
+		rsf := rv.Type().Field(position)
+
+		value, isNullable := nullableValue(value)
+
+		if rsf.Anonymous {
+			summarize(value, summary, scope, depth)
+			continue
+		}
+
+		if !rsf.IsExported() {
+			continue
+		}
+
+		summary.record(value, depth)
+
+		if isOpaqueType(value) || isNullable {
+			continue
+		}
+
+		path := strings.TrimPrefix(scope+"."+GetJSONTagValue(rsf), ".")
+
+		switch value.Kind() {
+		case reflect.Struct:
+			summarize(value, summary, path, depth+1)
+		case reflect.Slice, reflect.Array:
+			summarizeList(value, summary, path, depth+1)
+		case reflect.Map:
+			summarizeMap(value, summary, path, depth+1)
+		}
+	}
+}
+
+// Records the entries of the given map following the same rules used by `getMapAttributes`.
+// Lengths of the slices/arrays held by the entries are recorded under their keys, as in `settings.tags`.
+func summarizeMap(value reflect.Value, summary *AttributeSummary, path string, depth int) {
+	iter := value.MapRange()
+	for iter.Next() {
+		el, _ := PointerElement(iter.Value())
+		el, isNullable := nullableValue(el)
+		summary.record(el, depth)
+
+		if isOpaqueType(el) || isNullable {
+			continue
+		}
+
+		entryPath := path + "." + fmt.Sprint(iter.Key().Interface())
+
+		switch el.Kind() {
+		case reflect.Struct:
+			summarize(el, summary, entryPath, depth+1)
+		case reflect.Slice, reflect.Array:
+			summarizeList(el, summary, entryPath, depth+1)
+		case reflect.Map:
+			summarizeMap(el, summary, entryPath, depth+1)
+		}
+	}
+}
+
+// Records the elements of the given slice/array following the same rules used by `getListAttributes`.
+// Lengths of nested slices/arrays are recorded under the path of the field holding them.
+func summarizeList(value reflect.Value, summary *AttributeSummary, path string, depth int) {
+	if value.Len() > summary.SliceLengths[path] {
+		summary.SliceLengths[path] = value.Len()
+	}
+
+	if value.Len() > summary.MaxSliceLength {
+		summary.MaxSliceLength = value.Len()
+	}
+
+	elemType := baseType(value.Type().Elem())
+	isListOfPrimitives := (elemType.Kind() != reflect.Struct || IsNullableWrapper(elemType)) && value.Type() != uuidType
+
+	for l := 0; l < value.Len(); l++ {
+		if !isListOfPrimitives {
+			summarize(value.Index(l), summary, path, depth)
+			continue
+		}
+
+		el, _ := PointerElement(value.Index(l))
+		el, _ = nullableValue(el)
+		summary.record(el, depth)
+
+		if isNestedList(elemType) && el.Kind() != reflect.Pointer {
+			summarizeList(el, summary, path, depth+1)
+		}
+	}
+}
+
+func (summary *AttributeSummary) record(value reflect.Value, depth int) {
+	summary.Total++
+	summary.Kinds[value.Kind()]++
+	summary.Depths[depth]++
+
+	if depth > summary.MaxDepth {
+		summary.MaxDepth = depth
+	}
+}
@@ -0,0 +1,98 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func Test_Summary(t *testing.T) {
+	model := newBenchmarkModel()
+
+	got := Summary(model)
+	want := AttributeSummary{
+		Total:          25,
+		Kinds:          map[reflect.Kind]int{reflect.String: 21, reflect.Slice: 3, reflect.Struct: 1},
+		Depths:         map[int]int{0: 6, 1: 19},
+		MaxDepth:       1,
+		MaxSliceLength: 5,
+		SliceLengths:   map[string]int{"emails": 2, "addresses": 3, "tags": 5},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_Summary_UnexportedFields(t *testing.T) {
+	type Account struct {
+		Name     string `json:"name"`
+		password string
+		tokens   []string
+	}
+
+	account := Account{Name: "Leo", password: "secret", tokens: []string{"a", "b", "c"}}
+
+	want := AttributeSummary{
+		Total:        1,
+		Kinds:        map[reflect.Kind]int{reflect.String: 1},
+		Depths:       map[int]int{0: 1},
+		SliceLengths: map[string]int{},
+	}
+
+	if got := Summary(account); !reflect.DeepEqual(got, want) || got.Total != len(GetAttributes(reflect.ValueOf(account), []string{})) {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_Summary_MatchesAttributes(t *testing.T) {
+	type Leaf struct {
+		Values []string `json:"values"`
+	}
+
+	type Node struct {
+		Leaf
+		Name   string  `json:"name"`
+		Leaves []Leaf  `json:"leaves"`
+		Next   *Leaf   `json:"next"`
+		Scores [3]int  `json:"scores"`
+		Nested []*Leaf `json:"nested"`
+
+		Authors *[]*Leaf  `json:"authors"`
+		Grid    [][]*int  `json:"grid"`
+		Names   []*string `json:"names"`
+
+		Settings map[string]*Leaf `json:"settings"`
+		Labels   map[string][]int `json:"labels"`
+		Limits   map[int]string   `json:"limits"`
+	}
+
+	property := func(node Node) bool {
+		attributes := GetAttributes(reflect.ValueOf(node), []string{})
+		summary := Summary(node)
+
+		if summary.Total != len(attributes) {
+			return false
+		}
+
+		depths := map[int]int{}
+		for _, attr := range attributes {
+			depths[len(attr.Parents)]++
+		}
+
+		return reflect.DeepEqual(depths, summary.Depths)
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func Benchmark_Summary(b *testing.B) {
+	model := newBenchmarkModel()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Summary(model)
+	}
+}
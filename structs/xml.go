@@ -0,0 +1,31 @@
+package structs
+
+import (
+	"encoding/xml"
+)
+
+// Decodes an XML payload into a Go struct using `encoding/xml`, so fields are matched by their `xml` tags.
+// The hooks, `Recover` and `AtomicPopulate` behave as in `Decode`, but the schema rules are not checked,
+// since the JSON schema of the model does not describe its XML representation.
+// No paths are reported to `PopulateHook`, as the attributes set by `encoding/xml` cannot be told apart.
+//
+// If the payload is not valid XML (or does not fit the model), an `INVALID_PAYLOAD` error is reported under the `_` key.
+//
+// Usage:
+//
+//	type User struct {
+//		Name string `xml:"name"`
+//		Age  int    `xml:"age"`
+//	}
+//
+//	errs := DecodeXML([]byte(`<user><name>Leo</name><age>thirty</age></user>`), &user, DecoderOptions{})
+//	// -> {"_": ["INVALID_PAYLOAD"]}
+func DecodeXML(data []byte, model any, options DecoderOptions) map[string][]string {
+	return options.decode(data, model, func(data []byte, model any) ([]string, map[string][]string) {
+		if err := xml.Unmarshal(data, model); err != nil {
+			return nil, map[string][]string{"_": {options.errorCode("invalid_payload")}}
+		}
+
+		return nil, nil
+	}, nil).Validations
+}
@@ -0,0 +1,57 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_DecodeXML(t *testing.T) {
+	type User struct {
+		Name string   `xml:"name"`
+		Age  int      `xml:"age"`
+		Tags []string `xml:"tag"`
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		options   DecoderOptions
+		want      map[string][]string
+		wantModel User
+	}{
+		{
+			name:      "valid",
+			data:      `<user><name>Leo</name><age>30</age><tag>a</tag><tag>b</tag></user>`,
+			options:   DecoderOptions{},
+			want:      map[string][]string{},
+			wantModel: User{Name: "Leo", Age: 30, Tags: []string{"a", "b"}},
+		},
+		{
+			name:      "invalid",
+			data:      `<user><name>Leo</name><age>thirty</age></user>`,
+			options:   DecoderOptions{AtomicPopulate: true},
+			want:      map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel: User{},
+		},
+		{
+			name:      "empty",
+			data:      ``,
+			options:   DecoderOptions{},
+			want:      map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel: User{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model User
+
+			if got := DecodeXML([]byte(tt.data), &model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeXML() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.wantModel) {
+				t.Errorf("DecodeXML() model = %+v, want %+v", model, tt.wantModel)
+			}
+		})
+	}
+}
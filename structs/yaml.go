@@ -0,0 +1,85 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Decodes a YAML payload (i.e. a Kubernetes-style configuration) into a Go struct.
+// The payload is converted to JSON and then decoded by `Decode`, so fields are matched by their JSON names
+// and the same rules (`ADDITIONAL_PROPERTY`, `REQUIRED_ATTRIBUTE` and `INVALID_TYPE`) are checked.
+// Hooks receive the payload converted to JSON. Only the first document of a multi-document payload is decoded.
+//
+// If the payload is not valid YAML, an `INVALID_PAYLOAD` error is reported under the `_` key.
+//
+// Usage:
+//
+//	payload := []byte("name: 42\nemails:\n  - test@example.com\n")
+//	errs := DecodeYAML(payload, &user, DecoderOptions{Rules: []SchemaValidationRule{INVALID_TYPE}})
+//	// -> {"name": ["INVALID_TYPE"]}
+func DecodeYAML(data []byte, model any, options DecoderOptions) map[string][]string {
+	converted, err := yamlToJSON(data)
+	if err != nil {
+		return options.payloadError("invalid_payload")
+	}
+
+	return Decode(converted, model, options)
+}
+
+// Converts a YAML document into JSON. An empty document results in an empty payload.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if document == nil {
+		return []byte{}, nil
+	}
+
+	return json.Marshal(jsonCompatible(document))
+}
+
+// Returns the YAML representation of the model, using the JSON names of its fields.
+func encodeYAML(model any) ([]byte, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(document)
+}
+
+// Replaces the maps with non-string keys produced by the YAML decoder (i.e. `1: one`), which JSON does not support.
+func jsonCompatible(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = jsonCompatible(item)
+		}
+
+		return v
+	case map[any]any:
+		object := make(map[string]any, len(v))
+		for key, item := range v {
+			object[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+
+		return object
+	case []any:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+
+		return v
+	}
+
+	return value
+}
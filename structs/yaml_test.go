@@ -0,0 +1,98 @@
+package structs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_DecodeYAML(t *testing.T) {
+	type Container struct {
+		Name  string `json:"name" jsonschema:"required"`
+		Image string `json:"image" jsonschema:"required"`
+		Ports []int  `json:"ports"`
+	}
+
+	type Deployment struct {
+		Kind       string            `json:"kind" jsonschema:"required"`
+		Replicas   int               `json:"replicas"`
+		Labels     map[string]string `json:"labels"`
+		Containers []Container       `json:"containers"`
+	}
+
+	rules := []SchemaValidationRule{ADDITIONAL_PROPERTY, REQUIRED_ATTRIBUTE, INVALID_TYPE}
+
+	tests := []struct {
+		name      string
+		data      string
+		options   DecoderOptions
+		want      map[string][]string
+		wantModel Deployment
+	}{
+		{
+			name: "valid",
+			data: `
+kind: Deployment
+replicas: 2
+labels:
+  app: web
+  1: one
+containers:
+  - name: web
+    image: nginx
+    ports: [80, 443]
+`,
+			options: DecoderOptions{Rules: rules},
+			want:    map[string][]string{},
+			wantModel: Deployment{
+				Kind:       "Deployment",
+				Replicas:   2,
+				Labels:     map[string]string{"app": "web", "1": "one"},
+				Containers: []Container{{Name: "web", Image: "nginx", Ports: []int{80, 443}}},
+			},
+		},
+		{
+			name: "schema errors",
+			data: `
+replicas: two
+extra: true
+containers:
+  - name: web
+`,
+			options: DecoderOptions{Rules: rules},
+			want: map[string][]string{
+				"kind":             {"REQUIRED_ATTRIBUTE_MISSING"},
+				"replicas":         {"INVALID_TYPE"},
+				"extra":            {"ADDITIONAL_PROPERTY"},
+				"containers.image": {"REQUIRED_ATTRIBUTE_MISSING"},
+			},
+			wantModel: Deployment{Containers: []Container{{Name: "web"}}},
+		},
+		{
+			name:      "empty",
+			data:      "",
+			options:   DecoderOptions{Rules: rules},
+			want:      map[string][]string{},
+			wantModel: Deployment{},
+		},
+		{
+			name:      "invalid yaml",
+			data:      "kind: [Deployment",
+			options:   DecoderOptions{Rules: rules},
+			want:      map[string][]string{"_": {"INVALID_PAYLOAD"}},
+			wantModel: Deployment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model Deployment
+
+			if got := DecodeYAML([]byte(tt.data), &model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeYAML() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.wantModel) {
+				t.Errorf("DecodeYAML() model = %+v, want %+v", model, tt.wantModel)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// The parts of an AWS API Gateway proxy event (REST or HTTP API) that are bound to a model.
+//
+// Its JSON representation matches the one of the events received by Lambda functions,
+// so raw events can be unmarshalled into it directly.
+type APIGatewayEvent struct {
+	Body                            string              `json:"body"`
+	IsBase64Encoded                 bool                `json:"isBase64Encoded"`
+	PathParameters                  map[string]string   `json:"pathParameters"`
+	QueryStringParameters           map[string]string   `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string `json:"multiValueQueryStringParameters"`
+}
+
+// Binds an API Gateway proxy event to the given struct pointer and validates the result.
+//
+// Fields tagged with `pathparam` and `query` are populated with the path and query string parameters of the event
+// (see `structs.BindParams`), which are merged into the body before it is decoded the same way as `ValidatePayload` does.
+// This way, the schema rules see the whole request: a required field bound from the path is not reported as missing.
+// Parameters take precedence over values found in the body, and hooks receive the merged body.
+// Validation only runs once all three sources have been bound, so the returned errors cover the whole request.
+//
+// Usage:
+//
+//	type UpdateUser struct {
+//		Id     string `json:"id" pathparam:"id" validate:"uuid"`
+//		DryRun bool   `json:"dry_run" query:"dry_run"`
+//		Name   string `json:"name" validate:"min=2"`
+//	}
+//
+//	var r UpdateUser
+//	errs := BindAPIGatewayEvent(event, &r, PayloadValidationOptions{})
+func BindAPIGatewayEvent(event APIGatewayEvent, model any, options PayloadValidationOptions) map[string][]string {
+	options = options.resolved()
+
+	body := []byte(event.Body)
+
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return map[string][]string{options.KeyPrefix + "_": {options.decodingError("invalid_payload")}}
+		}
+
+		body = decoded
+	}
+
+	query := make(map[string][]string, len(event.QueryStringParameters))
+	for k, v := range event.QueryStringParameters {
+		query[k] = []string{v}
+	}
+
+	for k, v := range event.MultiValueQueryStringParameters {
+		query[k] = v
+	}
+
+	pathParams := make(map[string][]string, len(event.PathParameters))
+	for k, v := range event.PathParameters {
+		pathParams[k] = []string{v}
+	}
+
+	var bindErrors map[string][]string
+	if err := structs.ValidateModel(model); err == nil {
+		body, bindErrors = mergeParams(body, reflect.TypeOf(model).Elem(), map[string]map[string][]string{
+			structs.PATH_PARAM_TAG_KEYWORD: pathParams,
+			structs.QUERY_TAG_KEYWORD:      query,
+		})
+	}
+
+	decoderErrors := structs.Decode(body, model, options.DecoderOptions)
+
+	if _, ok := decoderErrors["_"]; !ok {
+		decoderErrors = structs.MergeValidations(decoderErrors, bindErrors)
+	}
+
+	decoderErrors = prefixKeys(decoderErrors, options.KeyPrefix)
+
+	// NOTE: no need to go any further because the payload (or the model) is invalid.
+	if _, ok := decoderErrors[options.KeyPrefix+"_"]; ok {
+		return decoderErrors
+	}
+
+	return structs.MergeValidations(decoderErrors, Validate(model, options.ValidationOptions))
+}
+
+// Merges the parameters, keyed by the tags of the fields they are bound to, into a JSON body for the given struct type.
+// Parameters are converted to the types of their fields, and the ones that cannot be converted are merged
+// as they are (so that the schema rules report them too), along with their `INVALID_TYPE` errors.
+// Bodies that are not JSON objects are returned as they are, for the decoder to report them.
+func mergeParams(body []byte, t reflect.Type, params map[string]map[string][]string) ([]byte, map[string][]string) {
+	document := map[string]json.RawMessage{}
+	if len(bytes.TrimSpace(body)) != 0 {
+		if err := json.Unmarshal(body, &document); err != nil || document == nil {
+			return body, nil
+		}
+	}
+
+	bound := reflect.New(t)
+	validations := map[string][]string{}
+
+	merged := false
+	for _, tag := range []string{structs.PATH_PARAM_TAG_KEYWORD, structs.QUERY_TAG_KEYWORD} {
+		validations = structs.MergeValidations(validations, structs.BindParams(bound.Interface(), tag, params[tag]))
+
+		for _, sf := range reflect.VisibleFields(t) {
+			if sf.Anonymous || !sf.IsExported() {
+				continue
+			}
+
+			if _, ok := sf.Tag.Lookup(tag); !ok {
+				continue
+			}
+
+			values, ok := params[tag][structs.GetTagValue(sf, tag)]
+			if !ok || len(values) == 0 {
+				continue
+			}
+
+			name := structs.GetJSONTagValue(sf)
+
+			var value any = values[0]
+			if _, invalid := validations[name]; !invalid {
+				value = bound.Elem().FieldByIndex(sf.Index).Interface()
+			}
+
+			if raw, err := json.Marshal(value); err == nil {
+				document[name] = raw
+				merged = true
+			}
+		}
+	}
+
+	if !merged {
+		return body, validations
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return body, validations
+	}
+
+	return data, validations
+}
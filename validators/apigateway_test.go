@@ -0,0 +1,100 @@
+package validators
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_BindAPIGatewayEvent(t *testing.T) {
+	type UpdateUser struct {
+		Id     string `json:"id" pathparam:"id" validate:"uuid" jsonschema:"required"`
+		DryRun bool   `json:"dry_run" query:"dry_run"`
+		Name   string `json:"name" validate:"min=2"`
+	}
+
+	tests := []struct {
+		name    string
+		event   APIGatewayEvent
+		options PayloadValidationOptions
+		model   UpdateUser
+		want    map[string][]string
+	}{
+		{
+			name: "event - 1",
+			event: APIGatewayEvent{
+				Body:                  `{"name": "Leonardo", "id": "abc"}`,
+				PathParameters:        map[string]string{"id": "2b852002-f19d-11ec-8ea0-0242ac120002"},
+				QueryStringParameters: map[string]string{"dry_run": "true"},
+			},
+			model: UpdateUser{Id: "2b852002-f19d-11ec-8ea0-0242ac120002", DryRun: true, Name: "Leonardo"},
+			want:  map[string][]string{},
+		},
+		{
+			name: "event - 2",
+			event: APIGatewayEvent{
+				Body:                            base64.StdEncoding.EncodeToString([]byte(`{"name": "L"}`)),
+				IsBase64Encoded:                 true,
+				PathParameters:                  map[string]string{"id": "abc"},
+				MultiValueQueryStringParameters: map[string][]string{"dry_run": {"maybe"}},
+			},
+			options: PayloadValidationOptions{ValidationOptions: ValidationOptions{KeyPrefix: "request."}},
+			model:   UpdateUser{Id: "abc", Name: "L"},
+			want: map[string][]string{
+				"request.id":      {"INVALID_FORMAT"},
+				"request.dry_run": {"INVALID_TYPE"},
+				"request.name":    {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "event - 3",
+			event: APIGatewayEvent{
+				Body:                  `{"name": "Leonardo"}`,
+				PathParameters:        map[string]string{"id": "2b852002-f19d-11ec-8ea0-0242ac120002"},
+				QueryStringParameters: map[string]string{"dry_run": "maybe"},
+			},
+			options: PayloadValidationOptions{
+				DecoderOptions: structs.DecoderOptions{Rules: []structs.SchemaValidationRule{structs.REQUIRED_ATTRIBUTE, structs.INVALID_TYPE}},
+			},
+			model: UpdateUser{Id: "2b852002-f19d-11ec-8ea0-0242ac120002", Name: "Leonardo"},
+			want:  map[string][]string{"dry_run": {"INVALID_TYPE"}},
+		},
+		{
+			name: "event - 4",
+			event: APIGatewayEvent{
+				PathParameters: map[string]string{"id": "2b852002-f19d-11ec-8ea0-0242ac120002"},
+			},
+			options: PayloadValidationOptions{
+				DecoderOptions: structs.DecoderOptions{Rules: []structs.SchemaValidationRule{structs.REQUIRED_ATTRIBUTE}},
+			},
+			model: UpdateUser{Id: "2b852002-f19d-11ec-8ea0-0242ac120002"},
+			want:  map[string][]string{"name": {"INVALID_LENGTH"}},
+		},
+		{
+			name:  "event - 5",
+			event: APIGatewayEvent{Body: "{", IsBase64Encoded: true},
+			want:  map[string][]string{"_": {"INVALID_PAYLOAD"}},
+		},
+		{
+			name:    "event - 6",
+			event:   APIGatewayEvent{Body: "{", IsBase64Encoded: true},
+			options: PayloadValidationOptions{ErrorCodes: map[string]string{"invalid_payload": "BAD_BODY"}},
+			want:    map[string][]string{"_": {"BAD_BODY"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model UpdateUser
+
+			if got := BindAPIGatewayEvent(tt.event, &model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BindAPIGatewayEvent() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.model) {
+				t.Errorf("BindAPIGatewayEvent() populated %+v, want %+v", model, tt.model)
+			}
+		})
+	}
+}
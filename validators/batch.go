@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"encoding/json"
+	"runtime"
+	"sync"
+)
+
+// Validates every item of a list, such as the records sent to a bulk-import endpoint. See `Validate`.
+// Items are validated concurrently, by as many workers as `runtime.GOMAXPROCS` allows.
+//
+// Returns the validations of each item, in the same order as the items.
+// Valid items have an empty map. `Trace` and `Collector` can be shared by all items, as they are safe for concurrent use.
+// A panic while validating an item (i.e. in a hook) is raised again in the calling goroutine, once all items are processed.
+//
+// Usage:
+//
+//	results := ValidateAll([]Person{{Name: "Leo"}, {Name: "L"}}, ValidationOptions{})
+//	// -> [{}, {name: ["INVALID_LENGTH"]}]
+func ValidateAll[T any](items []T, options ValidationOptions) []map[string][]string {
+	results := make([]map[string][]string, len(items))
+
+	forEachConcurrently(len(items), func(i int) {
+		results[i] = Validate(items[i], options)
+	})
+
+	return results
+}
+
+// Decodes and validates a JSON array, each of its elements into a new instance of `T`. See `ValidatePayload`.
+// Elements are processed concurrently, as in `ValidateAll`.
+//
+// Returns the decoded items and their validations, in the same order as the elements of the array.
+// If the payload is not a JSON array, no items are returned and an `INVALID_PAYLOAD` error is reported under the `_` key of a single result.
+//
+// Usage:
+//
+//	people, results := ValidatePayloadAll[Person]([]byte(`[{"name": "Leo"}, {"name": 1}]`), options)
+//	// -> [{}, {name: ["INVALID_TYPE", "INVALID_LENGTH"]}]
+func ValidatePayloadAll[T any](data []byte, options PayloadValidationOptions) ([]*T, []map[string][]string) {
+	options = options.resolved()
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil || elements == nil {
+		return nil, []map[string][]string{{options.KeyPrefix + "_": {options.decodingError("invalid_payload")}}}
+	}
+
+	items := make([]*T, len(elements))
+	results := make([]map[string][]string, len(elements))
+
+	forEachConcurrently(len(elements), func(i int) {
+		items[i] = new(T)
+		results[i] = ValidatePayload(elements[i], items[i], options)
+	})
+
+	return items, results
+}
+
+// Calls `fn` for every index in [0, n), using a pool of workers.
+// Panics are recovered in the workers, so that the remaining indexes are still processed,
+// and the first one is raised again in the calling goroutine, where it can be recovered.
+func forEachConcurrently(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+
+	var (
+		wg        sync.WaitGroup
+		once      sync.Once
+		panicked  bool
+		recovered any
+	)
+
+	call := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				once.Do(func() { panicked, recovered = true, r })
+			}
+		}()
+
+		fn(i)
+	}
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				call(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if panicked {
+		panic(recovered)
+	}
+}
@@ -0,0 +1,118 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ValidateAll(t *testing.T) {
+	type Person struct {
+		Name string `json:"name" validate:"min=2"`
+	}
+
+	tests := []struct {
+		name    string
+		items   []Person
+		options ValidationOptions
+		want    []map[string][]string
+	}{
+		{
+			name:    "empty",
+			items:   []Person{},
+			options: ValidationOptions{},
+			want:    []map[string][]string{},
+		},
+		{
+			name:    "per item",
+			items:   []Person{{Name: "Leo"}, {Name: "L"}, {Name: "Mo"}, {}},
+			options: ValidationOptions{KeyPrefix: "person."},
+			want: []map[string][]string{
+				{},
+				{"person.name": {"INVALID_LENGTH"}},
+				{},
+				{"person.name": {"INVALID_LENGTH"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateAll(tt.items, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Items are validated concurrently, but share the same collector
+	collector := NewFailureCollector()
+	ValidateAll(make([]Person, 100), ValidationOptions{Collector: collector})
+
+	if got := collector.Counts()["name"]["INVALID_LENGTH"]; got != 100 {
+		t.Errorf("FailureCollector.Counts() = %v, want %v", got, 100)
+	}
+
+	// Panics in the workers are raised again in the calling goroutine
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("ValidateAll() panic = %v, want %v", r, "boom")
+		}
+	}()
+
+	ValidateAll([]Person{{Name: "Leo"}, {Name: "boom"}}, ValidationOptions{
+		BeforeAttribute: func(attribute structs.StructAttribute) structs.StructAttribute {
+			if attribute.Value.String() == "boom" {
+				panic("boom")
+			}
+
+			return attribute
+		},
+	})
+
+	t.Errorf("ValidateAll() did not panic")
+}
+
+func Test_ValidatePayloadAll(t *testing.T) {
+	type Person struct {
+		Name string `json:"name" validate:"min=2"`
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		wantItems []*Person
+		want      []map[string][]string
+	}{
+		{
+			name:      "per element",
+			data:      `[{"name": "Leo"}, {"name": 1}, {"name": "L"}]`,
+			wantItems: []*Person{{Name: "Leo"}, {}, {Name: "L"}},
+			want: []map[string][]string{
+				{},
+				{"name": {"INVALID_TYPE", "INVALID_LENGTH"}},
+				{"name": {"INVALID_LENGTH"}},
+			},
+		},
+		{
+			name:      "not an array",
+			data:      `{"name": "Leo"}`,
+			wantItems: nil,
+			want:      []map[string][]string{{"_": {"INVALID_PAYLOAD"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, got := ValidatePayloadAll[Person]([]byte(tt.data), PayloadValidationOptions{
+				DecoderOptions: structs.DecoderOptions{Rules: []structs.SchemaValidationRule{structs.INVALID_TYPE}},
+			})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePayloadAll() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("ValidatePayloadAll() items = %v, want %v", items, tt.wantItems)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package validators
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// Returns `true` if the decimal number fits a `NUMERIC(precision, scale)` column without being rounded or overflowing:
+// it has at most `scale` digits after the decimal point and at most `precision - scale` digits before it.
+// Leading zeros of the integer part and trailing zeros of the fractional part are not counted.
+// Only plain decimals (as in `-1234.56`) are accepted, numbers in scientific notation are not.
+//
+// Usage:
+//
+//	FitsNumeric("12345678.90", 10, 2) // -> true
+//	FitsNumeric("123456789.9", 10, 2) // -> false
+//	FitsNumeric("0.125", 10, 2)       // -> false
+func FitsNumeric(value string, precision int, scale int) bool {
+	integer, fraction, ok := decimalDigits(value)
+	return ok && fraction <= scale && integer+scale <= precision
+}
+
+// Returns the number of significant digits before and after the decimal point of a plain decimal, as in `-0012.50` (2 and 1).
+// The third value is `false` if the value is not a plain decimal.
+func decimalDigits(value string) (integer int, fraction int, ok bool) {
+	if len(value) != 0 && (value[0] == '-' || value[0] == '+') {
+		value = value[1:]
+	}
+
+	digits, leading, point := 0, true, -1
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '.' && point == -1:
+			point = i
+		case c >= '0' && c <= '9':
+			digits++
+
+			// Leading zeros are not significant
+			if point == -1 && (!leading || c != '0') {
+				leading = false
+				integer++
+			}
+		default:
+			return 0, 0, false
+		}
+	}
+
+	if digits == 0 {
+		return 0, 0, false
+	}
+
+	if point != -1 {
+		fraction = len(value) - point - 1
+
+		// Trailing zeros are not significant
+		for fraction > 0 && value[point+fraction] == '0' {
+			fraction--
+		}
+	}
+
+	return integer, fraction, true
+}
+
+// Returns the value as a plain decimal, for numbers and strings. The second value is `false` for any other type.
+// Floats are written with the fewest digits that represent them exactly, so `0.1` stays `0.1`.
+func decimalValue(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsInf(v.Float(), 0) || math.IsNaN(v.Float()) {
+			return "", true
+		}
+
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), true
+	}
+
+	return "", false
+}
+
+// Returns the value of the `scale` rule of the attribute, if it has one. See `PRECISION`.
+func (options ValidationOptions) fieldScale(attribute structs.StructAttribute) (int, bool) {
+	for _, rule := range options.program(attribute.Field) {
+		if options.canonicalRule(rule.name) != SCALE || structs.Contains(options.SkipRules, SCALE) {
+			continue
+		}
+
+		scale, err := strconv.Atoi(rule.value)
+		return scale, err == nil && scale >= 0
+	}
+
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_FitsNumeric(t *testing.T) {
+	tests := []struct {
+		value     string
+		precision int
+		scale     int
+		want      bool
+	}{
+		{value: "12345678.90", precision: 10, scale: 2, want: true},
+		{value: "-12345678.9", precision: 10, scale: 2, want: true},
+		{value: "123456789.9", precision: 10, scale: 2, want: false},
+		{value: "0.125", precision: 10, scale: 2, want: false},
+		{value: "0.12500", precision: 10, scale: 3, want: true},
+		{value: "000123", precision: 3, scale: 0, want: true},
+		{value: ".5", precision: 1, scale: 1, want: true},
+		{value: "1e3", precision: 10, scale: 2, want: false},
+		{value: "1.2.3", precision: 10, scale: 2, want: false},
+		{value: "-", precision: 10, scale: 2, want: false},
+		{value: "", precision: 10, scale: 2, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := FitsNumeric(tt.value, tt.precision, tt.scale); got != tt.want {
+				t.Errorf("FitsNumeric() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_PrecisionAndScale(t *testing.T) {
+	type Invoice struct {
+		Total    float64   `json:"total" validate:"precision=10,scale=2"`
+		Amount   string    `json:"amount" validate:"precision=6,scale=4"`
+		Rate     *float32  `json:"rate" validate:"scale=3"`
+		Quantity int       `json:"quantity" validate:"precision=3"`
+		Items    []float64 `json:"items" validate:"each:precision=4,each:scale=1"`
+	}
+
+	rate := float32(0.125)
+	invalidRate := float32(0.1255)
+
+	tests := []struct {
+		name  string
+		model Invoice
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Invoice{Total: 12345678.9, Amount: "-12.3400", Rate: &rate, Quantity: 999, Items: []float64{100.5, 0.1}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid",
+			model: Invoice{Total: 123456789, Amount: "1.23456", Rate: &invalidRate, Quantity: 1000, Items: []float64{1000.5, 0.15}},
+			want: map[string][]string{
+				"total":    {"INVALID_VALUE"},
+				"amount":   {"INVALID_VALUE"},
+				"rate":     {"INVALID_VALUE"},
+				"quantity": {"INVALID_VALUE"},
+				"items[0]": {"INVALID_VALUE"},
+				"items[1]": {"INVALID_VALUE"},
+			},
+		},
+		{
+			name:  "not a decimal",
+			model: Invoice{Amount: "1e3", Rate: &rate},
+			want:  map[string][]string{"amount": {"INVALID_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	type Invalid struct {
+		Total  float64 `json:"total" validate:"precision=0"`
+		Amount bool    `json:"amount" validate:"scale=2"`
+	}
+
+	want := map[string][]string{"total": {"UNEXPECTED_ERROR"}, "amount": {"INVALID_TYPE"}}
+	if got := Validate(Invalid{}, ValidationOptions{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
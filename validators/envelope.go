@@ -0,0 +1,99 @@
+package validators
+
+import (
+	"encoding/json"
+)
+
+type (
+	// The model (and the options used for validating it) registered for a type of envelope.
+	EnvelopeRoute struct {
+		// An instance of the model the data of the envelope should be decoded into.
+		Model any
+
+		Options PayloadValidationOptions
+	}
+
+	// The outcome of dispatching an envelope.
+	EnvelopeResult struct {
+		// The type of the envelope.
+		Type string
+
+		// A pointer to a new instance of the registered model, populated with the data of the envelope.
+		// This is nil if the envelope is malformed or its type is not registered.
+		Model any
+
+		Validations map[string][]string
+	}
+
+	// A message in the format `{"type": "...", "data": {...}}`, commonly used over WebSockets.
+	envelope struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+)
+
+// Decodes the data of an envelope into the model registered for its type and validates it
+// using the options registered for that type.
+//
+// Malformed envelopes are reported as an `INVALID_PAYLOAD` error and unknown types as an `UNREGISTERED_MODEL` error,
+// both under the `_` key. See `DispatchEnvelopeWithOptions` for prefixing the keys of the errors.
+//
+// Usage:
+//
+//	routes := map[string]EnvelopeRoute{
+//		"chat.message": {Model: ChatMessage{}},
+//		"chat.typing":  {Model: Typing{}, Options: PayloadValidationOptions{...}},
+//	}
+//
+//	result := DispatchEnvelope([]byte(`{"type": "chat.message", "data": {"text": "hi"}}`), routes)
+//	if message, ok := EnvelopeModel[ChatMessage](result); ok {
+//		...
+//	}
+func DispatchEnvelope(data []byte, routes map[string]EnvelopeRoute) EnvelopeResult {
+	return DispatchEnvelopeWithOptions(data, routes, PayloadValidationOptions{})
+}
+
+// Works like `DispatchEnvelope`, using the given options for the errors of the envelope itself.
+//
+// The `KeyPrefix` is applied to every error: the ones of malformed envelopes and unknown types,
+// as well as the ones found in the data of the envelope, which are validated using the options registered for its type.
+// Error codes of the envelope (i.e. `INVALID_PAYLOAD` and `UNREGISTERED_MODEL`) can be replaced using `ErrorCodes`.
+//
+// Usage:
+//
+//	options := PayloadValidationOptions{ValidationOptions: ValidationOptions{KeyPrefix: "messages[3]."}}
+//
+//	DispatchEnvelopeWithOptions([]byte(`{"type": "chat.unknown"}`), routes, options).Validations
+//	// -> {"messages[3]._": ["UNREGISTERED_MODEL"]}
+func DispatchEnvelopeWithOptions(data []byte, routes map[string]EnvelopeRoute, options PayloadValidationOptions) EnvelopeResult {
+	options = options.resolved()
+
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil || e.Type == "" {
+		return EnvelopeResult{Validations: map[string][]string{options.KeyPrefix + "_": {options.decodingError("invalid_payload")}}}
+	}
+
+	route, ok := routes[e.Type]
+	if !ok {
+		return EnvelopeResult{Type: e.Type, Validations: map[string][]string{options.KeyPrefix + "_": {options.errorCode("unregistered")}}}
+	}
+
+	model := newModel(route.Model)
+
+	return EnvelopeResult{
+		Type:        e.Type,
+		Model:       model,
+		Validations: prefixKeys(ValidatePayload(e.Data, model, route.Options), options.KeyPrefix),
+	}
+}
+
+// Returns the model of the result as a `*T`.
+// The second value is `false` if the model is not of the given type.
+//
+// Usage:
+//
+//	message, ok := EnvelopeModel[ChatMessage](result)
+func EnvelopeModel[T any](result EnvelopeResult) (*T, bool) {
+	model, ok := result.Model.(*T)
+	return model, ok
+}
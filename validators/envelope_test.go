@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_DispatchEnvelope(t *testing.T) {
+	routes := map[string]EnvelopeRoute{
+		"contact": {Model: Contact{}},
+		"person":  {Model: &Person{}, Options: PayloadValidationOptions{ValidationOptions: ValidationOptions{Ignore: []string{"UUID"}}}},
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want EnvelopeResult
+	}{
+		{
+			name: "contact - 1",
+			data: `{"type": "contact", "data": {"emails": ["leo"]}}`,
+			want: EnvelopeResult{
+				Type:        "contact",
+				Model:       &Contact{Emails: []string{"leo"}},
+				Validations: map[string][]string{"emails[0]": {"INVALID_FORMAT"}},
+			},
+		},
+		{
+			name: "person - 1",
+			data: `{"type": "person", "data": {"name": "Leonardo", "contact": {"emails": ["leo@example.com"]}}}`,
+			want: EnvelopeResult{
+				Type:        "person",
+				Model:       &Person{Name: "Leonardo", Contact: Contact{Emails: []string{"leo@example.com"}}},
+				Validations: map[string][]string{},
+			},
+		},
+		{
+			name: "unregistered - 1",
+			data: `{"type": "account", "data": {}}`,
+			want: EnvelopeResult{Type: "account", Validations: map[string][]string{"_": {"UNREGISTERED_MODEL"}}},
+		},
+		{
+			name: "malformed - 1",
+			data: `{"data": {}}`,
+			want: EnvelopeResult{Validations: map[string][]string{"_": {"INVALID_PAYLOAD"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DispatchEnvelope([]byte(tt.data), routes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DispatchEnvelope() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DispatchEnvelopeWithOptions(t *testing.T) {
+	routes := map[string]EnvelopeRoute{
+		"contact": {Model: Contact{}},
+		"person":  {Model: &Person{}, Options: PayloadValidationOptions{ValidationOptions: ValidationOptions{KeyPrefix: "person."}}},
+	}
+
+	options := PayloadValidationOptions{
+		ValidationOptions: ValidationOptions{KeyPrefix: "messages[3]."},
+		ErrorCodes:        map[string]string{"invalid_payload": "BAD_ENVELOPE", "unregistered": "UNKNOWN_TYPE"},
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want map[string][]string
+	}{
+		{
+			name: "data",
+			data: `{"type": "contact", "data": {"emails": ["leo"]}}`,
+			want: map[string][]string{"messages[3].emails[0]": {"INVALID_FORMAT"}},
+		},
+		{
+			name: "prefix of the route",
+			data: `{"type": "person", "data": {"name": "L"}}`,
+			want: map[string][]string{
+				"messages[3].person.id":             {"INVALID_FORMAT"},
+				"messages[3].person.name":           {"INVALID_LENGTH"},
+				"messages[3].person.contact.emails": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "unregistered",
+			data: `{"type": "account", "data": {}}`,
+			want: map[string][]string{"messages[3]._": {"UNKNOWN_TYPE"}},
+		},
+		{
+			name: "malformed",
+			data: `{"data": {}}`,
+			want: map[string][]string{"messages[3]._": {"BAD_ENVELOPE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DispatchEnvelopeWithOptions([]byte(tt.data), routes, options).Validations; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DispatchEnvelopeWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_EnvelopeModel(t *testing.T) {
+	result := EnvelopeResult{Model: &Contact{IsActive: true}}
+
+	if contact, ok := EnvelopeModel[Contact](result); !ok || !contact.IsActive {
+		t.Errorf("EnvelopeModel() = %v, %v, want contact", contact, ok)
+	}
+
+	if _, ok := EnvelopeModel[Person](result); ok {
+		t.Errorf("EnvelopeModel() = _, %v, want false", ok)
+	}
+}
@@ -0,0 +1,365 @@
+package validators
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oleoneto/go-structs/structs"
+)
+
+var (
+	// Sample values used for string fields with a format rule.
+	exampleFormats = map[string]string{
+		BASE64URL:  "eyJpZCI6MX0",
+		CIDR:       "192.0.2.0/24",
+		CREDITCARD: "4111111111111111",
+		CURRENCY:   "USD",
+		DATETIME:   "2006-01-02T15:04:05Z",
+		E164:       "+14155552671",
+		EMAIL:      "user@example.com",
+		IBAN:       "GB82WEST12345698765432",
+		IP:         "192.0.2.1",
+		IPV4:       "192.0.2.1",
+		IPV6:       "2001:db8::1",
+		KSUID:      "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+		MAC:        "00:00:5e:00:53:01",
+		PHONE:      "+14155552671",
+		PRECISION:  "1",
+		SCALE:      "1",
+		ULID:       "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		URL:        "https://example.com",
+		UUID:       "2b852002-f19d-11ec-8ea0-0242ac120002",
+		UUID4:      "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		UUID7:      "01890a5d-ac96-774b-bcce-b302099a8057",
+	}
+
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// Generates a sample JSON payload for the given model (a struct or a pointer to one) based on its tags.
+// Every field is included and, whenever possible, the generated values pass the validation rules of their fields:
+//   - values of the `example` tag are used as they are (see `structs.EXAMPLE_TAG_KEYWORD`).
+//   - fields with a `jsonschema` enum (i.e. `jsonschema:"enum=ADMIN,enum=GUEST"`) get its first value.
+//   - fields with an `in` rule get the first accepted value.
+//   - fields with a format rule (i.e. `uuid`, `email`, `datetime`) get a value in that format.
+//   - lengths and numbers respect the `eq`, `min`, `max`, `len` and `range` rules, numeric strings included.
+//
+// Values for `regex` rules are not generated.
+//
+// Usage:
+//
+//	type Person struct {
+//		Id     string   `json:"id" validate:"uuid"`
+//		Role   string   `json:"role" validate:"in=ADMIN|GUEST"`
+//		Emails []string `json:"emails" validate:"min=1,each:email"`
+//	}
+//
+//	ExampleJSON(Person{})
+//	// -> {"emails": ["user@example.com"], "id": "2b852002-f19d-11ec-8ea0-0242ac120002", "role": "ADMIN"}
+func ExampleJSON(model any) ([]byte, error) {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil, structs.ErrInvalidModel
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, structs.ErrInvalidModel
+	}
+
+	return json.MarshalIndent(exampleValue(t, nil, "", map[reflect.Type]bool{}), "", "  ")
+}
+
+// Returns a sample value of the given type satisfying the provided rules.
+func exampleValue(t reflect.Type, rules []string, example string, visiting map[reflect.Type]bool) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if example != "" {
+		if value, ok := parseExample(t, example); ok {
+			return value
+		}
+	}
+
+	rules = ValidationOptions{}.expandRules(rules)
+
+	ruleValues := map[string]string{}
+	for _, rule := range rules {
+		name, value := ruleParts(rule)
+		ruleValues[name] = value
+	}
+
+	// Values of providers are resolved using the default providers
+	if accepted, ok := ruleValues[IN]; ok && isValueProvider(accepted) {
+		if values, ok := (ValidationOptions{}).acceptedValues(accepted); ok && len(values) != 0 {
+			ruleValues[IN] = values[0]
+		} else {
+			delete(ruleValues, IN)
+		}
+	}
+
+	switch t {
+	case timeType:
+		return exampleFormats[DATETIME]
+	case uuidType:
+		return exampleFormats[UUID]
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return exampleObject(t, visiting)
+	case reflect.Map:
+		return map[string]any{}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			// Encoded as base64 strings
+			return ""
+		}
+
+		length := 1
+		if t.Kind() == reflect.Array {
+			length = t.Len()
+		} else if n, ok := exampleNumber(ruleValues, 1); ok {
+			length = int(n)
+		}
+
+		rules := elementRules(t.Elem(), rules)
+
+		list := make([]any, 0, length)
+		for i := 0; i < length; i++ {
+			list = append(list, exampleValue(t.Elem(), rules, "", visiting))
+		}
+
+		return list
+	case reflect.String:
+		if accepted, ok := ruleValues[IN]; ok {
+			return strings.Split(accepted, "|")[0]
+		}
+
+		if value, ok := exampleNumericString(ruleValues); ok {
+			return value
+		}
+
+		if schemes, ok := ruleValues[URL]; ok && schemes != "" {
+			return strings.Split(schemes, "|")[0] + "://example.com"
+		}
+
+		for _, rule := range rules {
+			name, _ := ruleParts(rule)
+			if value, ok := exampleFormats[name]; ok {
+				return value
+			}
+		}
+
+		if length, ok := exampleNumber(ruleValues, float64(len("string"))); ok {
+			return strings.Repeat("a", int(length))
+		}
+
+		return "string"
+	case reflect.Bool:
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if accepted, ok := ruleValues[IN]; ok {
+			if n, err := strconv.ParseInt(strings.Split(accepted, "|")[0], 10, 64); err == nil {
+				return n
+			}
+		}
+
+		n, _ := exampleNumber(ruleValues, 1)
+		return int64(math.Ceil(n))
+	case reflect.Float32, reflect.Float64:
+		if accepted, ok := ruleValues[IN]; ok {
+			if n, err := strconv.ParseFloat(strings.Split(accepted, "|")[0], 64); err == nil {
+				return n
+			}
+		}
+
+		n, _ := exampleNumber(ruleValues, 1)
+		return n
+	}
+
+	return nil
+}
+
+func exampleObject(t reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	object := map[string]any{}
+
+	// Recursive types are only expanded once
+	if visiting[t] {
+		return object
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for position := 0; position < t.NumField(); position++ {
+		sf := t.Field(position)
+
+		if sf.Anonymous {
+			ft := sf.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() == reflect.Struct {
+				for k, v := range exampleObject(ft, visiting) {
+					object[k] = v
+				}
+
+				continue
+			}
+		}
+
+		name := structs.GetJSONTagValue(sf)
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+
+		rules := []string{}
+		if _, ok := sf.Tag.Lookup(VALIDATION_TAG_KEYWORD); ok {
+			rules = structs.GetTagRules(sf, VALIDATION_TAG_KEYWORD)
+		}
+
+		example := sf.Tag.Get(structs.EXAMPLE_TAG_KEYWORD)
+		if enum := jsonSchemaTagValues(sf)["enum"]; example == "" && len(enum) != 0 {
+			example = enum[0]
+		}
+
+		object[name] = exampleValue(sf.Type, rules, example, visiting)
+	}
+
+	return object
+}
+
+// Returns a number satisfying the `eq`, `min` and `max` rules, or the fallback if none of them are set.
+// The second value is `false` if the fallback is returned.
+func exampleNumber(ruleValues map[string]string, fallback float64) (float64, bool) {
+	if n, err := strconv.ParseFloat(ruleValues[EQUAL], 64); err == nil {
+		return n, true
+	}
+
+	if n, err := strconv.ParseFloat(ruleValues[MIN], 64); err == nil && n > fallback {
+		return n, true
+	}
+
+	if n, err := strconv.ParseFloat(ruleValues[MAX], 64); err == nil && n < fallback {
+		return n, true
+	}
+
+	return fallback, false
+}
+
+// Returns a string satisfying the `digits`, `intstr` or `floatstr` rules, including their ranges.
+// Strings are padded (with leading zeros for numbers) to the lengths required by the `eq`, `min` and `max` rules.
+// The second value is `false` if none of these rules are set.
+func exampleNumericString(ruleValues map[string]string) (string, bool) {
+	length, _ := exampleNumber(ruleValues, 0)
+
+	for _, rule := range []string{DIGITS, INTSTR, FLOATSTR} {
+		value, ok := ruleValues[rule]
+		if !ok {
+			continue
+		}
+
+		bounds, _ := parseRange(value)
+
+		n := 1.0
+		if bounds.hasMin {
+			n = bounds.min
+		} else if bounds.hasMax && bounds.max < n {
+			n = bounds.max
+		}
+
+		switch rule {
+		case DIGITS:
+			return strings.Repeat("1", int(math.Max(length, math.Max(1, math.Ceil(n))))), true
+		case INTSTR:
+			return padNumber(strconv.FormatInt(int64(math.Ceil(n)), 10), int(length)), true
+		}
+
+		return padNumber(strconv.FormatFloat(n, 'f', -1, 64), int(length)), true
+	}
+
+	return "", false
+}
+
+// Pads a number with leading zeros (after its sign) to the given length.
+func padNumber(number string, length int) string {
+	digits := strings.TrimPrefix(number, "-")
+	if len(number) >= length {
+		return number
+	}
+
+	return number[:len(number)-len(digits)] + strings.Repeat("0", length-len(number)) + digits
+}
+
+// Returns the rules that apply to the elements (of type `elem`) of a slice/array. See `structs.EACH_RULE_PREFIX`.
+//
+// As in `structs.GetAttributes`, the rows of nested slices/arrays (i.e. `[][]float64`) have no rules of their own:
+// the element rules are passed down to the innermost elements.
+func elementRules(elem reflect.Type, rules []string) []string {
+	if isNestedListType(elem) {
+		return structs.Map(elementRules(nil, rules), func(_ int, rule string) string { return structs.EACH_RULE_PREFIX + rule })
+	}
+
+	eachRules := []string{}
+	inheritedRules := []string{}
+
+	for _, rule := range rules {
+		if strings.HasPrefix(rule, structs.EACH_RULE_PREFIX) {
+			eachRules = append(eachRules, strings.TrimPrefix(rule, structs.EACH_RULE_PREFIX))
+			continue
+		}
+
+		name, _ := ruleParts(rule)
+		if !structs.Contains(structs.NON_INHERITABLE_TAG_ATTRIBUTES, name) {
+			inheritedRules = append(inheritedRules, rule)
+		}
+	}
+
+	if len(eachRules) != 0 {
+		return eachRules
+	}
+
+	return inheritedRules
+}
+
+// Reports whether the type is a slice/array of a list type other than UUIDs and byte slices.
+func isNestedListType(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	t = baseType(t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return t != uuidType && t.Elem().Kind() != reflect.Uint8
+	}
+
+	return false
+}
+
+// Converts the value of the `example` tag to the given type.
+func parseExample(t reflect.Type, example string) (any, bool) {
+	if t.Kind() == reflect.String {
+		return example, true
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(example), &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,83 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ExampleJSON(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"min=8"`
+		Zip    string `json:"zip" validate:"eq=5"`
+		Pin    string `json:"pin" validate:"digits=4..6"`
+		Number string `json:"number" validate:"intstr=10.."`
+		Code   string `json:"code" validate:"digits,len=4"`
+		Serial string `json:"serial" validate:"intstr=-5..,min=4"`
+		Amount string `json:"amount" validate:"floatstr=1..,len=5"`
+	}
+
+	type Account struct {
+		Identifiable
+		Role      string    `json:"role" validate:"in=ADMIN|GUEST"`
+		Kind      string    `json:"kind" jsonschema:"enum=USER,enum=BOT"`
+		Priority  int       `json:"priority" jsonschema:"enum=2,enum=4"`
+		Name      string    `json:"name" example:"Leonardo"`
+		Level     int       `json:"level" validate:"min=3,max=10"`
+		Ratio     *float64  `json:"ratio" validate:"max=0.5"`
+		Active    bool      `json:"active"`
+		Emails    []string  `json:"emails" validate:"min=2,each:email"`
+		Websites  []string  `json:"websites" validate:"url"`
+		Callback  string    `json:"callback" validate:"url=wss|https"`
+		Addresses []Address `json:"addresses"`
+		Settings  string    `json:"-"`
+		Tags      []string  `json:"tags" example:"[\"a\", \"b\"]"`
+		CreatedAt time.Time `json:"created_at"`
+		Parent    *Account  `json:"parent"`
+	}
+
+	data, err := ExampleJSON(&Account{})
+	if err != nil {
+		t.Fatalf("ExampleJSON() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("ExampleJSON() returned invalid JSON: %v", err)
+	}
+
+	want := map[string]any{
+		"id":         "2b852002-f19d-11ec-8ea0-0242ac120002",
+		"role":       "ADMIN",
+		"kind":       "USER",
+		"priority":   float64(2),
+		"name":       "Leonardo",
+		"level":      float64(3),
+		"ratio":      0.5,
+		"active":     true,
+		"emails":     []any{"user@example.com", "user@example.com"},
+		"websites":   []any{"https://example.com"},
+		"callback":   "wss://example.com",
+		"addresses":  []any{map[string]any{"street": "aaaaaaaa", "zip": "aaaaa", "pin": "1111", "number": "10", "code": "1111", "serial": "-005", "amount": "00001"}},
+		"tags":       []any{"a", "b"},
+		"created_at": "2006-01-02T15:04:05Z",
+		"parent":     map[string]any{},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExampleJSON() = %v, want %v", got, want)
+	}
+
+	// The generated payload must be valid
+	var account Account
+	if errs := ValidatePayload(data, &account, PayloadValidationOptions{}); len(errs) != 0 {
+		t.Errorf("ValidatePayload(ExampleJSON()) = %v, want no errors", errs)
+	}
+
+	if _, err := ExampleJSON("account"); err != structs.ErrInvalidModel {
+		t.Errorf("ExampleJSON() error = %v, want %v", err, structs.ErrInvalidModel)
+	}
+}
@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// A validation error in the format of the errors returned by GraphQL servers.
+// Its JSON representation is compatible with gqlgen's `gqlerror.Error`.
+type GraphQLError struct {
+	Message string `json:"message"`
+
+	// The location of the offending value. Elements are either field names (string) or list positions (int).
+	Path []any `json:"path,omitempty"`
+
+	// Contains the error `code` and the `field` path (see `structs.StructAttribute.FullName()`).
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Populates the given struct pointer with the provided values (see `structs.SetValuesFromMap`) and validates it.
+// Values that cannot be converted to the types of their fields are reported as `INVALID_TYPE`.
+// The provided map is not modified.
+//
+// Usage:
+//
+//	var p Person
+//	errs := ValidateMap(map[string]any{"name": "L"}, &p, ValidationOptions{}) // -> {name: ["INVALID_LENGTH"]}
+func ValidateMap(values map[string]any, model any, options ValidationOptions) map[string][]string {
+	copied := make(map[string]any, len(values))
+	for k, v := range values {
+		copied[k] = v
+	}
+
+	if _, err := structs.SetValuesFromMap(model, copied); err != nil {
+		return map[string][]string{options.KeyPrefix + "_": {options.errorCode("model")}}
+	}
+
+	return structs.MergeValidations(prefixKeys(mapTypeErrors(values, model, options), options.KeyPrefix), Validate(model, options))
+}
+
+// Returns the values that cannot be converted to the types of their fields, as reported by the decoder.
+// The values are decoded into a new value of the model, so that the model itself is populated by `structs.SetValuesFromMap` alone.
+func mapTypeErrors(values map[string]any, model any, options ValidationOptions) map[string][]string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return map[string][]string{}
+	}
+
+	decoder := structs.DecoderOptions{
+		Rules:      []structs.SchemaValidationRule{structs.INVALID_TYPE},
+		ErrorCodes: map[string]string{string(structs.INVALID_TYPE): options.errorCode("type")},
+	}
+
+	return structs.Decode(data, reflect.New(reflect.TypeOf(model).Elem()).Interface(), decoder)
+}
+
+// Validates the input object passed as an argument to a GraphQL resolver against the rules of the given struct.
+// This allows resolvers to share the same validation rules as REST handlers.
+//
+// Each error code is reported as a separate error, whose path starts with the name of the argument.
+// Errors are sorted by path.
+//
+// Usage:
+//
+//	func (r *mutationResolver) CreateUser(ctx context.Context, input map[string]any) (*User, error) {
+//		var user User
+//		for _, err := range ValidateGraphQLInput(input, "input", &user, ValidationOptions{}) {
+//			graphql.AddError(ctx, &gqlerror.Error{Message: err.Message, Path: ..., Extensions: err.Extensions})
+//		}
+//		...
+//	}
+func ValidateGraphQLInput(args map[string]any, argument string, model any, options ValidationOptions) []GraphQLError {
+	validations := ValidateMap(args, model, options)
+
+	keys := make([]string, 0, len(validations))
+	for k := range validations {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	errs := []GraphQLError{}
+	for _, key := range keys {
+		path := []any{}
+		if argument != "" {
+			path = append(path, argument)
+		}
+
+		if key != options.KeyPrefix+"_" {
+			for _, segment := range structs.ParsePath(key) {
+				if segment.IsIndex {
+					path = append(path, segment.Index)
+					continue
+				}
+
+				path = append(path, segment.Name)
+			}
+		}
+
+		for _, code := range validations[key] {
+			errs = append(errs, GraphQLError{
+				Message:    key + ": " + code,
+				Path:       path,
+				Extensions: map[string]any{"code": code, "field": key},
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,104 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ValidateMap(t *testing.T) {
+	values := map[string]any{"name": "L", "contact": map[string]any{"emails": []any{"leo"}}}
+
+	var person Person
+	got := ValidateMap(values, &person, ValidationOptions{})
+
+	want := map[string][]string{
+		"id":                {"INVALID_FORMAT"},
+		"name":              {"INVALID_LENGTH"},
+		"contact.emails[0]": {"INVALID_FORMAT"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateMap() = %v, want %v", got, want)
+	}
+
+	if len(values) != 2 {
+		t.Errorf("ValidateMap() modified the provided values: %v", values)
+	}
+
+	if got := ValidateMap(values, person, ValidationOptions{}); !reflect.DeepEqual(got, map[string][]string{"_": {"INVALID_MODEL"}}) {
+		t.Errorf("ValidateMap() = %v, want INVALID_MODEL", got)
+	}
+
+	options := ValidationOptions{ErrorCodes: map[string]string{"model": "BAD_MODEL"}}
+	if got := ValidateMap(values, person, options); !reflect.DeepEqual(got, map[string][]string{"_": {"BAD_MODEL"}}) {
+		t.Errorf("ValidateMap() = %v, want BAD_MODEL", got)
+	}
+}
+
+func Test_ValidateMap_InvalidTypes(t *testing.T) {
+	type Member struct {
+		Name  string   `json:"name" validate:"min=2"`
+		Age   int      `json:"age"`
+		Teams []string `json:"teams"`
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:   "convertible values",
+			values: map[string]any{"name": "Leo", "age": 30, "teams": []string{"core"}},
+			want:   map[string][]string{},
+		},
+		{
+			name:   "values that cannot be converted",
+			values: map[string]any{"name": "Leo", "age": "x", "teams": []any{"core", 1}},
+			want:   map[string][]string{"age": {"INVALID_TYPE"}, "teams": {"INVALID_TYPE"}},
+		},
+		{
+			name:    "custom codes and prefixes",
+			values:  map[string]any{"name": 1},
+			options: ValidationOptions{KeyPrefix: "member.", ErrorCodes: map[string]string{"type": "BAD_TYPE"}},
+			want:    map[string][]string{"member.name": {"BAD_TYPE", "INVALID_LENGTH"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var member Member
+			if got := ValidateMap(tt.values, &member, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidateGraphQLInput(t *testing.T) {
+	args := map[string]any{
+		"id":      "2b852002-f19d-11ec-8ea0-0242ac120002",
+		"name":    "Leonardo Ribeiro",
+		"contact": map[string]any{"emails": []any{"leo@example.com", "leo"}},
+	}
+
+	var person Person
+	got := ValidateGraphQLInput(args, "input", &person, ValidationOptions{})
+
+	want := []GraphQLError{
+		{
+			Message:    "contact.emails[1]: INVALID_FORMAT",
+			Path:       []any{"input", "contact", "emails", 1},
+			Extensions: map[string]any{"code": "INVALID_FORMAT", "field": "contact.emails[1]"},
+		},
+		{
+			Message:    "name: INVALID_LENGTH",
+			Path:       []any{"input", "name"},
+			Extensions: map[string]any{"code": "INVALID_LENGTH", "field": "name"},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateGraphQLInput() = %+v, want %+v", got, want)
+	}
+}
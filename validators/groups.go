@@ -0,0 +1,344 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+type (
+	// Errors found in an attribute by a rule that depends on other fields of the same struct.
+	fieldFailure struct {
+		attribute structs.StructAttribute
+		rule      string
+		ruleValue string
+		errs      []string
+	}
+
+	// Fields of the same struct sharing a group rule (see `AT_LEAST_ONE_OF` and `EXACTLY_ONE_OF`).
+	fieldGroup struct {
+		rule       string
+		name       string
+		attributes []structs.StructAttribute
+	}
+)
+
+// Checks the rules that depend on other fields of the same struct:
+// the group rules, followed by the rules referencing sibling fields (i.e. `eqfield` and `required_with`).
+// Only the first of the latter to fail is reported for each attribute.
+func crossFieldFailures(attributes structs.StructAttributes, options ValidationOptions) (failures []fieldFailure) {
+	for _, group := range fieldGroups(attributes, options) {
+		errs := group.validate(options)
+		if len(errs) == 0 {
+			continue
+		}
+
+		for _, attr := range group.attributes {
+			failures = append(failures, fieldFailure{attribute: attr, rule: group.rule, ruleValue: group.name, errs: errs})
+		}
+	}
+
+	// Siblings keyed by the path of the struct containing them, then by their JSON and Go names
+	siblings := map[string]map[string]structs.StructAttribute{}
+	for _, attr := range attributes {
+		scope := attributeScope(attr)
+		if siblings[scope] == nil {
+			siblings[scope] = map[string]structs.StructAttribute{}
+		}
+
+		siblings[scope][attr.Field.Name] = attr
+		siblings[scope][structs.GetJSONTagValue(attr.Field)] = attr
+	}
+
+	for _, attr := range attributes {
+		for _, rule := range options.crossFieldRules(attr, EQFIELD, GTFIELD, REQUIRED_IF, REQUIRED_WITH, REQUIRED_WITHOUT, SAME_DAY_AS) {
+			name, value := rule.name, rule.value
+
+			var errs []string
+			switch name {
+			case EQFIELD, GTFIELD:
+				errs = options.compareFields(attr, name, value, siblings[attributeScope(attr)])
+			case REQUIRED_IF:
+				errs = options.requiredIf(attr, value, siblings[attributeScope(attr)])
+			case SAME_DAY_AS:
+				errs = options.sameDay(attr, value, siblings[attributeScope(attr)])
+			default:
+				errs = options.requiredWith(attr, name, value, siblings[attributeScope(attr)])
+			}
+
+			if len(errs) != 0 {
+				failures = append(failures, fieldFailure{attribute: attr, rule: name, ruleValue: value, errs: errs})
+				break
+			}
+		}
+	}
+
+	return failures
+}
+
+// Checks `required_with` and `required_without`, given the siblings of the attribute.
+func (options ValidationOptions) requiredWith(attr structs.StructAttribute, rule string, fields string, siblings map[string]structs.StructAttribute) []string {
+	required := false
+	for _, field := range strings.Split(fields, "|") {
+		sibling, ok := siblings[field]
+		isSet := ok && options.isSet(sibling)
+
+		if (rule == REQUIRED_WITH && isSet) || (rule == REQUIRED_WITHOUT && !isSet) {
+			required = true
+		}
+	}
+
+	if required && !options.isSet(attr) {
+		return []string{options.errorCode("required")}
+	}
+
+	return nil
+}
+
+// Checks `required_if`, given the siblings of the attribute.
+// The rule value holds pairs of fields and values, as in `Country US Type company`.
+func (options ValidationOptions) requiredIf(attr structs.StructAttribute, conditions string, siblings map[string]structs.StructAttribute) []string {
+	pairs := strings.Fields(conditions)
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return []string{options.errorCode("unexpected")}
+	}
+
+	for i := 0; i < len(pairs); i += 2 {
+		sibling, ok := siblings[pairs[i]]
+		if !ok {
+			return []string{options.errorCode("unexpected")}
+		}
+
+		v, err := structs.PointerElement(sibling.Value)
+		if err != nil || !v.IsValid() || v.Kind() == reflect.Pointer || !v.CanInterface() || fmt.Sprint(v.Interface()) != pairs[i+1] {
+			return nil
+		}
+	}
+
+	if !options.isSet(attr) {
+		return []string{options.errorCode("required")}
+	}
+
+	return nil
+}
+
+// Checks `eqfield` and `gtfield`, given the siblings of the attribute.
+func (options ValidationOptions) compareFields(attr structs.StructAttribute, rule string, field string, siblings map[string]structs.StructAttribute) []string {
+	sibling, ok := siblings[field]
+	if !ok {
+		return []string{options.errorCode("unexpected")}
+	}
+
+	a, _ := structs.PointerElement(attr.Value)
+	b, _ := structs.PointerElement(sibling.Value)
+
+	if rule == EQFIELD {
+		if !fieldsEqual(a, b) {
+			return []string{options.errorCode("mismatch")}
+		}
+
+		return nil
+	}
+
+	if !options.isSet(attr) || !options.isSet(sibling) {
+		return nil
+	}
+
+	greater, ok := fieldGreater(a, b)
+	switch {
+	case !ok:
+		return []string{options.errorCode("type")}
+	case !greater:
+		return []string{options.errorCode("value")}
+	}
+
+	return nil
+}
+
+// Checks `same_day_as`, given the siblings of the attribute.
+func (options ValidationOptions) sameDay(attr structs.StructAttribute, field string, siblings map[string]structs.StructAttribute) []string {
+	sibling, ok := siblings[field]
+	if !ok {
+		return []string{options.errorCode("unexpected")}
+	}
+
+	if !options.isSet(attr) || !options.isSet(sibling) {
+		return nil
+	}
+
+	a, _ := structs.PointerElement(attr.Value)
+	b, _ := structs.PointerElement(sibling.Value)
+	if !a.IsValid() || !b.IsValid() || a.Kind() == reflect.Pointer || b.Kind() == reflect.Pointer {
+		return nil
+	}
+
+	at, aValid, aIsTime := timeValue(a, options.location())
+	bt, bValid, bIsTime := timeValue(b, options.location())
+	switch {
+	case !aIsTime || !bIsTime:
+		return []string{options.errorCode("type")}
+	case !aValid || !bValid:
+		return []string{options.errorCode("format")}
+	case !IsSameDay(at, bt, options.location()):
+		return []string{options.errorCode("mismatch")}
+	}
+
+	return nil
+}
+
+// Reports whether two (dereferenced) values are equal. Nil pointers are only equal to each other.
+func fieldsEqual(a, b reflect.Value) bool {
+	aIsNil := !a.IsValid() || a.Kind() == reflect.Pointer
+	bIsNil := !b.IsValid() || b.Kind() == reflect.Pointer
+	if aIsNil || bIsNil {
+		return aIsNil && bIsNil
+	}
+
+	if !a.CanInterface() || !b.CanInterface() {
+		return false
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// Reports whether the first value is greater than the second, and whether they could be compared at all.
+// Numbers and times are compared by value, strings, slices and maps by length.
+func fieldGreater(a, b reflect.Value) (greater bool, ok bool) {
+	if !a.IsValid() || !b.IsValid() || !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+
+	if at, ok := a.Interface().(time.Time); ok {
+		bt, ok := b.Interface().(time.Time)
+		return ok && at.After(bt), ok
+	}
+
+	if x, ok := numericValue(a); ok {
+		y, ok := numericValue(b)
+		return ok && x > y, ok
+	}
+
+	switch a.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		switch b.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+			return a.Len() > b.Len(), true
+		}
+	}
+
+	return false, false
+}
+
+// Returns the value of a number as a float64.
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
+
+// Collects the groups declared by the attributes, in the order they are first found.
+// Groups are scoped to the struct containing their fields, so the same group name
+// used in different structs (or in different elements of a slice) results in separate groups.
+func fieldGroups(attributes structs.StructAttributes, options ValidationOptions) []*fieldGroup {
+	groups := []*fieldGroup{}
+	byKey := map[string]*fieldGroup{}
+
+	for _, attr := range attributes {
+		for _, rule := range options.crossFieldRules(attr, AT_LEAST_ONE_OF, EXACTLY_ONE_OF) {
+			name, group := rule.name, rule.value
+
+			key := strings.Join([]string{attributeScope(attr), name, group}, "\x00")
+			if _, ok := byKey[key]; !ok {
+				byKey[key] = &fieldGroup{rule: name, name: group}
+				groups = append(groups, byKey[key])
+			}
+
+			byKey[key].attributes = append(byKey[key].attributes, attr)
+		}
+	}
+
+	return groups
+}
+
+// Returns the errors that should be reported for every field in the group.
+func (g *fieldGroup) validate(options ValidationOptions) []string {
+	set := 0
+	for _, attr := range g.attributes {
+		if options.isSet(attr) {
+			set++
+		}
+	}
+
+	switch {
+	case set == 0:
+		return []string{options.errorCode("missing_one")}
+	case set > 1 && g.rule == EXACTLY_ONE_OF:
+		return []string{options.errorCode("exclusive")}
+	}
+
+	return nil
+}
+
+// Returns the rules of the attribute matching the given names, once aliases are resolved.
+// The returned rules use the canonical names. Rules listed in `SkipRules` are left out.
+func (options ValidationOptions) crossFieldRules(attr structs.StructAttribute, names ...string) (rules []compiledRule) {
+	for _, rule := range options.program(attr.Field) {
+		if structs.Contains(options.SkipRules, rule.name) {
+			continue
+		}
+
+		name := options.canonicalRule(rule.name)
+		if !structs.Contains(names, name) || structs.Contains(options.SkipRules, name) {
+			continue
+		}
+
+		rules = append(rules, compiledRule{raw: rule.raw, name: name, value: rule.value})
+	}
+
+	return rules
+}
+
+// Reports whether the attribute is set: present in the payload if `Present` is set,
+// otherwise a non-nil pointer or a value that is neither a zero value nor an empty slice/map.
+func (options ValidationOptions) isSet(attr structs.StructAttribute) bool {
+	if options.Present != nil {
+		return structs.Contains(options.Present, attr.FullName())
+	}
+
+	v := attr.Value
+	if !v.IsValid() {
+		return false
+	}
+
+	// Non-nil pointers are dereferenced by `structs.GetAttributes`
+	if v.Kind() == reflect.Pointer {
+		return !v.IsNil()
+	}
+
+	// As with pointers, nullable wrappers (i.e. `sql.NullString` or `structs.Nullable`) holding a zero value are set
+	if attr.Field.Type.Kind() == reflect.Pointer || structs.IsNullableWrapper(attr.Field.Type) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	}
+
+	return !v.IsZero()
+}
+
+// Returns the path of the struct containing the attribute.
+func attributeScope(attr structs.StructAttribute) string {
+	return strings.TrimSuffix(attr.FullName(), structs.GetJSONTagValue(attr.Field))
+}
@@ -0,0 +1,292 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Validate_FieldGroups(t *testing.T) {
+	type Card struct {
+		Number string `json:"number"`
+	}
+
+	type Payment struct {
+		Card   *Card    `json:"card" validate:"exactly_one_of=method"`
+		Bank   string   `json:"bank" validate:"exactly_one_of=method"`
+		Wallet []string `json:"wallet" validate:"exactly_one_of=method"`
+	}
+
+	type Order struct {
+		Email    string    `json:"email" validate:"at_least_one_of=contact"`
+		Phone    *string   `json:"phone" validate:"at_least_one_of=contact"`
+		Payments []Payment `json:"payments"`
+	}
+
+	phone := "555-0100"
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Order{Phone: &phone, Payments: []Payment{{Bank: "ACME"}, {Card: &Card{}}}},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "none set",
+			model:   Order{Payments: []Payment{{Bank: "ACME"}, {Wallet: []string{}}}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"email":              {"MISSING_ONE_OF"},
+				"phone":              {"MISSING_ONE_OF"},
+				"payments[1].card":   {"MISSING_ONE_OF"},
+				"payments[1].bank":   {"MISSING_ONE_OF"},
+				"payments[1].wallet": {"MISSING_ONE_OF"},
+			},
+		},
+		{
+			name:    "more than one set",
+			model:   Order{Email: "leo@example.com", Phone: &phone, Payments: []Payment{{Bank: "ACME", Wallet: []string{"x"}}}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"payments[0].card":   {"MUTUALLY_EXCLUSIVE"},
+				"payments[0].bank":   {"MUTUALLY_EXCLUSIVE"},
+				"payments[0].wallet": {"MUTUALLY_EXCLUSIVE"},
+			},
+		},
+		{
+			name:    "skipped",
+			model:   Order{Email: "leo@example.com", Payments: []Payment{{}}},
+			options: ValidationOptions{SkipRules: []string{EXACTLY_ONE_OF, AT_LEAST_ONE_OF}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_RequiredWith(t *testing.T) {
+	type Checkout struct {
+		CardNumber string `json:"card_number"`
+		Cvv        string `json:"cvv" validate:"required_with=CardNumber"`
+		Email      string `json:"email"`
+		Phone      *int   `json:"phone" validate:"required_without=email|EmailAddress"`
+	}
+
+	phone := 0
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Checkout{CardNumber: "4111", Cvv: "123", Email: "leo@example.com", Phone: &phone},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "missing",
+			model:   Checkout{CardNumber: "4111", Email: "leo@example.com"},
+			options: ValidationOptions{},
+			want:    map[string][]string{"cvv": {"REQUIRED_ATTRIBUTE_MISSING"}, "phone": {"REQUIRED_ATTRIBUTE_MISSING"}},
+		},
+		{
+			name:    "present",
+			model:   Checkout{},
+			options: ValidationOptions{Present: []string{"card_number", "cvv"}},
+			want:    map[string][]string{"phone": {"REQUIRED_ATTRIBUTE_MISSING"}},
+		},
+		{
+			name:    "skipped",
+			model:   Checkout{CardNumber: "4111"},
+			options: ValidationOptions{SkipRules: []string{REQUIRED_WITH, REQUIRED_WITHOUT}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_FieldComparisons(t *testing.T) {
+	type Signup struct {
+		Password             string  `json:"password"`
+		PasswordConfirmation string  `json:"password_confirmation" validate:"eqfield=Password"`
+		MinAge               int     `json:"min_age"`
+		MaxAge               float64 `json:"max_age" validate:"gtfield=min_age"`
+		Code                 string  `json:"code" validate:"gtfield=min_age"`
+		Referrer             string  `json:"referrer" validate:"eqfield=inviter"`
+	}
+
+	type Booking struct {
+		StartsAt time.Time  `json:"starts_at"`
+		EndsAt   *time.Time `json:"ends_at" validate:"gtfield=StartsAt"`
+	}
+
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Booking{StartsAt: before, EndsAt: &now},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "unset fields are not compared",
+			model:   Booking{StartsAt: now},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "not greater",
+			model:   Booking{StartsAt: now, EndsAt: &before},
+			options: ValidationOptions{},
+			want:    map[string][]string{"ends_at": {"INVALID_VALUE"}},
+		},
+		{
+			name:    "mismatch",
+			model:   Signup{Password: "secret", PasswordConfirmation: "secrets", MinAge: 18, MaxAge: 12},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"password_confirmation": {"FIELD_MISMATCH"},
+				"max_age":               {"INVALID_VALUE"},
+				"referrer":              {"UNEXPECTED_ERROR"},
+			},
+		},
+		{
+			name:    "incomparable",
+			model:   Signup{Password: "secret", PasswordConfirmation: "secret", MinAge: 18, MaxAge: 21, Code: "x"},
+			options: ValidationOptions{SkipRules: []string{EQFIELD}},
+			want:    map[string][]string{"code": {"INVALID_TYPE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_RequiredIf(t *testing.T) {
+	type Address struct {
+		Country string `json:"country"`
+		Type    string `json:"type"`
+		State   string `json:"state" validate:"required_if=Country US"`
+		Vat     *int   `json:"vat" validate:"required_if=type company country DE"`
+	}
+
+	type Customer struct {
+		Addresses []Address `json:"addresses"`
+	}
+
+	vat := 123
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Customer{Addresses: []Address{{Country: "US", State: "NY"}, {Country: "DE", Type: "company", Vat: &vat}, {Country: "DE"}}},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "missing",
+			model:   Customer{Addresses: []Address{{Country: "US"}, {Country: "DE", Type: "company"}, {Country: "FR", Type: "company"}}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"addresses[0].state": {"REQUIRED_ATTRIBUTE_MISSING"},
+				"addresses[1].vat":   {"REQUIRED_ATTRIBUTE_MISSING"},
+			},
+		},
+		{
+			name:    "skipped",
+			model:   Customer{Addresses: []Address{{Country: "US"}}},
+			options: ValidationOptions{SkipRules: []string{REQUIRED_IF}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidatePayload_Presence(t *testing.T) {
+	type Settings struct {
+		Enabled *bool `json:"enabled"`
+		Limit   int   `json:"limit" validate:"required_with=enabled"`
+		Notify  bool  `json:"notify" validate:"at_least_one_of=channel"`
+		Email   bool  `json:"email" validate:"at_least_one_of=channel"`
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want map[string][]string
+	}{
+		{
+			name: "explicit zero values",
+			data: `{"enabled": false, "limit": 0, "notify": false}`,
+			want: map[string][]string{},
+		},
+		{
+			name: "missing values",
+			data: `{"enabled": false}`,
+			want: map[string][]string{
+				"limit":  {"REQUIRED_ATTRIBUTE_MISSING"},
+				"notify": {"MISSING_ONE_OF"},
+				"email":  {"MISSING_ONE_OF"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var populated []string
+
+			var settings Settings
+			options := PayloadValidationOptions{}
+			options.PopulateHook = func(paths []string) { populated = paths }
+
+			if got := ValidatePayload([]byte(tt.data), &settings, options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePayload() = %v, want %v", got, tt.want)
+			}
+
+			if len(populated) == 0 {
+				t.Errorf("ValidatePayload() did not call the PopulateHook")
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"strings"
+	"sync"
+)
+
+// The locale used when no translation is found for the requested one.
+const DEFAULT_LOCALE string = "en"
+
+var (
+	translationsMu sync.RWMutex
+
+	// Messages keyed by locale, then by error code.
+	translations = map[string]map[string]string{
+		"en": {
+			"IMMUTABLE_VALUE":            "cannot be changed",
+			"INVALID_FORMAT":             "has an invalid format",
+			"INVALID_LENGTH":             "has an invalid length",
+			"INVALID_TYPE":               "has an invalid type",
+			"INVALID_VALUE":              "has an invalid value",
+			"UNEXPECTED_ERROR":           "could not be validated",
+			"UNREGISTERED_MODEL":         "does not match any known model",
+			"MUTUALLY_EXCLUSIVE":         "cannot be set along with the other fields of its group",
+			"MISSING_ONE_OF":             "or one of the other fields of its group must be set",
+			"REQUIRED_ATTRIBUTE_MISSING": "is required",
+			"FIELD_MISMATCH":             "does not match {rule_value}",
+			"UNKNOWN_RULE":               "has an unknown validation rule: {rule}",
+			"ADDITIONAL_PROPERTY":        "is not allowed",
+			"INVALID_PAYLOAD":            "is not a valid payload",
+		},
+		"pt-BR": {
+			"IMMUTABLE_VALUE":            "não pode ser alterado",
+			"INVALID_FORMAT":             "tem um formato inválido",
+			"INVALID_LENGTH":             "tem um tamanho inválido",
+			"INVALID_TYPE":               "tem um tipo inválido",
+			"INVALID_VALUE":              "tem um valor inválido",
+			"UNEXPECTED_ERROR":           "não pôde ser validado",
+			"UNREGISTERED_MODEL":         "não corresponde a nenhum modelo conhecido",
+			"MUTUALLY_EXCLUSIVE":         "não pode ser informado junto com os outros campos do seu grupo",
+			"MISSING_ONE_OF":             "ou um dos outros campos do seu grupo deve ser informado",
+			"REQUIRED_ATTRIBUTE_MISSING": "é obrigatório",
+			"FIELD_MISMATCH":             "não confere com {rule_value}",
+			"UNKNOWN_RULE":               "tem uma regra de validação desconhecida: {rule}",
+			"ADDITIONAL_PROPERTY":        "não é permitido",
+			"INVALID_PAYLOAD":            "não é um conteúdo válido",
+		},
+		"es": {
+			"IMMUTABLE_VALUE":            "no se puede modificar",
+			"INVALID_FORMAT":             "tiene un formato inválido",
+			"INVALID_LENGTH":             "tiene una longitud inválida",
+			"INVALID_TYPE":               "tiene un tipo inválido",
+			"INVALID_VALUE":              "tiene un valor inválido",
+			"UNEXPECTED_ERROR":           "no se pudo validar",
+			"UNREGISTERED_MODEL":         "no corresponde a ningún modelo conocido",
+			"MUTUALLY_EXCLUSIVE":         "no se puede informar junto con los demás campos de su grupo",
+			"MISSING_ONE_OF":             "o uno de los demás campos de su grupo es obligatorio",
+			"REQUIRED_ATTRIBUTE_MISSING": "es obligatorio",
+			"FIELD_MISMATCH":             "no coincide con {rule_value}",
+			"UNKNOWN_RULE":               "tiene una regla de validación desconocida: {rule}",
+			"ADDITIONAL_PROPERTY":        "no está permitido",
+			"INVALID_PAYLOAD":            "no es un contenido válido",
+		},
+	}
+)
+
+// Registers the messages of a locale (i.e. `pt-BR`), keyed by error code.
+// Messages are merged into the ones already registered for the locale, replacing those with the same codes.
+// The messages of the `en`, `pt-BR` and `es` locales are registered by default.
+//
+// Messages may contain the following placeholders:
+//   - `{path}`: the path of the attribute, as in `addresses[0].street`.
+//   - `{rule}`: the name of the rule the attribute failed, as in `min`.
+//   - `{rule_value}`: the value of the rule, as in `2` for `min=2`.
+//
+// Usage:
+//
+//	RegisterTranslations("pt-BR", map[string]string{
+//		"INVALID_SKU": "não é um SKU válido",
+//		"INVALID_LENGTH": "deve ter ao menos {rule_value} caracteres",
+//	})
+func RegisterTranslations(locale string, messages map[string]string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+
+	if translations[locale] == nil {
+		translations[locale] = map[string]string{}
+	}
+
+	for code, message := range messages {
+		translations[locale][code] = message
+	}
+}
+
+// Returns the message of the error in the given locale. See `RegisterTranslations`.
+// When the locale has no message for the error code, the language of the locale (i.e. `pt` for `pt-BR`)
+// and then `DEFAULT_LOCALE` are tried. If none of them has a message, the error code itself is returned.
+//
+// Usage:
+//
+//	Translate("pt-BR", ValidationError{Path: "name", Code: "REQUIRED_ATTRIBUTE_MISSING"}) // -> "é obrigatório"
+func Translate(locale string, err ValidationError) string {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+
+	language, _, _ := strings.Cut(locale, "-")
+
+	for _, l := range []string{locale, language, DEFAULT_LOCALE} {
+		if message, ok := translations[l][err.Code]; ok {
+			return strings.NewReplacer("{path}", err.Path, "{rule}", err.Rule, "{rule_value}", err.RuleValue).Replace(message)
+		}
+	}
+
+	return err.Code
+}
+
+// Returns the messages of the errors, keyed by the paths of the attributes. See `ValidationOptions.Locale`.
+// Errors without a message are represented by their codes.
+func (result ValidationResult) Messages() map[string][]string {
+	messages := make(map[string][]string, len(result))
+
+	for _, err := range result {
+		message := err.Message
+		if message == "" {
+			message = err.Code
+		}
+
+		messages[err.Path] = append(messages[err.Path], message)
+	}
+
+	return messages
+}
@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ValidateResult_Messages(t *testing.T) {
+	type Signup struct {
+		Name                 string `json:"name" validate:"min=2"`
+		Password             string `json:"password"`
+		PasswordConfirmation string `json:"password_confirmation" validate:"eqfield=password"`
+		Sku                  string `json:"sku" validate:"i18n_sku"`
+	}
+
+	RegisterRule("i18n_sku", func(attribute structs.StructAttribute, ruleValue string) []string {
+		return []string{"INVALID_SKU"}
+	})
+
+	RegisterTranslations("pt", map[string]string{"INVALID_SKU": "{path} não é um SKU válido"})
+	RegisterTranslations("fr-CA", map[string]string{"INVALID_LENGTH": "doit avoir au moins {rule_value} caractères"})
+
+	model := Signup{Name: "L", Password: "secret"}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "no locale",
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"name":                  {"INVALID_LENGTH"},
+				"password_confirmation": {"FIELD_MISMATCH"},
+				"sku":                   {"INVALID_SKU"},
+			},
+		},
+		{
+			name:    "pt-BR",
+			options: ValidationOptions{Locale: "pt-BR"},
+			want: map[string][]string{
+				"name":                  {"tem um tamanho inválido"},
+				"password_confirmation": {"não confere com password"},
+				"sku":                   {"sku não é um SKU válido"},
+			},
+		},
+		{
+			name:    "es",
+			options: ValidationOptions{Locale: "es"},
+			want: map[string][]string{
+				"name":                  {"tiene una longitud inválida"},
+				"password_confirmation": {"no coincide con password"},
+				"sku":                   {"INVALID_SKU"},
+			},
+		},
+		{
+			name:    "partially translated",
+			options: ValidationOptions{Locale: "fr-CA"},
+			want: map[string][]string{
+				"name":                  {"doit avoir au moins 2 caractères"},
+				"password_confirmation": {"does not match password"},
+				"sku":                   {"INVALID_SKU"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateResult(model, tt.options)
+
+			if got := result.Messages(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidationResult.Messages() = %v, want %v", got, tt.want)
+			}
+
+			if got := result.Map(); !reflect.DeepEqual(got, Validate(model, tt.options)) {
+				t.Errorf("ValidationResult.Map() = %v, want %v", got, Validate(model, tt.options))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// The Crockford's base32 alphabet used by ULIDs, which leaves out `I`, `L`, `O` and `U`.
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// The base62 alphabet used by KSUIDs, in ASCII order.
+	ksuidAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// The largest KSUID, holding 160 bits set.
+	maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+// Returns `true` if value is a UUID of the given version (i.e. `4` or `7`) and of the RFC 4122 variant,
+// in one of the forms accepted by the options. See `IsUUIDWithOptions`.
+//
+// Usage:
+//
+//	IsUUIDVersion("f47ac10b-58cc-4372-a567-0e02b2c3d479", 4, UUIDOptions{}) // -> true
+//	IsUUIDVersion("2b852002-f19d-11ec-8ea0-0242ac120002", 4, UUIDOptions{}) // -> false (version 1)
+func IsUUIDVersion(value string, version int, options UUIDOptions) bool {
+	if !IsUUIDWithOptions(value, options) {
+		return false
+	}
+
+	id := uuid.MustParse(value)
+	return id.Variant() == uuid.RFC4122 && int(id.Version()) == version
+}
+
+// Returns `true` if value is a ULID: 26 characters of Crockford's base32, case-insensitive,
+// encoding a 48-bit timestamp followed by 80 random bits.
+//
+// Usage:
+//
+//	IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAV") // -> true
+//	IsULID("81ARZ3NDEKTSV4RRFFQ69G5FAV") // -> false (overflows 128 bits)
+func IsULID(value string) bool {
+	if len(value) != 26 || value[0] > '7' {
+		return false
+	}
+
+	for _, c := range strings.ToUpper(value) {
+		if !strings.ContainsRune(ulidAlphabet, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns `true` if value is a KSUID: 27 characters of base62 encoding a 32-bit timestamp followed by 128 random bits.
+//
+// Usage:
+//
+//	IsKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv") // -> true
+//	IsKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLO")  // -> false
+func IsKSUID(value string) bool {
+	if len(value) != len(maxKSUID) || value > maxKSUID {
+		return false
+	}
+
+	for _, c := range value {
+		if !strings.ContainsRune(ksuidAlphabet, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Reports whether the value is an identifier of the kind expected by the given rule (`ksuid`, `ulid`, `uuid4` or `uuid7`).
+func isIdentifier(value string, rule string, options UUIDOptions) bool {
+	switch rule {
+	case KSUID:
+		return IsKSUID(value)
+	case ULID:
+		return IsULID(value)
+	case UUID4:
+		return IsUUIDVersion(value, 4, options)
+	}
+
+	return IsUUIDVersion(value, 7, options)
+}
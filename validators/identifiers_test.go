@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func Test_IsUUIDVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		version int
+		options UUIDOptions
+		want    bool
+	}{
+		{name: "version 4", value: "f47ac10b-58cc-4372-a567-0e02b2c3d479", version: 4, want: true},
+		{name: "version 7", value: "01890a5d-ac96-774b-bcce-b302099a8057", version: 7, want: true},
+		{name: "version 1", value: "2b852002-f19d-11ec-8ea0-0242ac120002", version: 4, want: false},
+		{name: "version 4 as version 7", value: "f47ac10b-58cc-4372-a567-0e02b2c3d479", version: 7, want: false},
+		{name: "other variant", value: "f47ac10b-58cc-4372-c567-0e02b2c3d479", version: 4, want: false},
+		{name: "uppercase", value: "F47AC10B-58CC-4372-A567-0E02B2C3D479", version: 4, want: false},
+		{name: "uppercase allowed", value: "F47AC10B-58CC-4372-A567-0E02B2C3D479", version: 4, options: UUIDOptions{Uppercase: true}, want: true},
+		{name: "invalid", value: "f47ac10b", version: 4, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUUIDVersion(tt.value, tt.version, tt.options); got != tt.want {
+				t.Errorf("IsUUIDVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsULID(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "01ARZ3NDEKTSV4RRFFQ69G5FAV", want: true},
+		{value: "01arz3ndektsv4rrffq69g5fav", want: true},
+		{value: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", want: true},
+		{value: "81ARZ3NDEKTSV4RRFFQ69G5FAV", want: false},
+		{value: "01ARZ3NDEKTSV4RRFFQ69G5FAU", want: false},
+		{value: "01ARZ3NDEKTSV4RRFFQ69G5FA", want: false},
+		{value: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsULID(tt.value); got != tt.want {
+				t.Errorf("IsULID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsKSUID(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "0ujtsYcgvSTl8PAuAdqWYSMnLOv", want: true},
+		{value: "000000000000000000000000000", want: true},
+		{value: "aWgEPTl1tmebfsQzFP4bxwgy80V", want: true},
+		{value: "aWgEPTl1tmebfsQzFP4bxwgy80W", want: false},
+		{value: "0ujtsYcgvSTl8PAuAdqWYSMnLO-", want: false},
+		{value: "0ujtsYcgvSTl8PAuAdqWYSMnLO", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsKSUID(tt.value); got != tt.want {
+				t.Errorf("IsKSUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Identifiers(t *testing.T) {
+	type Event struct {
+		Id        string    `json:"id" validate:"ulid"`
+		TraceId   *string   `json:"trace_id" validate:"ksuid"`
+		AccountId uuid.UUID `json:"account_id" validate:"uuid4"`
+		Sequence  string    `json:"sequence" validate:"uuid7"`
+		Related   []string  `json:"related" validate:"ulid"`
+		Version   int       `json:"version" validate:"uuid4"`
+	}
+
+	traceId := "0ujtsYcgvSTl8PAuAdqWYSMnLOv"
+
+	tests := []struct {
+		name  string
+		model Event
+		want  map[string][]string
+	}{
+		{
+			name: "valid",
+			model: Event{
+				Id:        "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+				TraceId:   &traceId,
+				AccountId: uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+				Sequence:  "01890a5d-ac96-774b-bcce-b302099a8057",
+				Related:   []string{"01BX5ZZKBKACTAV9WEVGEMMVRZ"},
+			},
+			want: map[string][]string{"version": {"INVALID_TYPE"}},
+		},
+		{
+			name: "invalid",
+			model: Event{
+				Id:        traceId,
+				AccountId: uuid.MustParse("2b852002-f19d-11ec-8ea0-0242ac120002"),
+				Sequence:  "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Related:   []string{"01BX5ZZKBKACTAV9WEVGEMMVRZ", "?"},
+			},
+			want: map[string][]string{
+				"id":         {"INVALID_FORMAT"},
+				"trace_id":   {"INVALID_FORMAT"},
+				"account_id": {"INVALID_FORMAT"},
+				"sequence":   {"INVALID_FORMAT"},
+				"related[1]": {"INVALID_FORMAT"},
+				"version":    {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
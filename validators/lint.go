@@ -0,0 +1,104 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// A problem found in the validation rules of a model.
+type LintWarning struct {
+	// The path of the field. See `structs.FieldDoc`.
+	Path string
+
+	// The rule as it appears in the tag.
+	Rule string
+
+	Message string
+}
+
+// The names of the built-in rules handled by `ValidateAttribute`.
+var builtinRules = []string{
+	AFTER, AT_LEAST_ONE_OF, BASE64URL, BEFORE, CIDR, CREDITCARD, CURRENCY, DATETIME, DIGITS, E164, EMAIL, EQFIELD, EQUAL, EXACTLY_ONE_OF, FLOATSTR, GTFIELD,
+	IBAN, IN, INTSTR, IP, IPV4, IPV6, KSUID, LENGTH, MAC, MAX, MIN, MIN_AGE, NOTBLANK, PHONE, PRECISION, RANGE, REGEX, REQUIRED, REQUIRED_IF, REQUIRED_WITH, REQUIRED_WITHOUT,
+	SAME_DAY_AS, SCALE, ULID, URL, UUID, UUID4, UUID7,
+}
+
+// Checks the validation rules of the given model (a struct or a pointer to one) and reports:
+//   - rules that are neither built-in nor registered (see `RegisterRule`), which are silently ignored by `Validate` (unless `StrictRules` is set).
+//   - deprecated rules (see `DeprecatedRules`), along with their migration messages.
+//   - `in` rules referencing value providers that do not exist (see `ValueProviders`).
+//   - `regex` rules whose patterns do not compile.
+//   - `phone` rules with unknown regions.
+//   - rules that cannot be parsed (see `structs.ParseRule`), such as `regex(^a` or `in='a`.
+//
+// Aliases are resolved using the same rules as `Validate`.
+//
+// Usage:
+//
+//	DeprecatedRules["is_email"] = "use `email` instead"
+//	RuleAliases["is_email"] = "email"
+//
+//	type Person struct {
+//		Email string `json:"email" validate:"is_email"`
+//		Name  string `json:"name" validate:"lenght=3"`
+//	}
+//
+//	Lint(Person{}, ValidationOptions{})
+//	// -> [
+//	//	{Path: email, Rule: is_email, Message: "deprecated rule: use `email` instead"},
+//	//	{Path: name, Rule: lenght=3, Message: "unknown rule: lenght"},
+//	// ]
+func Lint(model any, options ValidationOptions) []LintWarning {
+	warnings := []LintWarning{}
+
+	for _, field := range structs.DescribeModel(model) {
+		for _, rule := range field.Rules {
+			if rule == "" {
+				continue
+			}
+
+			parsed, err := structs.ParseRule(rule)
+			if err != nil {
+				warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: fmt.Sprint("invalid rule: ", err)})
+				continue
+			}
+
+			name, value := parsed.Name, parsed.Value
+
+			if message, ok := DeprecatedRules[name]; ok {
+				warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: "deprecated rule: " + message})
+			}
+
+			if !isKnownRule(options.canonicalRule(name)) {
+				warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: fmt.Sprint("unknown rule: ", name)})
+			}
+
+			if _, ok := options.valueProvider(value); options.canonicalRule(name) == IN && isValueProvider(value) && !ok {
+				warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: fmt.Sprint("unknown value provider: ", value)})
+			}
+
+			if _, ok := phoneRegions[strings.ToUpper(value)]; options.canonicalRule(name) == PHONE && value != "" && !ok {
+				warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: fmt.Sprint("unknown phone region: ", value)})
+			}
+
+			if options.canonicalRule(name) == REGEX {
+				if _, err := compiledPattern(value); err != nil {
+					warnings = append(warnings, LintWarning{Path: field.Path, Rule: rule, Message: fmt.Sprint("invalid pattern: ", err)})
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+func isKnownRule(name string) bool {
+	_, registered := registeredRule(name)
+	return isBuiltinRule(name) || registered
+}
+
+func isBuiltinRule(name string) bool {
+	return structs.Contains(builtinRules, name) || strings.HasPrefix(name, REGEX+"(")
+}
@@ -0,0 +1,150 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Lint(t *testing.T) {
+	type Person struct {
+		Email  string   `json:"email" validate:"is_email"`
+		Name   string   `json:"name" validate:"min=2,lenght=3"`
+		Emails []string `json:"emails" validate:"each:mail"`
+		Phone  string   `json:"phone" validate:"regex([0-9]+)"`
+		Role   string   `json:"role" validate:"in=@roles"`
+		Code   string   `json:"code" validate:"regex(^a{2,1}$)"`
+		Tags   []string `json:"tags" validate:"in='a,b"`
+	}
+
+	RuleAliases["is_email"] = "email"
+	DeprecatedRules["is_email"] = "use `email` instead"
+
+	defer func() {
+		delete(RuleAliases, "is_email")
+		delete(DeprecatedRules, "is_email")
+	}()
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    []LintWarning
+	}{
+		{
+			name:    "lint - 1",
+			options: ValidationOptions{},
+			want: []LintWarning{
+				{Path: "email", Rule: "is_email", Message: "deprecated rule: use `email` instead"},
+				{Path: "name", Rule: "lenght=3", Message: "unknown rule: lenght"},
+				{Path: "emails", Rule: "each:mail", Message: "unknown rule: mail"},
+				{Path: "role", Rule: "in=@roles", Message: "unknown value provider: @roles"},
+				{Path: "code", Rule: "regex(^a{2,1}$)", Message: "invalid pattern: error parsing regexp: invalid repeat count: `{2,1}`"},
+				{Path: "tags", Rule: "in='a,b", Message: `invalid rule: invalid rule expression: unterminated quote: "in='a,b"`},
+			},
+		},
+		{
+			name: "lint - 2",
+			options: ValidationOptions{
+				RuleAliases:    map[string]string{"mail": "email", "lenght": "eq"},
+				ValueProviders: map[string]func() []string{"roles": func() []string { return []string{"ADMIN"} }},
+			},
+			want: []LintWarning{
+				{Path: "email", Rule: "is_email", Message: "deprecated rule: use `email` instead"},
+				{Path: "code", Rule: "regex(^a{2,1}$)", Message: "invalid pattern: error parsing regexp: invalid repeat count: `{2,1}`"},
+				{Path: "tags", Rule: "in='a,b", Message: `invalid rule: invalid rule expression: unterminated quote: "in='a,b"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lint(Person{}, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_RuleAliases(t *testing.T) {
+	type Person struct {
+		Email  string   `json:"email" validate:"is_email"`
+		Emails []string `json:"emails" validate:"each:mail"`
+	}
+
+	model := Person{Email: "leo", Emails: []string{"leo@example.com", "leo"}}
+
+	options := ValidationOptions{RuleAliases: map[string]string{"is_email": "email", "mail": "email"}}
+	want := map[string][]string{"email": {"INVALID_FORMAT"}, "emails[1]": {"INVALID_FORMAT"}}
+
+	if got := Validate(model, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	options.SkipRules = []string{"mail"}
+	want = map[string][]string{"email": {"INVALID_FORMAT"}}
+
+	if got := Validate(model, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	if got := Validate(model, ValidationOptions{}); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no errors", got)
+	}
+}
+
+func Test_Validate_TagAliases(t *testing.T) {
+	type Person struct {
+		ID     string   `json:"id" format:"uuid"`
+		Email  string   `json:"email" validate:"required" format:"email"`
+		Emails []string `json:"emails" format:"each:email"`
+		Zip    string   `json:"zip" form:"digits"`
+	}
+
+	model := Person{ID: "leo", Email: "leo", Emails: []string{"leo@example.com", "leo"}, Zip: "A1"}
+
+	TagAliases["format"] = VALIDATION_TAG_KEYWORD
+	defer delete(TagAliases, "format")
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "default aliases",
+			options: ValidationOptions{},
+			want:    map[string][]string{"id": {"INVALID_FORMAT"}, "email": {"INVALID_FORMAT"}, "emails[1]": {"INVALID_FORMAT"}},
+		},
+		{
+			name:    "aliases of additional tags",
+			options: ValidationOptions{AdditionalTags: []string{"binding"}, TagAliases: map[string]string{"form": "binding"}},
+			want:    map[string][]string{"id": {"INVALID_FORMAT"}, "email": {"INVALID_FORMAT"}, "emails[1]": {"INVALID_FORMAT"}, "zip": {"INVALID_FORMAT"}},
+		},
+		{
+			name:    "aliases of tags that are not read",
+			options: ValidationOptions{TagAliases: map[string]string{"form": "binding"}},
+			want:    map[string][]string{"id": {"INVALID_FORMAT"}, "email": {"INVALID_FORMAT"}, "emails[1]": {"INVALID_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+
+			if got := NewValidator(tt.options).Validate(model); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validator.Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Validators keep the aliases they were created with
+	validator := NewValidator(ValidationOptions{})
+	delete(TagAliases, "format")
+
+	if got := Validate(model, ValidationOptions{}); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no errors", got)
+	}
+
+	if got := validator.Validate(model); len(got) != 3 {
+		t.Errorf("Validator.Validate() = %v, want the errors of the aliased tag", got)
+	}
+}
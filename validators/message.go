@@ -0,0 +1,85 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+const (
+	// Suffix appended to a topic in order to obtain the name of the subject its values are registered under,
+	// following the topic name strategy of the Confluent Schema Registry.
+	SCHEMA_REGISTRY_SUBJECT_SUFFIX string = "-value"
+)
+
+// Decodes and validates the payload of a message (i.e. a Kafka event) using the model registered for its topic.
+//
+// The registry maps topics (or subjects) to an instance of the model its messages should be decoded into.
+// A new instance of the registered model is populated for every message and returned alongside its validations.
+// If no model is registered for the topic, the returned model is nil and an `UNREGISTERED_MODEL` error is reported under the `_` key.
+//
+// Usage:
+//
+//	registry := map[string]any{
+//		"users.created": User{},
+//		"users.deleted": UserDeleted{},
+//	}
+//
+//	model, errs := ValidateMessage(registry, "users.created", payload, PayloadValidationOptions{})
+//	user := model.(*User)
+func ValidateMessage(topicModel map[string]any, topic string, payload []byte, options PayloadValidationOptions) (any, map[string][]string) {
+	options = options.resolved()
+
+	prototype, ok := topicModel[topic]
+	if !ok {
+		return nil, map[string][]string{options.KeyPrefix + "_": {options.errorCode("unregistered")}}
+	}
+
+	model := newModel(prototype)
+
+	return model, ValidatePayload(payload, model, options)
+}
+
+// Returns the JSON schemas of the registered models in the format expected by the Confluent Schema Registry,
+// keyed by the subject they should be registered under (see `SCHEMA_REGISTRY_SUBJECT_SUFFIX`).
+//
+// Each value can be sent as the body of a `POST /subjects/{subject}/versions` request.
+//
+// Usage:
+//
+//	requests, err := SchemaRegistryRequests(registry, structs.DecoderOptions{})
+//	// -> {"users.created-value": `{"schemaType": "JSON", "schema": "{...}"}`, ...}
+func SchemaRegistryRequests(topicModel map[string]any, options structs.DecoderOptions) (map[string][]byte, error) {
+	requests := make(map[string][]byte, len(topicModel))
+
+	for topic, prototype := range topicModel {
+		schema, err := structs.JSONSchema(newModel(prototype), options)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := json.Marshal(map[string]string{"schemaType": "JSON", "schema": string(schema)})
+		if err != nil {
+			return nil, err
+		}
+
+		requests[topic+SCHEMA_REGISTRY_SUBJECT_SUFFIX] = body
+	}
+
+	return requests, nil
+}
+
+// Returns a pointer to a new zero value of the type of the given model.
+func newModel(prototype any) any {
+	t := reflect.TypeOf(prototype)
+	if t == nil {
+		return nil
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return reflect.New(t).Interface()
+}
@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ValidateMessage(t *testing.T) {
+	registry := map[string]any{
+		"people":   Person{},
+		"contacts": &Contact{},
+	}
+
+	tests := []struct {
+		name    string
+		topic   string
+		payload []byte
+		model   any
+		want    map[string][]string
+	}{
+		{
+			name:    "people - 1",
+			topic:   "people",
+			payload: []byte(`{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "Leonardo", "contact": {"emails": ["leo@example.com"]}}`),
+			model: &Person{
+				Identifiable: Identifiable{UUID: "2b852002-f19d-11ec-8ea0-0242ac120002"},
+				Name:         "Leonardo",
+				Contact:      Contact{Emails: []string{"leo@example.com"}},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name:    "contacts - 1",
+			topic:   "contacts",
+			payload: []byte(`{"emails": ["leo"]}`),
+			model:   &Contact{Emails: []string{"leo"}},
+			want:    map[string][]string{"emails[0]": {"INVALID_FORMAT"}},
+		},
+		{
+			name:    "unregistered - 1",
+			topic:   "accounts",
+			payload: []byte(`{}`),
+			model:   nil,
+			want:    map[string][]string{"_": {"UNREGISTERED_MODEL"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, got := ValidateMessage(registry, tt.topic, tt.payload, PayloadValidationOptions{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateMessage() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(model, tt.model) {
+				t.Errorf("ValidateMessage() model = %+v, want %+v", model, tt.model)
+			}
+		})
+	}
+}
+
+func Test_SchemaRegistryRequests(t *testing.T) {
+	registry := map[string]any{"contacts": Contact{}}
+
+	requests, err := SchemaRegistryRequests(registry, structs.DecoderOptions{})
+	if err != nil {
+		t.Fatalf("SchemaRegistryRequests() error = %v", err)
+	}
+
+	var body struct {
+		SchemaType string `json:"schemaType"`
+		Schema     string `json:"schema"`
+	}
+
+	if err := json.Unmarshal(requests["contacts-value"], &body); err != nil {
+		t.Fatalf("SchemaRegistryRequests() returned an invalid body: %v", err)
+	}
+
+	if body.SchemaType != "JSON" || !json.Valid([]byte(body.Schema)) {
+		t.Errorf("SchemaRegistryRequests() = %s", requests["contacts-value"])
+	}
+}
@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// The key under which options are stored in a context. See `WithOptions`.
+type optionsContextKey struct{}
+
+// Returns a copy of the options with the given overrides applied on top of them.
+// This allows a base set of options to be defined once per service and tweaked per handler.
+// Neither the options nor the overrides are modified.
+//
+// Fields are merged as follows:
+//   - lists (i.e. `SkipRules`) are concatenated.
+//   - maps (i.e. `ErrorCodes`) are combined, with the overrides taking precedence.
+//   - booleans are enabled if they are enabled in either of them, unless the overrides list them in `DisabledOptions`.
+//   - any other field is replaced, unless it is left at its zero value in the overrides.
+//
+// Usage:
+//
+//	base := ValidationOptions{SkipRules: []string{"currency"}, KeyPrefix: "payload.", Recover: true}
+//	ValidationOptions.Merge(base, ValidationOptions{SkipRules: []string{"uuid"}, DisabledOptions: []string{"Recover"}})
+//	// -> {SkipRules: [currency uuid], KeyPrefix: payload., DisabledOptions: [Recover]}
+func (options ValidationOptions) Merge(overrides ValidationOptions) ValidationOptions {
+	merged := reflect.New(reflect.TypeOf(options)).Elem()
+	merged.Set(reflect.ValueOf(options))
+	mergeValues(merged, reflect.ValueOf(options), reflect.ValueOf(overrides))
+
+	for _, name := range overrides.DisabledOptions {
+		if field := fieldByPath(merged, name); field.IsValid() && field.Kind() == reflect.Bool {
+			field.SetBool(false)
+		}
+	}
+
+	// Options enabled by the overrides are no longer disabled
+	disabled := []string{}
+	for _, name := range options.DisabledOptions {
+		if field := fieldByPath(reflect.ValueOf(overrides), name); !field.IsValid() || field.Kind() != reflect.Bool || !field.Bool() {
+			disabled = append(disabled, name)
+		}
+	}
+
+	result := merged.Interface().(ValidationOptions)
+	result.DisabledOptions = nil
+	result.isolated = options.isolated || overrides.isolated
+
+	if disabled = append(disabled, overrides.DisabledOptions...); len(disabled) != 0 {
+		result.DisabledOptions = disabled
+	}
+
+	return result
+}
+
+// Returns the (possibly nested) field of the struct found under the given path, as in `UUID.Uppercase`.
+// The returned value is invalid if there is no such field.
+func fieldByPath(rv reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if rv.Kind() != reflect.Struct {
+			return reflect.Value{}
+		}
+
+		rv = rv.FieldByName(name)
+	}
+
+	return rv
+}
+
+// Returns a copy of the context carrying the given options, merged on top of the ones it already carries.
+// Use it in middlewares to tweak the options used by the handlers further down the chain. See `ValidateContext`.
+//
+// Usage:
+//
+//	ctx = WithOptions(r.Context(), ValidationOptions{SkipRules: []string{"uuid"}})
+//	errs := ValidateContext(ctx, payload, base)
+func WithOptions(ctx context.Context, options ValidationOptions) context.Context {
+	return context.WithValue(ctx, optionsContextKey{}, OptionsFromContext(ctx).Merge(options))
+}
+
+// Returns the options carried by the context, or the zero value if there are none. See `WithOptions`.
+func OptionsFromContext(ctx context.Context) ValidationOptions {
+	options, _ := ctx.Value(optionsContextKey{}).(ValidationOptions)
+	return options
+}
+
+// Validates the model using the options carried by the context merged on top of the given ones. See `Validate`.
+func ValidateContext(ctx context.Context, model any, options ValidationOptions) map[string][]string {
+	return Validate(model, options.Merge(OptionsFromContext(ctx)))
+}
+
+// Sets `dst` to the result of merging `overrides` on top of `base`. See `ValidationOptions.Merge`.
+func mergeValues(dst, base, overrides reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			// Unexported fields are set by `Merge` itself
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+
+			mergeValues(dst.Field(i), base.Field(i), overrides.Field(i))
+		}
+	case reflect.Slice:
+		if base.IsNil() && overrides.IsNil() {
+			return
+		}
+
+		merged := reflect.MakeSlice(dst.Type(), 0, base.Len()+overrides.Len())
+		dst.Set(reflect.AppendSlice(reflect.AppendSlice(merged, base), overrides))
+	case reflect.Map:
+		if base.IsNil() && overrides.IsNil() {
+			return
+		}
+
+		merged := reflect.MakeMapWithSize(dst.Type(), base.Len()+overrides.Len())
+		for _, m := range []reflect.Value{base, overrides} {
+			iter := m.MapRange()
+			for iter.Next() {
+				merged.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+
+		dst.Set(merged)
+	case reflect.Bool:
+		dst.SetBool(base.Bool() || overrides.Bool())
+	default:
+		if overrides.IsZero() {
+			dst.Set(base)
+			return
+		}
+
+		dst.Set(overrides)
+	}
+}
@@ -0,0 +1,133 @@
+package validators
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_ValidationOptions_Merge(t *testing.T) {
+	base := ValidationOptions{
+		SkipRules:  []string{CURRENCY},
+		KeyPrefix:  "payload.",
+		ErrorCodes: map[string]string{"format": "BAD_FORMAT", "value": "BAD_VALUE"},
+		UUID:       UUIDOptions{Uppercase: true},
+	}
+
+	overrides := ValidationOptions{
+		SkipRules:                   []string{UUID},
+		ErrorCodes:                  map[string]string{"value": "WRONG_VALUE"},
+		NonInheritableTagAttributes: []string{},
+		UUID:                        UUIDOptions{Braces: true},
+		Recover:                     true,
+	}
+
+	got := ValidationOptions.Merge(base, overrides)
+
+	want := ValidationOptions{
+		SkipRules:                   []string{CURRENCY, UUID},
+		KeyPrefix:                   "payload.",
+		ErrorCodes:                  map[string]string{"format": "BAD_FORMAT", "value": "WRONG_VALUE"},
+		NonInheritableTagAttributes: []string{},
+		UUID:                        UUIDOptions{Uppercase: true, Braces: true},
+		Recover:                     true,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+
+	// Neither the options nor the overrides are modified
+	got.SkipRules[0] = EMAIL
+	got.ErrorCodes["format"] = "FORMAT"
+
+	if base.SkipRules[0] != CURRENCY || base.ErrorCodes["format"] != "BAD_FORMAT" || len(overrides.ErrorCodes) != 1 {
+		t.Errorf("Merge() modified its arguments: %+v, %+v", base, overrides)
+	}
+}
+
+func Test_ValidationOptions_Merge_DisabledOptions(t *testing.T) {
+	base := ValidationOptions{Recover: true, StrictRules: true, UUID: UUIDOptions{Uppercase: true, Braces: true}}
+
+	tests := []struct {
+		name      string
+		overrides ValidationOptions
+		want      ValidationOptions
+	}{
+		{
+			name:      "booleans are kept",
+			overrides: ValidationOptions{},
+			want:      base,
+		},
+		{
+			name:      "disabled",
+			overrides: ValidationOptions{DisabledOptions: []string{"Recover", "UUID.Braces"}},
+			want:      ValidationOptions{StrictRules: true, UUID: UUIDOptions{Uppercase: true}, DisabledOptions: []string{"Recover", "UUID.Braces"}},
+		},
+		{
+			name:      "unknown names",
+			overrides: ValidationOptions{DisabledOptions: []string{"Recovery", "KeyPrefix", "UUID.Braces.Value"}},
+			want:      ValidationOptions{Recover: true, StrictRules: true, UUID: UUIDOptions{Uppercase: true, Braces: true}, DisabledOptions: []string{"Recovery", "KeyPrefix", "UUID.Braces.Value"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Merge(tt.overrides); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// Options disabled by earlier overrides can be enabled again
+	merged := base.Merge(ValidationOptions{DisabledOptions: []string{"Recover"}}).Merge(ValidationOptions{Recover: true})
+	if !merged.Recover || merged.DisabledOptions != nil {
+		t.Errorf("Merge() = %+v, want Recover enabled", merged)
+	}
+
+	// Disabled options are carried by contexts
+	ctx := WithOptions(context.Background(), ValidationOptions{DisabledOptions: []string{"StrictRules", "Recover"}})
+	if got := ValidationOptions.Merge(base, OptionsFromContext(ctx)); got.StrictRules || got.Recover {
+		t.Errorf("Merge() = %+v, want StrictRules and Recover disabled", got)
+	}
+
+	ctx = WithOptions(ctx, ValidationOptions{Recover: true})
+	if got := ValidationOptions.Merge(base, OptionsFromContext(ctx)); got.StrictRules || !got.Recover {
+		t.Errorf("Merge() = %+v, want StrictRules disabled and Recover enabled", got)
+	}
+}
+
+func Test_ValidateContext(t *testing.T) {
+	type Resource struct {
+		Id       string `json:"id" validate:"uuid"`
+		Currency string `json:"currency" validate:"currency"`
+	}
+
+	base := ValidationOptions{KeyPrefix: "payload."}
+
+	ctx := WithOptions(context.Background(), ValidationOptions{SkipRules: []string{UUID}})
+	ctx = WithOptions(ctx, ValidationOptions{ErrorCodes: map[string]string{"value": "BAD_VALUE"}})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string][]string
+	}{
+		{
+			name: "without options",
+			ctx:  context.Background(),
+			want: map[string][]string{"payload.id": {"INVALID_FORMAT"}, "payload.currency": {"INVALID_VALUE"}},
+		},
+		{
+			name: "with options",
+			ctx:  ctx,
+			want: map[string][]string{"payload.currency": {"BAD_VALUE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateContext(tt.ctx, Resource{Id: "abc", Currency: "ABC"}, base); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+const (
+	// The number of items per page used by `DefaultPage`, `DefaultCursor` and `DefaultRange`.
+	DEFAULT_PAGE_LIMIT = 20
+)
+
+type (
+	// Page-based pagination parameters.
+	//
+	// Embed it in a request struct so that every endpoint reports the same errors:
+	//
+	//	type ListUsers struct {
+	//		validators.Page
+	//		Role string `json:"role" query:"role"`
+	//	}
+	//
+	// Errors are reported under the `page` and `limit` keys. The limit cannot be greater than 100.
+	Page struct {
+		// Named `Number` so that it is not shadowed by the embedded struct itself.
+		Number int `json:"page" query:"page" validate:"min=1"`
+		Limit  int `json:"limit" query:"limit" validate:"min=1,max=100"`
+	}
+
+	// Cursor-based pagination parameters.
+	// The cursor is an opaque, URL-safe base64-encoded string. An empty cursor points to the first page.
+	//
+	// Errors are reported under the `cursor` and `limit` keys. The limit cannot be greater than 100.
+	Cursor struct {
+		Cursor string `json:"cursor" query:"cursor" validate:"base64url"`
+		Limit  int    `json:"limit" query:"limit" validate:"min=1,max=100"`
+	}
+
+	// Offset-based pagination parameters.
+	//
+	// Errors are reported under the `offset` and `limit` keys. The limit cannot be greater than 100.
+	Range struct {
+		Offset int `json:"offset" query:"offset" validate:"min=0"`
+		Limit  int `json:"limit" query:"limit" validate:"min=1,max=100"`
+	}
+)
+
+// Returns the first page, using the default limit.
+// Use it to initialize requests before binding the values sent by the client.
+func DefaultPage() Page {
+	return Page{Number: 1, Limit: DEFAULT_PAGE_LIMIT}
+}
+
+// Returns a cursor pointing to the first page, using the default limit.
+func DefaultCursor() Cursor {
+	return Cursor{Limit: DEFAULT_PAGE_LIMIT}
+}
+
+// Returns a range starting at the first item, using the default limit.
+func DefaultRange() Range {
+	return Range{Limit: DEFAULT_PAGE_LIMIT}
+}
+
+// Returns the number of items that come before the page.
+//
+// Usage:
+//
+//	Page{Number: 3, Limit: 20}.Offset() // -> 40
+func (p Page) Offset() int {
+	if p.Number < 1 {
+		return 0
+	}
+
+	return (p.Number - 1) * p.Limit
+}
+
+// Returns the value encoded in the cursor.
+func (c Cursor) Decode() ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(c.Cursor, "="))
+}
+
+// Returns a cursor pointing to the given value, keeping the same limit.
+//
+// Usage:
+//
+//	next := cursor.Next([]byte(`{"id":1}`)) // -> {Cursor: eyJpZCI6MX0, Limit: 20}
+func (c Cursor) Next(value []byte) Cursor {
+	return Cursor{Cursor: base64.RawURLEncoding.EncodeToString(value), Limit: c.Limit}
+}
@@ -0,0 +1,91 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_Pagination(t *testing.T) {
+	type ListUsers struct {
+		Page
+		Role string `json:"role" query:"role" validate:"in=ADMIN|GUEST"`
+	}
+
+	type Feed struct {
+		Cursor
+	}
+
+	type Export struct {
+		Range
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "page - 1",
+			model: ListUsers{Page: DefaultPage(), Role: "ADMIN"},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "page - 2",
+			model: ListUsers{Page: Page{Number: 0, Limit: 500}, Role: "ADMIN"},
+			want:  map[string][]string{"page": {"INVALID_VALUE"}, "limit": {"INVALID_VALUE"}},
+		},
+		{
+			name:  "cursor - 1",
+			model: Feed{Cursor: DefaultCursor().Next([]byte(`{"id":1}`))},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "cursor - 2",
+			model: Feed{Cursor: Cursor{Cursor: "not a cursor!", Limit: 0}},
+			want:  map[string][]string{"cursor": {"INVALID_FORMAT"}, "limit": {"INVALID_VALUE"}},
+		},
+		{
+			name:  "range - 1",
+			model: Export{Range: Range{Offset: -1, Limit: 10}},
+			want:  map[string][]string{"offset": {"INVALID_VALUE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Pagination_BindParams(t *testing.T) {
+	type ListUsers struct {
+		Page
+	}
+
+	request := ListUsers{Page: DefaultPage()}
+	structs.BindParams(&request, structs.QUERY_TAG_KEYWORD, map[string][]string{"page": {"3"}})
+
+	if request.Number != 3 || request.Limit != DEFAULT_PAGE_LIMIT || request.Offset() != 40 {
+		t.Errorf("BindParams() populated %+v", request)
+	}
+}
+
+func Test_Cursor_Decode(t *testing.T) {
+	cursor := DefaultCursor().Next([]byte(`{"id":1}`))
+
+	if got, err := cursor.Decode(); err != nil || string(got) != `{"id":1}` {
+		t.Errorf("Cursor.Decode() = %s, %v", got, err)
+	}
+}
+
+func Test_IsBase64URL(t *testing.T) {
+	for value, want := range map[string]bool{"": true, "eyJpZCI6MX0": true, "eyJpZCI6MX0=": true, "a+b/": false, "not base64!": false} {
+		if got := IsBase64URL(value); got != want {
+			t.Errorf("IsBase64URL(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
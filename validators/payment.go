@@ -0,0 +1,102 @@
+package validators
+
+import (
+	"strings"
+)
+
+var (
+	// Characters allowed between the digits of a formatted card number, as in `4111 1111 1111 1111`.
+	cardSeparators = strings.NewReplacer(" ", "", "-", "")
+
+	// Lengths of the IBANs of each country, keyed by their ISO 3166-1 alpha-2 codes, as in the IBAN registry.
+	ibanLengths = map[string]int{
+		"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+		"BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29,
+		"ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28,
+		"HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+		"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+		"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29,
+		"RO": 24, "RS": 22, "SA": 24, "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+		"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+	}
+)
+
+// Returns `true` if value is a payment card number: 12 to 19 digits, optionally grouped by spaces or hyphens,
+// whose last digit is the Luhn check digit of the others.
+//
+// Usage:
+//
+//	IsCreditCard("4111 1111 1111 1111") // -> true
+//	IsCreditCard("4111 1111 1111 1112") // -> false
+func IsCreditCard(value string) bool {
+	number := cardSeparators.Replace(value)
+	if len(number) < 12 || len(number) > 19 || !isDigits(number) {
+		return false
+	}
+
+	return IsLuhn(number)
+}
+
+// Returns `true` if value is a non-empty string of digits passing the Luhn (mod 10) checksum,
+// as used by payment cards and IMEIs.
+//
+// Usage:
+//
+//	IsLuhn("79927398713") // -> true
+//	IsLuhn("79927398710") // -> false
+func IsLuhn(value string) bool {
+	if !isDigits(value) {
+		return false
+	}
+
+	sum := 0
+	for i := len(value) - 1; i >= 0; i-- {
+		digit := int(value[i] - '0')
+
+		// Every second digit, starting from the check digit, is doubled
+		if (len(value)-i)%2 == 0 {
+			if digit *= 2; digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// Returns `true` if value is an International Bank Account Number (IBAN), as in `GB82 WEST 1234 5698 7654 32`.
+// Spaces are ignored and letters are case-insensitive. The length must match the one of its country,
+// and the check digits must pass the mod-97 checksum (ISO 7064).
+//
+// Usage:
+//
+//	IsIBAN("GB82 WEST 1234 5698 7654 32") // -> true
+//	IsIBAN("GB83 WEST 1234 5698 7654 32") // -> false
+func IsIBAN(value string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+
+	if len(iban) < 4 || !isDigits(iban[2:4]) {
+		return false
+	}
+
+	if length, ok := ibanLengths[iban[:2]]; !ok || len(iban) != length {
+		return false
+	}
+
+	// The country code and check digits are moved to the end, and letters are replaced by numbers (A = 10, ..., Z = 35)
+	remainder := 0
+	for _, c := range iban[4:] + iban[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+
+	return remainder == 1
+}
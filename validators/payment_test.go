@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_IsCreditCard(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "4111111111111111", want: true},
+		{value: "4111 1111 1111 1111", want: true},
+		{value: "5500-0000-0000-0004", want: true},
+		{value: "378282246310005", want: true},
+		{value: "4111111111111112", want: false},
+		{value: "4111.1111.1111.1111", want: false},
+		{value: "41111111111", want: false},
+		{value: "41111111111111111111", want: false},
+		{value: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsCreditCard(tt.value); got != tt.want {
+				t.Errorf("IsCreditCard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsLuhn(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "79927398713", want: true},
+		{value: "0", want: true},
+		{value: "79927398710", want: false},
+		{value: "7992739871a", want: false},
+		{value: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsLuhn(tt.value); got != tt.want {
+				t.Errorf("IsLuhn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsIBAN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "GB82WEST12345698765432", want: true},
+		{value: "GB82 WEST 1234 5698 7654 32", want: true},
+		{value: "gb82 west 1234 5698 7654 32", want: true},
+		{value: "DE89370400440532013000", want: true},
+		{value: "BR1800360305000010009795493C1", want: true},
+		{value: "NO9386011117947", want: true},
+		{value: "GB83WEST12345698765432", want: false},
+		{value: "GB82WEST1234569876543", want: false},
+		{value: "XX82WEST12345698765432", want: false},
+		{value: "GBAAWEST12345698765432", want: false},
+		{value: "GB82-WEST-1234-5698-7654-32", want: false},
+		{value: "GB", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsIBAN(tt.value); got != tt.want {
+				t.Errorf("IsIBAN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Payment(t *testing.T) {
+	type Payment struct {
+		Card     string   `json:"card" validate:"creditcard"`
+		Account  *string  `json:"account" validate:"iban"`
+		Accounts []string `json:"accounts" validate:"iban"`
+		Amount   int      `json:"amount" validate:"creditcard"`
+	}
+
+	account := "DE89 3704 0044 0532 0130 00"
+
+	tests := []struct {
+		name  string
+		model Payment
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Payment{Card: "4111 1111 1111 1111", Account: &account, Accounts: []string{"GB82WEST12345698765432"}},
+			want:  map[string][]string{"amount": {"INVALID_TYPE"}},
+		},
+		{
+			name:  "invalid",
+			model: Payment{Card: "4111 1111 1111 1112", Accounts: []string{"GB82WEST12345698765432", "GB83WEST12345698765432"}},
+			want: map[string][]string{
+				"card":        {"INVALID_FORMAT"},
+				"account":     {"INVALID_FORMAT"},
+				"accounts[1]": {"INVALID_FORMAT"},
+				"amount":      {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
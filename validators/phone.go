@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The numbering plan of a region, as used to interpret its national phone numbers.
+type phoneRegion struct {
+	// The country calling code, as in `55` for Brazil.
+	code string
+
+	// The prefix dialed before national numbers within the region (i.e. `0`), which is not part of the number itself.
+	trunk string
+
+	// Bounds of the number of digits of national (significant) numbers.
+	min, max int
+}
+
+var (
+	e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+	// Characters allowed between the digits of a formatted phone number, as in `+55 (11) 98765-4321`.
+	phoneSeparators = strings.NewReplacer(" ", "", ".", "", "-", "", "/", "", "(", "", ")", "")
+
+	// Numbering plans of the regions accepted as hints by the `phone` rule, keyed by their ISO 3166-1 alpha-2 codes.
+	phoneRegions = map[string]phoneRegion{
+		"AO": {code: "244", min: 9, max: 9},
+		"AR": {code: "54", trunk: "0", min: 10, max: 11},
+		"AT": {code: "43", trunk: "0", min: 4, max: 13},
+		"AU": {code: "61", trunk: "0", min: 9, max: 9},
+		"BE": {code: "32", trunk: "0", min: 8, max: 9},
+		"BR": {code: "55", trunk: "0", min: 10, max: 11},
+		"CA": {code: "1", trunk: "1", min: 10, max: 10},
+		"CH": {code: "41", trunk: "0", min: 9, max: 9},
+		"CL": {code: "56", min: 9, max: 9},
+		"CN": {code: "86", trunk: "0", min: 7, max: 11},
+		"CO": {code: "57", min: 10, max: 10},
+		"DE": {code: "49", trunk: "0", min: 6, max: 13},
+		"DK": {code: "45", min: 8, max: 8},
+		"ES": {code: "34", min: 9, max: 9},
+		"FR": {code: "33", trunk: "0", min: 9, max: 9},
+		"GB": {code: "44", trunk: "0", min: 9, max: 10},
+		"IE": {code: "353", trunk: "0", min: 7, max: 9},
+		"IN": {code: "91", trunk: "0", min: 10, max: 10},
+		"IT": {code: "39", min: 6, max: 11},
+		"JP": {code: "81", trunk: "0", min: 9, max: 10},
+		"KE": {code: "254", trunk: "0", min: 9, max: 9},
+		"KR": {code: "82", trunk: "0", min: 8, max: 10},
+		"MX": {code: "52", min: 10, max: 10},
+		"MZ": {code: "258", min: 8, max: 9},
+		"NG": {code: "234", trunk: "0", min: 8, max: 10},
+		"NL": {code: "31", trunk: "0", min: 9, max: 9},
+		"NO": {code: "47", min: 8, max: 8},
+		"NZ": {code: "64", trunk: "0", min: 8, max: 10},
+		"PE": {code: "51", trunk: "0", min: 8, max: 9},
+		"PL": {code: "48", min: 9, max: 9},
+		"PT": {code: "351", min: 9, max: 9},
+		"RU": {code: "7", trunk: "8", min: 10, max: 10},
+		"SE": {code: "46", trunk: "0", min: 7, max: 9},
+		"US": {code: "1", trunk: "1", min: 10, max: 10},
+		"UY": {code: "598", trunk: "0", min: 8, max: 8},
+		"ZA": {code: "27", trunk: "0", min: 9, max: 9},
+	}
+)
+
+// Returns `true` if value is a phone number in the E.164 format: a `+` followed by the country calling code
+// and the subscriber number (up to 15 digits in total), without any formatting.
+//
+// Usage:
+//
+//	IsE164("+5511987654321")    // -> true
+//	IsE164("+55 11 98765-4321") // -> false
+func IsE164(value string) bool {
+	return e164Pattern.MatchString(value)
+}
+
+// Returns `true` if value is a phone number, formatted or not, as accepted by `NormalizePhone`.
+//
+// Usage:
+//
+//	IsPhone("+55 (11) 98765-4321", "")   // -> true
+//	IsPhone("(11) 98765-4321", "BR")     // -> true
+//	IsPhone("(11) 98765-4321", "")       // -> false
+func IsPhone(value string, region string) bool {
+	_, ok := NormalizePhone(value, region)
+	return ok
+}
+
+// Returns the phone number in the E.164 format. Spaces, dots, hyphens, slashes and parentheses are removed.
+//
+// International numbers start with `+` or with the international prefix `00`.
+// Any other number is a national number of the given region (i.e. `BR`), whose trunk prefix (usually `0`) is removed.
+// The length of national numbers is checked against the numbering plan of the region.
+//
+// Returns `false` if the number is invalid, or if it is a national number and the region is either empty or unknown.
+//
+// Usage:
+//
+//	NormalizePhone("+55 (11) 98765-4321", "") // -> "+5511987654321", true
+//	NormalizePhone("011 98765-4321", "BR")    // -> "+5511987654321", true
+//	NormalizePhone("(415) 555-2671", "US")    // -> "+14155552671", true
+func NormalizePhone(value string, region string) (string, bool) {
+	number := phoneSeparators.Replace(value)
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		plan, ok := phoneRegions[strings.ToUpper(region)]
+		if !ok || !isDigits(number) {
+			return "", false
+		}
+
+		if plan.trunk != "" && strings.HasPrefix(number, plan.trunk) && len(number)-len(plan.trunk) >= plan.min {
+			number = number[len(plan.trunk):]
+		}
+
+		if len(number) < plan.min || len(number) > plan.max {
+			return "", false
+		}
+
+		number = plan.code + number
+	}
+
+	if !IsE164("+" + number) {
+		return "", false
+	}
+
+	return "+" + number, true
+}
+
+// Returns `true` if value is not empty and only contains decimal digits.
+func isDigits(value string) bool {
+	_, ok := parseNumericString(value, DIGITS)
+	return ok
+}
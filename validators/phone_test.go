@@ -0,0 +1,119 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_NormalizePhone(t *testing.T) {
+	tests := []struct {
+		value  string
+		region string
+		want   string
+		wantOk bool
+	}{
+		{value: "+5511987654321", want: "+5511987654321", wantOk: true},
+		{value: "+55 (11) 98765-4321", want: "+5511987654321", wantOk: true},
+		{value: "00 351 912 345 678", region: "BR", want: "+351912345678", wantOk: true},
+		{value: "(11) 98765-4321", region: "BR", want: "+5511987654321", wantOk: true},
+		{value: "011 98765-4321", region: "br", want: "+5511987654321", wantOk: true},
+		{value: "(415) 555-2671", region: "US", want: "+14155552671", wantOk: true},
+		{value: "1 415 555 2671", region: "US", want: "+14155552671", wantOk: true},
+		{value: "912 345 678", region: "PT", want: "+351912345678", wantOk: true},
+		{value: "(11) 98765-4321", wantOk: false},
+		{value: "(11) 98765-4321", region: "XX", wantOk: false},
+		{value: "98765-4321", region: "BR", wantOk: false},
+		{value: "+0 11 98765-4321", wantOk: false},
+		{value: "+55 11 98765-4321 ext. 2", wantOk: false},
+		{value: "+1234567890123456", wantOk: false},
+		{value: "", region: "BR", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value+tt.region, func(t *testing.T) {
+			got, ok := NormalizePhone(tt.value, tt.region)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("NormalizePhone() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+
+			if IsPhone(tt.value, tt.region) != tt.wantOk {
+				t.Errorf("IsPhone() = %v, want %v", !tt.wantOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_IsE164(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "+5511987654321", want: true},
+		{value: "+14155552671", want: true},
+		{value: "+55 11 98765-4321", want: false},
+		{value: "5511987654321", want: false},
+		{value: "+0511987654321", want: false},
+		{value: "+123", want: false},
+		{value: "+1234567890123456", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IsE164(tt.value); got != tt.want {
+				t.Errorf("IsE164() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Phone(t *testing.T) {
+	type Contact struct {
+		Mobile   string   `json:"mobile" validate:"e164"`
+		Phone    *string  `json:"phone" validate:"phone"`
+		Landline string   `json:"landline" validate:"phone=BR"`
+		Others   []string `json:"others" validate:"phone=PT"`
+		Fax      string   `json:"fax" validate:"phone=XX"`
+		Ext      int      `json:"ext" validate:"e164"`
+	}
+
+	phone, formatted := "+1 (415) 555-2671", "(11) 3333-4444"
+
+	tests := []struct {
+		name  string
+		model Contact
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Contact{Mobile: "+5511987654321", Phone: &phone, Landline: formatted, Others: []string{"912 345 678", "+5511987654321"}},
+			want: map[string][]string{
+				"fax": {"UNEXPECTED_ERROR"},
+				"ext": {"INVALID_TYPE"},
+			},
+		},
+		{
+			name:  "invalid",
+			model: Contact{Mobile: phone, Phone: &formatted, Landline: "3333-4444", Others: []string{"912 345 678", "12"}},
+			want: map[string][]string{
+				"mobile":    {"INVALID_FORMAT"},
+				"phone":     {"INVALID_FORMAT"},
+				"landline":  {"INVALID_FORMAT"},
+				"others[1]": {"INVALID_FORMAT"},
+				"fax":       {"UNEXPECTED_ERROR"},
+				"ext":       {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	warnings := Lint(Contact{}, ValidationOptions{})
+	want := []LintWarning{{Path: "fax", Rule: "phone=XX", Message: "unknown phone region: XX"}}
+
+	if !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Lint() = %v, want %v", warnings, want)
+	}
+}
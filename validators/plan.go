@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// Validates a JSON document (decoded into a map) following the plan of a model, without access to the model itself.
+// This allows services sharing only the contract of a model to validate it the same way. See `structs.AttributePlan`.
+//
+// Values are checked as found in the document, so numbers are validated as `float64`.
+// Fields missing from the document are validated as the zero values of their kinds, or as nil pointers if they are nullable.
+// Values that do not have the kinds of their fields (i.e. a string found in an `int` field) get an `INVALID_TYPE` error instead.
+//
+// Usage:
+//
+//	// In the service owning the model
+//	data, _ := json.Marshal(structs.NewAttributePlan(Person{}))
+//
+//	// In the service receiving the documents
+//	plan, _ := structs.LoadAttributePlan(data)
+//	errs := ValidatePlan(plan, document, ValidationOptions{}) // -> {name: ["INVALID_LENGTH"]}
+func ValidatePlan(plan structs.AttributePlan, document map[string]any, options ValidationOptions) (validations map[string][]string) {
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				validations = map[string][]string{options.KeyPrefix + recoveredPath(r): {options.errorCode("unexpected")}}
+			}
+		}()
+	}
+
+	var mismatched structs.StructAttributes
+	result := validateAttributes(nil, nil, func() (attributes structs.StructAttributes) {
+		attributes, mismatched = plan.CheckedAttributes(document, options.attributeOptions())
+		return attributes
+	}, options)
+
+	// Values that do not have the kinds of their fields are not validated by the rules of their fields
+	for _, attr := range mismatched {
+		errs := []string{options.errorCode("type")}
+		result = append(result, options.validationErrors(attr, "type", "", errs)...)
+
+		if options.OnError != nil {
+			options.OnError(options.observedAttribute(attr), errs)
+		}
+	}
+
+	return options.afterValidation(result.Map())
+}
@@ -0,0 +1,134 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ValidatePlan(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"min=3"`
+	}
+
+	type Customer struct {
+		Id        string    `json:"id" validate:"uuid"`
+		Name      string    `json:"name" validate:"min=2"`
+		Age       *int      `json:"age" validate:"range=18.."`
+		Emails    []string  `json:"emails" validate:"min=1,email"`
+		Addresses []Address `json:"addresses"`
+	}
+
+	data, _ := json.Marshal(structs.NewAttributePlan(Customer{}))
+	plan, err := structs.LoadAttributePlan(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     map[string][]string
+	}{
+		{
+			name:     "valid",
+			document: `{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "Leo", "age": 30, "emails": ["leo@example.com"], "addresses": [{"street": "Main St"}]}`,
+			want:     map[string][]string{},
+		},
+		{
+			name:     "invalid",
+			document: `{"id": "1", "name": "L", "age": 17, "emails": ["leo@example.com", "leo"], "addresses": [{"street": "St"}]}`,
+			want: map[string][]string{
+				"id":                  {"INVALID_FORMAT"},
+				"name":                {"INVALID_LENGTH"},
+				"age":                 {"INVALID_VALUE"},
+				"emails[1]":           {"INVALID_FORMAT"},
+				"addresses[0].street": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name:     "missing values",
+			document: `{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "age": null}`,
+			want: map[string][]string{
+				"name":   {"INVALID_LENGTH"},
+				"age":    {"INVALID_VALUE"},
+				"emails": {"INVALID_LENGTH"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document map[string]any
+			_ = json.Unmarshal([]byte(tt.document), &document)
+
+			got := ValidatePlan(plan, document, ValidationOptions{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePlan() = %v, want %v", got, tt.want)
+			}
+
+			// Matches the validation of the model itself
+			var customer Customer
+			_ = json.Unmarshal([]byte(tt.document), &customer)
+
+			if want := Validate(customer, ValidationOptions{}); !reflect.DeepEqual(got, want) {
+				t.Errorf("ValidatePlan() = %v, Validate() = %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_ValidatePlan_MismatchedKinds(t *testing.T) {
+	type Contact struct {
+		Email string `json:"email" validate:"email"`
+	}
+
+	type Member struct {
+		Age      int       `json:"age" validate:"range=18..30"`
+		Active   bool      `json:"active"`
+		Contact  Contact   `json:"contact"`
+		Items    []Contact `json:"items" validate:"min=1"`
+		JoinedAt time.Time `json:"joined_at"`
+	}
+
+	plan := structs.NewAttributePlan(Member{})
+
+	tests := []struct {
+		name     string
+		document string
+		want     map[string][]string
+	}{
+		{
+			name:     "matching kinds",
+			document: `{"age": 20, "active": true, "contact": {"email": "leo@example.com"}, "items": [{"email": "leo@example.com"}], "joined_at": "2022-06-21T00:00:00Z"}`,
+			want:     map[string][]string{},
+		},
+		{
+			name:     "string in a numeric field",
+			document: `{"age": "old", "active": true, "items": [{"email": "leo@example.com"}]}`,
+			want:     map[string][]string{"age": {"INVALID_TYPE"}, "contact.email": {"INVALID_FORMAT"}},
+		},
+		{
+			name:     "values in struct and slice fields",
+			document: `{"age": 20, "active": "yes", "contact": "str", "items": {"a": 1}}`,
+			want:     map[string][]string{"active": {"INVALID_TYPE"}, "contact": {"INVALID_TYPE"}, "items": {"INVALID_TYPE"}},
+		},
+		{
+			name:     "values in nested fields",
+			document: `{"age": 20, "contact": {"email": 1}, "items": ["leo@example.com", {"email": []}], "joined_at": 1}`,
+			want:     map[string][]string{"contact.email": {"INVALID_TYPE"}, "items[0]": {"INVALID_TYPE"}, "items[1].email": {"INVALID_TYPE"}, "joined_at": {"INVALID_TYPE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document map[string]any
+			_ = json.Unmarshal([]byte(tt.document), &document)
+
+			if got := ValidatePlan(plan, document, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
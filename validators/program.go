@@ -0,0 +1,234 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// A rule of a validation tag, parsed once and shared by every validation of the fields declaring it.
+type compiledRule struct {
+	// The rule as it is reported by `Trace`, as in `min=2`.
+	raw string
+
+	// The name of the rule, as written in the tag (aliases are resolved when validating).
+	// Element rules keep their `structs.EACH_RULE_PREFIX`.
+	name string
+
+	value string
+
+	// The rules this one stands for if it turns out to be a `len` or `range` shorthand, as in [min=1 max=5] for `len=1..5`.
+	// Since aliases may differ between validations, this is computed for every rule. See `expandRules`.
+	bounds []compiledRule
+}
+
+// Identifies the tags a program was compiled from.
+type programKey struct {
+	tag            reflect.StructTag
+	additionalTags string
+}
+
+// The validation program of a type: the compiled rules of its fields and of the fields of the types nested in it.
+type typeProgram struct {
+	// Compiled rules keyed by the tags they were compiled from. It is never modified once the program is stored.
+	rules map[reflect.StructTag][]compiledRule
+
+	// Whether values of the type have nothing to validate: none of the fields found in it declare rules,
+	// and none of the types found in it are `Validatable` or interfaces (whose values are only known when validating).
+	empty bool
+}
+
+// Identifies the type a program was compiled for.
+type typeProgramKey struct {
+	t              reflect.Type
+	additionalTags string
+}
+
+var (
+	// Compiled rules of the validation tags, keyed by `programKey`. See `ValidationOptions.program`.
+	rulePrograms sync.Map
+
+	// Programs of the validated types, keyed by `typeProgramKey`. See `ValidationOptions.typeProgram`.
+	typePrograms sync.Map
+)
+
+// Parses the validation rules of the given models (structs or pointers to them) and of the structs nested in them,
+// so that the first validation of each type does not pay for it. Rules are compiled on demand otherwise.
+// Rules of the tags listed in `ValidationOptions.AdditionalTags` (and of their aliases, see `TagAliases`) are compiled as well.
+//
+// Returns an error wrapping `structs.ErrInvalidRuleExpression` for the first malformed rule found, prefixed by the path of its field.
+// Validations read malformed rules the best they can, so this is the way of finding them before they are validated. See `Lint`.
+//
+// Usage:
+//
+//	func init() {
+//		if err := validators.CompileRules(ValidationOptions{}, Person{}, Address{}); err != nil {
+//			panic(err)
+//		}
+//	}
+func CompileRules(options ValidationOptions, models ...any) error {
+	visited := map[reflect.Type]bool{}
+
+	for _, model := range models {
+		if err := options.compileType(reflect.TypeOf(model), "", visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Compiles the rules of the fields of the given type (and of the structs nested in it). See `CompileRules`.
+func (options ValidationOptions) compileType(t reflect.Type, scope string, visited map[reflect.Type]bool) error {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct || visited[t] {
+		return nil
+	}
+
+	visited[t] = true
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+
+		path := strings.TrimPrefix(scope+"."+structs.GetJSONTagValue(sf), ".")
+
+		for _, keyword := range append([]string{VALIDATION_TAG_KEYWORD}, options.additionalTags()...) {
+			for _, rule := range structs.GetTagRules(sf, keyword) {
+				if _, err := structs.ParseRule(rule); rule != "" && err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+			}
+		}
+
+		options.program(sf)
+
+		if err := options.compileType(sf.Type, path, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the compiled rules of the field: the ones of the validation tag followed by the ones of the additional tags.
+// See `ValidationOptions.additionalTags`.
+// Tags are only parsed the first time they are seen, so repeated validations of the same types skip string splitting.
+func (options ValidationOptions) program(field reflect.StructField) []compiledRule {
+	additionalTags := options.additionalTags()
+
+	key := programKey{tag: field.Tag, additionalTags: strings.Join(additionalTags, ",")}
+	if program, ok := rulePrograms.Load(key); ok {
+		return program.([]compiledRule)
+	}
+
+	rules := structs.GetTagRules(field, VALIDATION_TAG_KEYWORD)
+	for _, tag := range additionalTags {
+		rules = append(rules[:len(rules):len(rules)], structs.GetTagRules(field, tag)...)
+	}
+
+	program := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		name, value := ruleParts(rule)
+		program = append(program, compiledRule{raw: rule, name: name, value: value, bounds: ruleBounds(value)})
+	}
+
+	stored, _ := rulePrograms.LoadOrStore(key, program)
+	return stored.([]compiledRule)
+}
+
+// Returns the program of the given type, compiling it the first time the type is seen.
+// Validations look their rules up in it, rather than in the programs of each tag, and skip types with nothing to validate.
+func (options ValidationOptions) typeProgram(t reflect.Type) *typeProgram {
+	key := typeProgramKey{t: t, additionalTags: strings.Join(options.additionalTags(), ",")}
+	if program, ok := typePrograms.Load(key); ok {
+		return program.(*typeProgram)
+	}
+
+	program := &typeProgram{rules: map[reflect.StructTag][]compiledRule{}}
+	program.empty = !options.collectRules(t, program.rules, map[reflect.Type]bool{})
+
+	stored, _ := typePrograms.LoadOrStore(key, program)
+	return stored.(*typeProgram)
+}
+
+// Compiles the rules of the fields of the given type (and of the types nested in it) into `rules`.
+// Returns `true` if values of the type have anything to validate. See `typeProgram.empty`.
+func (options ValidationOptions) collectRules(t reflect.Type, rules map[reflect.StructTag][]compiledRule, visited map[reflect.Type]bool) (validated bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+
+	validated = reflect.PointerTo(t).Implements(validatableType)
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+	default:
+		return validated
+	}
+
+	// The fields of recursive types are only compiled once
+	if visited[t] {
+		return validated
+	}
+
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		program := options.program(sf)
+		rules[sf.Tag] = program
+
+		if options.collectRules(sf.Type, rules, visited) || len(program) != 0 {
+			validated = true
+		}
+	}
+
+	return validated
+}
+
+// Returns the compiled rules of the field, looking them up in the program of the validated type first.
+// Fields missing from it (i.e. the ones of the elements of slices, whose tags only keep the inherited rules) are compiled on demand.
+func (p *typeProgram) fieldRules(field reflect.StructField, options ValidationOptions) []compiledRule {
+	if p != nil {
+		if rules, ok := p.rules[field.Tag]; ok {
+			return rules
+		}
+	}
+
+	return options.program(field)
+}
+
+// Returns the rules a `len` or `range` shorthand with the given value stands for.
+//
+// Usage:
+//
+//	ruleBounds("1..5") // -> [min=1 max=5]
+//	ruleBounds("..5")  // -> [max=5]
+//	ruleBounds("3")    // -> [eq=3]
+func ruleBounds(value string) (bounds []compiledRule) {
+	lower, upper, isRange := strings.Cut(value, "..")
+	if !isRange {
+		return []compiledRule{{raw: EQUAL + "=" + value, name: EQUAL, value: value}}
+	}
+
+	if lower != "" {
+		bounds = append(bounds, compiledRule{raw: MIN + "=" + lower, name: MIN, value: lower})
+	}
+
+	if upper != "" {
+		bounds = append(bounds, compiledRule{raw: MAX + "=" + upper, name: MAX, value: upper})
+	}
+
+	return bounds
+}
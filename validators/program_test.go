@@ -0,0 +1,176 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+type programAddress struct {
+	Street string `json:"street" validate:"required,len=3..40"`
+	Zip    string `json:"zip" binding:"regex(^\\d{5}$)"`
+}
+
+type programPerson struct {
+	Name      string           `json:"name" validate:"required,min=2"`
+	Emails    []string         `json:"emails" validate:"len=1..3,each:email"`
+	Addresses []programAddress `json:"addresses"`
+	Parent    *programPerson   `json:"parent"`
+}
+
+func Test_CompileRules(t *testing.T) {
+	if err := CompileRules(ValidationOptions{AdditionalTags: []string{"binding"}}, programPerson{}, &programAddress{}); err != nil {
+		t.Errorf("CompileRules() error = %v", err)
+	}
+
+	type Invalid struct {
+		Address struct {
+			Zip string `json:"zip" binding:"in='a"`
+		} `json:"address"`
+	}
+
+	// Rules of additional tags are only compiled when the tags are listed
+	if err := CompileRules(ValidationOptions{}, Invalid{}); err != nil {
+		t.Errorf("CompileRules() error = %v", err)
+	}
+
+	err := CompileRules(ValidationOptions{AdditionalTags: []string{"binding"}}, Invalid{})
+	if !errors.Is(err, structs.ErrInvalidRuleExpression) || err.Error() != `address.zip: invalid rule expression: unterminated quote: "in='a"` {
+		t.Errorf("CompileRules() error = %v, want %v", err, structs.ErrInvalidRuleExpression)
+	}
+}
+
+func Test_ValidationOptions_program(t *testing.T) {
+	sf, _ := reflect.TypeOf(programAddress{}).FieldByName("Street")
+
+	want := compiledRule{
+		raw:    "len=3..40",
+		name:   LENGTH,
+		value:  "3..40",
+		bounds: []compiledRule{{raw: "min=3", name: MIN, value: "3"}, {raw: "max=40", name: MAX, value: "40"}},
+	}
+
+	program := ValidationOptions{}.program(sf)
+	if len(program) != 2 || program[0].name != REQUIRED || !reflect.DeepEqual(program[1], want) {
+		t.Errorf("program() = %+v, want [required %+v]", program, want)
+	}
+
+	// Programs are compiled once and shared between validations
+	if again := (ValidationOptions{}).program(sf); &again[0] != &program[0] {
+		t.Errorf("program() compiled the rules again")
+	}
+
+	// Rules of additional tags follow the ones of the validation tag
+	zip, _ := reflect.TypeOf(programAddress{}).FieldByName("Zip")
+	if got := (ValidationOptions{AdditionalTags: []string{"binding"}}).program(zip); len(got) != 1 || got[0].name != REGEX || got[0].value != `^\d{5}$` {
+		t.Errorf("program() = %+v, want the rules of the binding tag", got)
+	}
+}
+
+func Test_ValidationOptions_typeProgram(t *testing.T) {
+	type Plain struct {
+		Name  string            `json:"name"`
+		Tags  []string          `json:"tags"`
+		Extra map[string]string `json:"extra"`
+		Next  *Plain            `json:"next"`
+	}
+
+	type Dynamic struct {
+		Value any `json:"value"`
+	}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		model   any
+		empty   bool
+	}{
+		{name: "no rules", model: Plain{}, empty: true},
+		{name: "pointer without rules", model: &Plain{}, empty: true},
+		{name: "slice without rules", model: []Plain{}, empty: true},
+		{name: "nested rules", model: programPerson{}, empty: false},
+		{name: "rules of additional tags", model: programAddress{}, options: ValidationOptions{AdditionalTags: []string{"binding"}}, empty: false},
+		{name: "interfaces", model: Dynamic{}, empty: false},
+		{name: "validatable", model: period{}, empty: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := tt.options.typeProgram(reflect.TypeOf(tt.model))
+			if program.empty != tt.empty {
+				t.Errorf("typeProgram().empty = %v, want %v", program.empty, tt.empty)
+			}
+
+			// Programs are compiled once per type
+			if again := tt.options.typeProgram(reflect.TypeOf(tt.model)); again != program {
+				t.Errorf("typeProgram() compiled the type again")
+			}
+		})
+	}
+
+	// Types with nothing to validate are not walked, unless the attributes are observed
+	walked := 0
+	Validate(Plain{Name: "Leo"}, ValidationOptions{})
+	Validate(Plain{Name: "Leo"}, ValidationOptions{BeforeAttribute: func(attribute structs.StructAttribute) structs.StructAttribute {
+		walked++
+		return attribute
+	}})
+	if walked == 0 {
+		t.Errorf("Validate() did not walk the attributes observed by BeforeAttribute")
+	}
+}
+
+func Test_Validate_CompiledRules(t *testing.T) {
+	person := programPerson{
+		Name:      "L",
+		Emails:    []string{"a@b.co", "?"},
+		Addresses: []programAddress{{Street: "Rua", Zip: "123"}},
+	}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "defaults",
+			options: ValidationOptions{},
+			want:    map[string][]string{"name": {"INVALID_LENGTH"}, "emails[1]": {"INVALID_FORMAT"}},
+		},
+		{
+			name:    "additional tags",
+			options: ValidationOptions{AdditionalTags: []string{"binding"}},
+			want:    map[string][]string{"name": {"INVALID_LENGTH"}, "emails[1]": {"INVALID_FORMAT"}, "addresses[0].zip": {"INVALID_FORMAT"}},
+		},
+		{
+			name:    "skipped shorthands",
+			options: ValidationOptions{SkipRules: []string{MIN}},
+			want:    map[string][]string{"emails[1]": {"INVALID_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The same programs are used by every validation, whatever the options
+			for i := 0; i < 2; i++ {
+				if got := Validate(person, tt.options); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Validate() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Benchmark_Validate(b *testing.B) {
+	person := programPerson{
+		Name:      "Leonardo",
+		Emails:    []string{"a@b.co", "c@d.co"},
+		Addresses: []programAddress{{Street: "Rua Augusta", Zip: "01305"}, {Street: "Avenida Paulista", Zip: "01311"}},
+		Parent:    &programPerson{Name: "Maria", Emails: []string{"e@f.co"}},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Validate(person, ValidationOptions{})
+	}
+}
@@ -0,0 +1,108 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+type (
+	// A single error found while validating a model.
+	ValidationError struct {
+		// The full name of the attribute, prefixed by `ValidationOptions.KeyPrefix`. See `structs.StructAttribute.FullName()`.
+		// Errors that do not belong to an attribute are reported under `_`.
+		Path string `json:"path"`
+
+		// The canonical name of the rule the attribute failed (i.e. `min`), once aliases are resolved.
+		// This is empty for errors that are not caused by a rule, like a recovered panic.
+		Rule string `json:"rule,omitempty"`
+
+		// The value of the rule, as in `2` for `min=2`.
+		RuleValue string `json:"rule_value,omitempty"`
+
+		// The value of the attribute. The values of sensitive attributes (see `structs.SENSITIVE_TAG_KEYWORD`)
+		// are always replaced by `structs.REDACTED_VALUE`, since results are commonly logged or returned to clients.
+		Value any `json:"value,omitempty"`
+
+		// The error code. See `Errors`.
+		Code string `json:"code"`
+
+		// A human-readable description of the error, in the locale set in `ValidationOptions.Locale`. See `Translate`.
+		Message string `json:"message,omitempty"`
+	}
+
+	// The errors found while validating a model, in the order they were found.
+	ValidationResult []ValidationError
+)
+
+// Validates a model like `Validate`, but keeps the context of each error: the rule that caused it and the value that failed it.
+// The `AfterValidate` hook is not run, since it works on the map returned by `Validate`.
+// Use `ValidationResult.Map()` to obtain that map, or `ValidationResult.Messages()` for localized messages (see `ValidationOptions.Locale`).
+//
+// Usage:
+//
+//	type Person struct {
+//		Name string `json:"name" validate:"min=2"`
+//	}
+//
+//	ValidateResult(Person{Name: "L"}, ValidationOptions{})
+//	// -> [{Path: name, Rule: min, RuleValue: 2, Value: L, Code: INVALID_LENGTH}]
+func ValidateResult(model any, options ValidationOptions) ValidationResult {
+	result := validate(model, options)
+
+	if options.Collector != nil {
+		options.Collector.Observe(result.Map())
+	}
+
+	return result
+}
+
+// Returns the error codes keyed by the paths of the attributes, as returned by `Validate`.
+func (result ValidationResult) Map() map[string][]string {
+	validations := make(map[string][]string, len(result))
+
+	for _, err := range result {
+		validations[err.Path] = append(validations[err.Path], err.Code)
+	}
+
+	return validations
+}
+
+// Returns the errors found in the attribute with the given path.
+func (result ValidationResult) ForPath(path string) ValidationResult {
+	return structs.Filter(result, func(_ int, err ValidationError) bool { return err.Path == path })
+}
+
+func (result ValidationResult) Error() string {
+	return strings.Join(structs.Map(result, func(_ int, err ValidationError) string { return err.Error() }), "; ")
+}
+
+func (err ValidationError) Error() string {
+	return err.Path + ": " + err.Code
+}
+
+// Returns one error per code found in the attribute by the given rule.
+func (options ValidationOptions) validationErrors(attr structs.StructAttribute, rule string, ruleValue string, codes []string) ValidationResult {
+	path := options.KeyPrefix + attr.FullName()
+
+	var value any
+	if structs.IsSensitive(attr, options.SensitiveTag) {
+		value = structs.REDACTED_VALUE
+	} else if v, _ := structs.PointerElement(attr.Value); v.IsValid() && v.CanInterface() && v.Kind() != reflect.Pointer {
+		value = v.Interface()
+	}
+
+	return structs.Map(codes, func(_ int, code string) ValidationError {
+		return options.localized(ValidationError{Path: path, Rule: rule, RuleValue: ruleValue, Value: value, Code: code})
+	})
+}
+
+// Attaches the message of the error in the locale set in the options, if any.
+func (options ValidationOptions) localized(err ValidationError) ValidationError {
+	if options.Locale != "" {
+		err.Message = Translate(options.Locale, err)
+	}
+
+	return err
+}
@@ -0,0 +1,71 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_ValidateResult(t *testing.T) {
+	type Account struct {
+		Name     string   `json:"name" validate:"min=2"`
+		Emails   []string `json:"emails" validate:"is_email"`
+		Password string   `json:"password" validate:"min=8" sensitive:"true"`
+		Phone    string   `json:"phone" validate:"at_least_one_of=contact"`
+		Email    string   `json:"email" validate:"at_least_one_of=contact"`
+	}
+
+	model := Account{Name: "L", Emails: []string{"leo@example.com", "leo"}, Password: "secret"}
+	options := ValidationOptions{
+		RuleAliases: map[string]string{"is_email": EMAIL},
+		KeyPrefix:   "account.",
+	}
+
+	want := ValidationResult{
+		{Path: "account.name", Rule: MIN, RuleValue: "2", Value: "L", Code: "INVALID_LENGTH"},
+		{Path: "account.emails[1]", Rule: EMAIL, Value: "leo", Code: "INVALID_FORMAT"},
+		{Path: "account.password", Rule: MIN, RuleValue: "8", Value: structs.REDACTED_VALUE, Code: "INVALID_LENGTH"},
+		{Path: "account.phone", Rule: AT_LEAST_ONE_OF, RuleValue: "contact", Value: "", Code: "MISSING_ONE_OF"},
+		{Path: "account.email", Rule: AT_LEAST_ONE_OF, RuleValue: "contact", Value: "", Code: "MISSING_ONE_OF"},
+	}
+
+	got := ValidateResult(model, options)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ValidateResult() = %+v, want %+v", got, want)
+	}
+
+	if validations := Validate(model, options); !reflect.DeepEqual(got.Map(), validations) {
+		t.Errorf("Map() = %v, want %v", got.Map(), validations)
+	}
+
+	if errs := got.ForPath("account.emails[1]"); !reflect.DeepEqual(errs, want[1:2]) {
+		t.Errorf("ForPath() = %v, want %v", errs, want[1:2])
+	}
+
+	if msg, want := got[:2].Error(), "account.name: INVALID_LENGTH; account.emails[1]: INVALID_FORMAT"; msg != want {
+		t.Errorf("Error() = %v, want %v", msg, want)
+	}
+
+	// Sensitive values are masked even if `ExposeSensitive` is set
+	options.ExposeSensitive = true
+	if errs := ValidateResult(model, options).ForPath("account.password"); !reflect.DeepEqual(errs, want[2:3]) {
+		t.Errorf("ForPath() = %v, want %v", errs, want[2:3])
+	}
+
+	data, _ := json.Marshal(got[1])
+	if want := `{"path":"account.emails[1]","rule":"email","value":"leo","code":"INVALID_FORMAT"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func Test_ValidateResult_Valid(t *testing.T) {
+	type Account struct {
+		Name string `json:"name" validate:"min=2"`
+	}
+
+	if got := ValidateResult(Account{Name: "Leo"}, ValidationOptions{}); len(got) != 0 || got.Map() == nil {
+		t.Errorf("ValidateResult() = %v, want an empty result", got)
+	}
+}
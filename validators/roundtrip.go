@@ -0,0 +1,190 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// The literal name of the tag read by the JSON schema reflector (see `structs.JSONSchema`).
+const JSONSCHEMA_TAG_KEYWORD string = "jsonschema"
+
+// An inconsistency between the `json`, `jsonschema` and `validate` tags of a field.
+type Mismatch struct {
+	// The path of the field. See `structs.FieldDoc`.
+	Path string
+
+	Message string
+}
+
+// Checks that the `json`, `jsonschema` and `validate` tags of the given model (a struct or a pointer to one)
+// agree with each other, so that values encoded by the service can be decoded and validated by its clients.
+// This is meant to be used in tests, catching contract drift during code review.
+//
+// The following mismatches are reported:
+//   - fields required by the schema that are omitted from the JSON output when empty (`omitempty`).
+//   - fields ignored by encoding/json (`json:"-"` or unexported) that declare schema or validation rules.
+//   - `in` rules accepting values other than the ones of the schema `enum`.
+//   - `min`/`max` rules (and their shorthands) disagreeing with the bounds of the schema.
+//
+// Usage:
+//
+//	type User struct {
+//		Id   *string `json:"id,omitempty" jsonschema:"required"`
+//		Name string  `json:"name" validate:"min=2" jsonschema:"minLength=3"`
+//	}
+//
+//	CheckRoundTrip(User{})
+//	// -> [
+//	//	{Path: id, Message: "required by the schema but omitted from JSON when empty"},
+//	//	{Path: name, Message: "validate `min=2` does not match jsonschema `minLength=3`"},
+//	// ]
+func CheckRoundTrip(model any) []Mismatch {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return []Mismatch{}
+	}
+
+	return roundTripMismatches(t, "", map[reflect.Type]bool{})
+}
+
+func roundTripMismatches(t reflect.Type, scope string, visiting map[reflect.Type]bool) []Mismatch {
+	mismatches := []Mismatch{}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Recursive types are only checked once
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return mismatches
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for _, sf := range reflect.VisibleFields(t) {
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			continue
+		}
+
+		name := structs.GetJSONTagValue(sf)
+		path := strings.TrimPrefix(scope+"."+name, ".")
+
+		_, hasSchema := sf.Tag.Lookup(JSONSCHEMA_TAG_KEYWORD)
+		_, hasRules := sf.Tag.Lookup(VALIDATION_TAG_KEYWORD)
+
+		if name == "-" || !sf.IsExported() {
+			if hasSchema || hasRules {
+				mismatches = append(mismatches, Mismatch{Path: strings.TrimPrefix(scope+"."+sf.Name, "."), Message: "ignored by encoding/json but declares schema or validation rules"})
+			}
+
+			continue
+		}
+
+		schema := jsonSchemaTagValues(sf)
+
+		if _, required := schema["required"]; required && structs.Contains(structs.GetTagValues(sf, "json"), "omitempty") {
+			mismatches = append(mismatches, Mismatch{Path: path, Message: "required by the schema but omitted from JSON when empty"})
+		}
+
+		rules := map[string]string{}
+		for _, rule := range (ValidationOptions{}).expandRules(structs.GetTagRules(sf, VALIDATION_TAG_KEYWORD)) {
+			name, value := ruleParts(rule)
+			rules[name] = value
+		}
+
+		if accepted, ok := rules[IN]; ok && !isValueProvider(accepted) && len(schema["enum"]) != 0 {
+			values := strings.Split(accepted, "|")
+			enum := append([]string{}, schema["enum"]...)
+
+			sort.Strings(values)
+			sort.Strings(enum)
+
+			if !reflect.DeepEqual(values, enum) {
+				mismatches = append(mismatches, Mismatch{Path: path, Message: fmt.Sprintf("validate `in=%s` does not match jsonschema enum %v", accepted, schema["enum"])})
+			}
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		lower, upper := schemaBounds(ft)
+		for _, bound := range []struct{ rule, keyword string }{{MIN, lower}, {MAX, upper}, {EQUAL, lower}, {EQUAL, upper}} {
+			ruleValue, hasRule := rules[bound.rule]
+			schemaValues := schema[bound.keyword]
+
+			if !hasRule || bound.keyword == "" || len(schemaValues) == 0 {
+				continue
+			}
+
+			a, errA := strconv.ParseFloat(ruleValue, 64)
+			b, errB := strconv.ParseFloat(schemaValues[0], 64)
+
+			if errA != nil || errB != nil || a != b {
+				mismatches = append(mismatches, Mismatch{
+					Path:    path,
+					Message: fmt.Sprintf("validate `%s=%s` does not match jsonschema `%s=%s`", bound.rule, ruleValue, bound.keyword, schemaValues[0]),
+				})
+			}
+		}
+
+		if ft == structs.RawMessageType || ft == uuidType || ft == timeType {
+			continue
+		}
+
+		switch ft.Kind() {
+		case reflect.Struct:
+			mismatches = append(mismatches, roundTripMismatches(ft, path, visiting)...)
+		case reflect.Slice, reflect.Array:
+			mismatches = append(mismatches, roundTripMismatches(ft.Elem(), path+"[]", visiting)...)
+		}
+	}
+
+	return mismatches
+}
+
+// Returns the values of the `jsonschema` tag of the field, keyed by their names.
+// Names without values (i.e. `required`) are mapped to an empty list.
+func jsonSchemaTagValues(sf reflect.StructField) map[string][]string {
+	values := map[string][]string{}
+
+	if _, ok := sf.Tag.Lookup(JSONSCHEMA_TAG_KEYWORD); !ok {
+		return values
+	}
+
+	for _, item := range structs.GetTagValues(sf, JSONSCHEMA_TAG_KEYWORD) {
+		name, value, hasValue := strings.Cut(item, "=")
+		if !hasValue {
+			values[name] = []string{}
+			continue
+		}
+
+		values[name] = append(values[name], value)
+	}
+
+	return values
+}
+
+// Returns the schema keywords holding the lower and upper bounds of values of the given type,
+// as checked by the `min` and `max` rules.
+func schemaBounds(t reflect.Type) (lower string, upper string) {
+	switch t.Kind() {
+	case reflect.String:
+		return "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		return "minItems", "maxItems"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "minimum", "maximum"
+	}
+
+	return "", ""
+}
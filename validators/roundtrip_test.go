@@ -0,0 +1,46 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_CheckRoundTrip(t *testing.T) {
+	type Audit struct {
+		Source string `json:"source"`
+	}
+
+	type Address struct {
+		Zip string `json:"zip" validate:"len=5" jsonschema:"minLength=5,maxLength=9"`
+	}
+
+	type User struct {
+		Audit
+		Id        *string   `json:"id,omitempty" jsonschema:"required"`
+		Name      string    `json:"name" validate:"min=2" jsonschema:"minLength=3"`
+		Age       int       `json:"age" validate:"range=18..130" jsonschema:"minimum=18,maximum=130"`
+		Role      string    `json:"role" validate:"in=ADMIN|GUEST" jsonschema:"enum=GUEST,enum=ADMIN"`
+		Status    string    `json:"status" validate:"in=ACTIVE" jsonschema:"enum=ACTIVE,enum=BLOCKED"`
+		Password  string    `json:"-" validate:"min=8"`
+		Addresses []Address `json:"addresses" jsonschema:"required"`
+		Parent    *User     `json:"parent,omitempty"`
+		internal  string    `jsonschema:"required"`
+	}
+
+	want := []Mismatch{
+		{Path: "id", Message: "required by the schema but omitted from JSON when empty"},
+		{Path: "name", Message: "validate `min=2` does not match jsonschema `minLength=3`"},
+		{Path: "status", Message: "validate `in=ACTIVE` does not match jsonschema enum [ACTIVE BLOCKED]"},
+		{Path: "Password", Message: "ignored by encoding/json but declares schema or validation rules"},
+		{Path: "addresses[].zip", Message: "validate `eq=5` does not match jsonschema `maxLength=9`"},
+		{Path: "internal", Message: "ignored by encoding/json but declares schema or validation rules"},
+	}
+
+	if got := CheckRoundTrip(&User{internal: ""}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckRoundTrip() = %v, want %v", got, want)
+	}
+
+	if got := CheckRoundTrip(nil); len(got) != 0 {
+		t.Errorf("CheckRoundTrip() = %v, want no mismatches", got)
+	}
+}
@@ -0,0 +1,94 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// Checks a rule against an attribute, given the value of the rule (i.e. `4` for `sku=4`).
+// Returns the error codes to report for the attribute, or nil if it passes the rule.
+type RuleFunc func(attribute structs.StructAttribute, ruleValue string) []string
+
+var (
+	customRulesMu sync.RWMutex
+	customRules   = map[string]RuleFunc{}
+)
+
+// Registers a rule, so that it can be used in validation tags like any of the built-in rules.
+// Registered rules can be skipped (see `ValidationOptions.SkipRules`), aliased (see `RuleAliases`)
+// and are inherited by the elements of slices/arrays, unless listed in `structs.NON_INHERITABLE_TAG_ATTRIBUTES`.
+// As with the built-in rules, slices/arrays themselves are not checked, only their elements are.
+//
+// Registering a rule under the name of a registered rule replaces it.
+// It panics if the name is empty or is the name of a built-in rule.
+//
+// Usage:
+//
+//	RegisterRule("sku", func(attribute structs.StructAttribute, ruleValue string) []string {
+//		if !strings.HasPrefix(attribute.Value.String(), "SKU-") {
+//			return []string{"INVALID_SKU"}
+//		}
+//
+//		return nil
+//	})
+//
+//	type Product struct {
+//		Sku   string   `json:"sku" validate:"sku"`
+//		Parts []string `json:"parts" validate:"sku"`
+//	}
+func RegisterRule(name string, fn RuleFunc) {
+	if name == "" || isBuiltinRule(name) {
+		panic(fmt.Sprintf("validators: cannot register rule %q", name))
+	}
+
+	customRulesMu.Lock()
+	defer customRulesMu.Unlock()
+
+	customRules[name] = fn
+}
+
+// Returns the function of the registered rule with the given name.
+func registeredRule(name string) (RuleFunc, bool) {
+	customRulesMu.RLock()
+	defer customRulesMu.RUnlock()
+
+	fn, ok := customRules[name]
+	return fn, ok
+}
+
+// Checks a registered rule against the attribute. See `RegisterRule`.
+func checkRegisteredRule(fn RuleFunc, attribute structs.StructAttribute, ruleValue string) []string {
+	if f, err := structs.PointerElement(attribute.Value); err == nil {
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		}
+	}
+
+	if errs := fn(attribute, ruleValue); len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Returns the name and the value of a single rule, as in (min, 2) for `min=2`. See `structs.ParseRule`.
+// Names of element rules keep their `structs.EACH_RULE_PREFIX`. Malformed rules are split on their first `=`.
+func ruleParts(rule string) (name string, value string) {
+	parsed, err := structs.ParseRule(rule)
+	if err != nil {
+		name, value, _ = strings.Cut(rule, "=")
+		return name, value
+	}
+
+	if parsed.Each {
+		return structs.EACH_RULE_PREFIX + parsed.Name, parsed.Value
+	}
+
+	return parsed.Name, parsed.Value
+}
@@ -0,0 +1,87 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+func Test_RegisterRule(t *testing.T) {
+	RegisterRule("sku", func(attribute structs.StructAttribute, ruleValue string) []string {
+		value := attribute.Value.String()
+		if !strings.HasPrefix(value, "SKU-") || (ruleValue != "" && len(value) != len("SKU-")+len(ruleValue)) {
+			return []string{"INVALID_SKU"}
+		}
+
+		return nil
+	})
+
+	defer func() {
+		customRulesMu.Lock()
+		delete(customRules, "sku")
+		customRulesMu.Unlock()
+	}()
+
+	type Product struct {
+		Sku   string   `json:"sku" validate:"sku=0000"`
+		Parts []string `json:"parts" validate:"sku,min=1"`
+		Code  string   `json:"code" validate:"product_code"`
+	}
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Product{Sku: "SKU-0001", Parts: []string{"SKU-1", "SKU-22"}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid",
+			model: Product{Sku: "SKU-1", Parts: []string{"SKU-1", "PART-2"}},
+			want:  map[string][]string{"sku": {"INVALID_SKU"}, "parts[1]": {"INVALID_SKU"}},
+		},
+		{
+			name:    "skipped",
+			model:   Product{Sku: "SKU-1", Parts: []string{"PART-2"}},
+			options: ValidationOptions{SkipRules: []string{"sku"}},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "aliased",
+			model:   Product{Sku: "SKU-0001", Parts: []string{"SKU-1"}, Code: "PART-2"},
+			options: ValidationOptions{RuleAliases: map[string]string{"product_code": "sku"}},
+			want:    map[string][]string{"code": {"INVALID_SKU"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := Lint(Product{}, ValidationOptions{}); !reflect.DeepEqual(got, []LintWarning{{Path: "code", Rule: "product_code", Message: "unknown rule: product_code"}}) {
+		t.Errorf("Lint() = %v", got)
+	}
+}
+
+func Test_RegisterRule_Panics(t *testing.T) {
+	for _, name := range []string{"", EMAIL, "regex(^a$)"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterRule(%q) did not panic", name)
+				}
+			}()
+
+			RegisterRule(name, func(structs.StructAttribute, string) []string { return nil })
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package validators
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// The name of the metric written by `FailureCollector.WritePrometheus` when none is given.
+const DEFAULT_FAILURES_METRIC_NAME string = "validation_failures_total"
+
+// Counts validation failures per field and error code, so that teams can see which fields users most often get wrong.
+// Positions of slices/arrays are left out of the paths (i.e. `emails[]`), which keeps the number of fields bounded.
+// It is safe for concurrent use.
+//
+// Usage:
+//
+//	collector := NewFailureCollector()
+//	options := ValidationOptions{Collector: collector}
+//
+//	Validate(person, options)
+//	...
+//
+//	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+//		collector.WritePrometheus(w, "")
+//	})
+type FailureCollector struct {
+	mu     sync.Mutex
+	counts map[failureKey]uint64
+}
+
+type failureKey struct {
+	path string
+	code string
+}
+
+// Returns an empty collector.
+func NewFailureCollector() *FailureCollector {
+	return &FailureCollector{counts: map[failureKey]uint64{}}
+}
+
+// Records the failures found in the given validations.
+func (c *FailureCollector) Observe(validations map[string][]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for path, codes := range validations {
+		path = failurePath(path)
+
+		for _, code := range codes {
+			c.counts[failureKey{path, code}]++
+		}
+	}
+}
+
+// Returns the number of failures recorded so far, keyed by path and error code.
+//
+// Usage:
+//
+//	collector.Counts() // -> {"emails[]": {"INVALID_FORMAT": 3}, "name": {"INVALID_LENGTH": 1}}
+func (c *FailureCollector) Counts() map[string]map[string]uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := map[string]map[string]uint64{}
+	for key, n := range c.counts {
+		if counts[key.path] == nil {
+			counts[key.path] = map[string]uint64{}
+		}
+
+		counts[key.path][key.code] = n
+	}
+
+	return counts
+}
+
+// Discards all the failures recorded so far.
+func (c *FailureCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.counts = map[failureKey]uint64{}
+}
+
+// Writes the failures recorded so far as a Prometheus counter (text exposition format), labeled by `field` and `code`.
+// The metric is named `DEFAULT_FAILURES_METRIC_NAME` if no name is given. Samples are sorted by field and code.
+//
+// Usage:
+//
+//	collector.WritePrometheus(w, "")
+//	// # HELP validation_failures_total Number of validation failures per field and error code.
+//	// # TYPE validation_failures_total counter
+//	// validation_failures_total{field="emails[]",code="INVALID_FORMAT"} 3
+func (c *FailureCollector) WritePrometheus(w io.Writer, name string) error {
+	if name == "" {
+		name = DEFAULT_FAILURES_METRIC_NAME
+	}
+
+	c.mu.Lock()
+	keys := make([]failureKey, 0, len(c.counts))
+	for key := range c.counts {
+		keys = append(keys, key)
+	}
+
+	counts := make(map[failureKey]uint64, len(c.counts))
+	for key, n := range c.counts {
+		counts[key] = n
+	}
+	c.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].path != keys[j].path {
+			return keys[i].path < keys[j].path
+		}
+
+		return keys[i].code < keys[j].code
+	})
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# HELP %s Number of validation failures per field and error code.\n", name)
+	fmt.Fprintf(&sb, "# TYPE %s counter\n", name)
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "%s{field=\"%s\",code=\"%s\"} %d\n", name, escape.Replace(key.path), escape.Replace(key.code), counts[key])
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Replaces the positions of slices/arrays found in the path by `[]`, as in `emails[]`.
+func failurePath(path string) string {
+	var sb strings.Builder
+
+	for position, segment := range structs.ParsePath(path) {
+		if segment.IsIndex {
+			sb.WriteString("[]")
+			continue
+		}
+
+		if position > 0 {
+			sb.WriteByte('.')
+		}
+
+		sb.WriteString(segment.Name)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_FailureCollector(t *testing.T) {
+	collector := NewFailureCollector()
+	options := ValidationOptions{Collector: collector}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Validate(Person{Name: "L", Contact: Contact{Emails: []string{"a", "b@example.com", "c"}}}, options)
+		}()
+	}
+
+	wg.Wait()
+
+	want := map[string]map[string]uint64{
+		"id":               {"INVALID_FORMAT": 10},
+		"name":             {"INVALID_LENGTH": 10},
+		"contact.emails[]": {"INVALID_FORMAT": 20},
+	}
+
+	if got := collector.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+
+	var sb strings.Builder
+	if err := collector.WritePrometheus(&sb, ""); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+
+	metrics := strings.Join([]string{
+		"# HELP validation_failures_total Number of validation failures per field and error code.",
+		"# TYPE validation_failures_total counter",
+		`validation_failures_total{field="contact.emails[]",code="INVALID_FORMAT"} 20`,
+		`validation_failures_total{field="id",code="INVALID_FORMAT"} 10`,
+		`validation_failures_total{field="name",code="INVALID_LENGTH"} 10`,
+		"",
+	}, "\n")
+
+	if sb.String() != metrics {
+		t.Errorf("WritePrometheus() = %v, want %v", sb.String(), metrics)
+	}
+
+	collector.Reset()
+	if got := collector.Counts(); len(got) != 0 {
+		t.Errorf("Counts() = %v after Reset(), want no failures", got)
+	}
+}
@@ -0,0 +1,165 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Returns `true` if the time is within the bound set by the rule: `after` and `before` are exclusive,
+// `min` and `max` are inclusive and `eq` checks if both times are the same instant.
+//
+// Usage:
+//
+//	IsValidTime(time.Now(), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), BEFORE) // -> true
+//	IsValidTime(time.Now(), time.Now().Add(time.Hour), AFTER)                     // -> false
+func IsValidTime(value time.Time, bound time.Time, rule string) bool {
+	switch rule {
+	case AFTER:
+		return value.After(bound)
+	case BEFORE:
+		return value.Before(bound)
+	case MIN:
+		return !value.Before(bound)
+	case MAX:
+		return !value.After(bound)
+	}
+
+	return value.Equal(bound)
+}
+
+// Parses the value of a rule applied to a `time.Time` field. Accepted values are:
+//   - `now`, optionally followed by a duration, as in `now+24h` or `now-30m`.
+//   - a timestamp in the RFC 3339 format, as in `2030-01-01T00:00:00Z`.
+//   - a date, as in `2030-01-01`, which stands for its midnight in the given location.
+//
+// Usage:
+//
+//	parseTimeBound("2030-01-01", time.UTC) // -> 2030-01-01 00:00:00 +0000 UTC
+//	parseTimeBound("now-1h", time.UTC)     // -> an hour ago
+func parseTimeBound(value string, location *time.Location) (time.Time, error) {
+	if strings.HasPrefix(value, "now") {
+		offset := value[len("now"):]
+		if offset == "" {
+			return time.Now(), nil
+		}
+
+		if offset[0] != '+' && offset[0] != '-' {
+			return time.Time{}, errors.New("invalid time bound: " + value)
+		}
+
+		d, err := time.ParseDuration(offset)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return time.Now().Add(d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation("2006-01-02", value, location)
+}
+
+// Returns `true` if both times fall on the same calendar day in the given location.
+// Times are compared by instant, so `2024-01-01T23:00:00-03:00` and `2024-01-02T02:00:00Z` are on the same day in UTC.
+//
+// Usage:
+//
+//	IsSameDay(startsAt, endsAt, time.UTC)
+//	IsSameDay(startsAt, endsAt, time.Local)
+func IsSameDay(a time.Time, b time.Time, location *time.Location) bool {
+	ay, am, ad := a.In(location).Date()
+	by, bm, bd := b.In(location).Date()
+
+	return ay == by && am == bm && ad == bd
+}
+
+// Returns `true` if at least the given number of years, months and days have passed from the date to now,
+// counting calendar days in the location of now. Someone born on a February 29th turns a year older on March 1st
+// of common years.
+//
+// Usage:
+//
+//	HasMinAge(time.Date(2000, 5, 17, 0, 0, 0, 0, time.UTC), time.Now(), 18, 0, 0) // -> true
+func HasMinAge(date time.Time, now time.Time, years int, months int, days int) bool {
+	y, m, d := date.In(now.Location()).Date()
+	threshold := time.Date(y+years, m+time.Month(months), d+days, 0, 0, 0, 0, now.Location())
+
+	y, m, d = now.Date()
+	return !time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Before(threshold)
+}
+
+// Parses a calendar period, as in `18y`, `6m`, `2w`, `30d` or `1y6m`, into years, months and days.
+// Weeks are read as seven days.
+//
+// Usage:
+//
+//	parseCalendarPeriod("1y6m") // -> 1, 6, 0
+func parseCalendarPeriod(value string) (years int, months int, days int, err error) {
+	if value == "" {
+		return 0, 0, 0, errors.New("invalid period: " + value)
+	}
+
+	for rest := value; rest != ""; {
+		end := strings.IndexAny(rest, "ymwd")
+		if end <= 0 || !isDigits(rest[:end]) {
+			return 0, 0, 0, errors.New("invalid period: " + value)
+		}
+
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		switch rest[end] {
+		case 'y':
+			years += n
+		case 'm':
+			months += n
+		case 'w':
+			days += 7 * n
+		default:
+			days += n
+		}
+
+		rest = rest[end+1:]
+	}
+
+	return years, months, days, nil
+}
+
+// Returns the time held by the value: either a `time.Time` or a string holding an RFC 3339 timestamp
+// or a date (as in `2030-01-01`), which stands for its midnight in the given location.
+// The second value is `false` if the value is a string that is neither, and the third one is `false` if it is not a time at all.
+func timeValue(v reflect.Value, location *time.Location) (t time.Time, valid bool, isTime bool) {
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time), true, true
+	case v.Kind() == reflect.String:
+		if t, err := time.Parse(time.RFC3339, v.String()); err == nil {
+			return t, true, true
+		}
+
+		t, err := time.ParseInLocation("2006-01-02", v.String(), location)
+		return t, err == nil, true
+	}
+
+	return time.Time{}, false, false
+}
+
+// Parses the value of a rule applied to a `time.Duration` field, as in `1h30m`.
+// Plain numbers are read as nanoseconds, as done for other integers.
+func parseDurationBound(value string) (float64, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return float64(d), nil
+	}
+
+	return parsedLengthAttribute(value)
+}
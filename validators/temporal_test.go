@@ -0,0 +1,301 @@
+package validators
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_IsValidTime(t *testing.T) {
+	bound := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value time.Time
+		rule  string
+		want  bool
+	}{
+		{name: "after", value: bound.Add(time.Second), rule: AFTER, want: true},
+		{name: "after (same instant)", value: bound, rule: AFTER, want: false},
+		{name: "before", value: bound.Add(-time.Second), rule: BEFORE, want: true},
+		{name: "before (same instant)", value: bound, rule: BEFORE, want: false},
+		{name: "min (same instant)", value: bound, rule: MIN, want: true},
+		{name: "min", value: bound.Add(-time.Second), rule: MIN, want: false},
+		{name: "max (same instant)", value: bound, rule: MAX, want: true},
+		{name: "max", value: bound.Add(time.Second), rule: MAX, want: false},
+		{name: "eq in another location", value: bound.In(time.FixedZone("BRT", -3*60*60)), rule: EQUAL, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidTime(tt.value, bound, tt.rule); got != tt.want {
+				t.Errorf("IsValidTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseTimeBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		location *time.Location
+		want     time.Time
+		wantErr  bool
+	}{
+		{name: "date", value: "2030-01-01", want: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp", value: "2030-01-01T09:00:00-03:00", want: time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "date in location", value: "2030-01-01", location: time.FixedZone("BRT", -3*60*60), want: time.Date(2030, 1, 1, 3, 0, 0, 0, time.UTC)},
+		{name: "invalid offset", value: "now*1h", wantErr: true},
+		{name: "invalid duration", value: "now+1y", wantErr: true},
+		{name: "invalid", value: "tomorrow", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := tt.location
+			if location == nil {
+				location = time.UTC
+			}
+
+			got, err := parseTimeBound(tt.value, location)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeBound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseTimeBound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Relative bounds are computed from the current time
+	if got, _ := parseTimeBound("now-1h", time.UTC); time.Since(got) < time.Hour || time.Since(got) > time.Hour+time.Minute {
+		t.Errorf("parseTimeBound() = %v, want an hour ago", got)
+	}
+}
+
+func Test_Validate_Temporal(t *testing.T) {
+	type Subscription struct {
+		ExpiresAt time.Time     `json:"expires_at" validate:"after=now"`
+		StartsAt  *time.Time    `json:"starts_at" validate:"after=2024-01-01,before=2030-01-01"`
+		BirthDate time.Time     `json:"birth_date" validate:"min=1900-01-01,max=now"`
+		Interval  time.Duration `json:"interval" validate:"min=1h,max=24h"`
+		Timeout   time.Duration `json:"timeout" validate:"eq=30s"`
+		Name      string        `json:"name" validate:"after=now"`
+	}
+
+	startsAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	lateStart := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		model Subscription
+		want  map[string][]string
+	}{
+		{
+			name: "valid",
+			model: Subscription{
+				ExpiresAt: time.Now().Add(time.Hour),
+				StartsAt:  &startsAt,
+				BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+				Interval:  90 * time.Minute,
+				Timeout:   30 * time.Second,
+			},
+			want: map[string][]string{"name": {"INVALID_TYPE"}},
+		},
+		{
+			name: "invalid",
+			model: Subscription{
+				ExpiresAt: time.Now().Add(-time.Hour),
+				StartsAt:  &lateStart,
+				BirthDate: time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC),
+				Interval:  30 * time.Minute,
+				Timeout:   time.Minute,
+			},
+			want: map[string][]string{
+				"expires_at": {"INVALID_VALUE"},
+				"starts_at":  {"INVALID_VALUE"},
+				"birth_date": {"INVALID_VALUE"},
+				"interval":   {"INVALID_VALUE"},
+				"timeout":    {"INVALID_VALUE"},
+				"name":       {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	type Invalid struct {
+		ExpiresAt time.Time  `json:"expires_at" validate:"after=tomorrow"`
+		StartsAt  time.Time  `json:"starts_at" validate:"min=yesterday"`
+		EndsAt    *time.Time `json:"ends_at" validate:"max=2030-13-01"`
+		SentAt    time.Time  `json:"sent_at" validate:"eq=soon"`
+	}
+
+	want := map[string][]string{
+		"expires_at": {"UNEXPECTED_ERROR"},
+		"starts_at":  {"UNEXPECTED_ERROR"},
+		"ends_at":    {"UNEXPECTED_ERROR"},
+		"sent_at":    {"UNEXPECTED_ERROR"},
+	}
+
+	if got := Validate(Invalid{EndsAt: &startsAt}, ValidationOptions{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	// Durations are bounded by their values in nanoseconds, while times are left unbounded
+	schema := ZodSchema(Subscription{})
+	for _, want := range []string{"interval: z.number().int().gte(3600000000000).lte(86400000000000)", "birth_date: z.string(),"} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("ZodSchema() = %v, want %v", schema, want)
+		}
+	}
+}
+
+func Test_IsSameDay(t *testing.T) {
+	brt := time.FixedZone("BRT", -3*60*60)
+
+	tests := []struct {
+		name     string
+		a        time.Time
+		b        time.Time
+		location *time.Location
+		want     bool
+	}{
+		{name: "same day", a: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), b: time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "different days", a: time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC), b: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), location: time.UTC, want: false},
+		{name: "same day in utc", a: time.Date(2024, 1, 1, 23, 0, 0, 0, brt), b: time.Date(2024, 1, 2, 5, 0, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "different days in location", a: time.Date(2024, 1, 1, 23, 0, 0, 0, brt), b: time.Date(2024, 1, 2, 5, 0, 0, 0, time.UTC), location: brt, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSameDay(tt.a, tt.b, tt.location); got != tt.want {
+				t.Errorf("IsSameDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_HasMinAge(t *testing.T) {
+	now := time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		years  int
+		months int
+		days   int
+		want   bool
+	}{
+		{name: "birthday", date: time.Date(2006, 5, 17, 0, 0, 0, 0, time.UTC), years: 18, want: true},
+		{name: "day before birthday", date: time.Date(2006, 5, 18, 0, 0, 0, 0, time.UTC), years: 18, want: false},
+		{name: "leap day on common year", date: time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC), years: 20, want: true},
+		{name: "months", date: time.Date(2023, 11, 17, 0, 0, 0, 0, time.UTC), years: 0, months: 6, want: true},
+		{name: "days", date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), days: 17, want: false},
+		{name: "date in another location", date: time.Date(2006, 5, 17, 22, 0, 0, 0, time.FixedZone("BRT", -3*60*60)), years: 18, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasMinAge(tt.date, now, tt.years, tt.months, tt.days); got != tt.want {
+				t.Errorf("HasMinAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseCalendarPeriod(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    [3]int
+		wantErr bool
+	}{
+		{value: "18y", want: [3]int{18, 0, 0}},
+		{value: "1y6m", want: [3]int{1, 6, 0}},
+		{value: "2w3d", want: [3]int{0, 0, 17}},
+		{value: "", wantErr: true},
+		{value: "18", wantErr: true},
+		{value: "y", wantErr: true},
+		{value: "-1y", wantErr: true},
+		{value: "1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			years, months, days, err := parseCalendarPeriod(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCalendarPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := [3]int{years, months, days}; !tt.wantErr && got != tt.want {
+				t.Errorf("parseCalendarPeriod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_CalendarDays(t *testing.T) {
+	type Signup struct {
+		BirthDate time.Time  `json:"birth_date" validate:"min_age=18y"`
+		Birthday  string     `json:"birthday" validate:"min_age=21y"`
+		StartsAt  time.Time  `json:"starts_at"`
+		EndsAt    *time.Time `json:"ends_at" validate:"same_day_as=starts_at"`
+		Date      string     `json:"date" validate:"same_day_as=StartsAt"`
+	}
+
+	brt := time.FixedZone("BRT", -3*60*60)
+	startsAt := time.Date(2024, 1, 1, 23, 0, 0, 0, brt)
+	endsAt := time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)
+	adult := time.Now().AddDate(-30, 0, 0)
+	minor := time.Now().AddDate(-17, 0, 0)
+
+	tests := []struct {
+		name    string
+		model   Signup
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Signup{BirthDate: adult, Birthday: adult.Format("2006-01-02"), StartsAt: startsAt, EndsAt: &endsAt, Date: "2024-01-02"},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "unset",
+			model: Signup{BirthDate: adult, Birthday: adult.Format(time.RFC3339)},
+			want:  map[string][]string{},
+		},
+		{
+			name:    "invalid in location",
+			model:   Signup{BirthDate: adult, Birthday: adult.Format("2006-01-02"), StartsAt: startsAt, EndsAt: &endsAt, Date: "2024-01-02"},
+			options: ValidationOptions{Location: brt},
+			want:    map[string][]string{"ends_at": {"FIELD_MISMATCH"}, "date": {"FIELD_MISMATCH"}},
+		},
+		{
+			name:  "invalid",
+			model: Signup{BirthDate: minor, Birthday: "17/05/1990", StartsAt: startsAt, Date: "tomorrow"},
+			want:  map[string][]string{"birth_date": {"INVALID_VALUE"}, "birthday": {"INVALID_FORMAT"}, "date": {"INVALID_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	type Invalid struct {
+		Age      int       `json:"age" validate:"min_age=18y"`
+		Born     time.Time `json:"born" validate:"min_age=18"`
+		StartsAt time.Time `json:"starts_at" validate:"same_day_as=missing"`
+	}
+
+	want := map[string][]string{"age": {"INVALID_TYPE"}, "born": {"UNEXPECTED_ERROR"}, "starts_at": {"UNEXPECTED_ERROR"}}
+	if got := Validate(Invalid{}, ValidationOptions{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
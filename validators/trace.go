@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// The outcome of checking a single rule against an attribute.
+type TraceEntry struct {
+	// The full name of the attribute, including the `KeyPrefix`. See `structs.StructAttribute.FullName()`.
+	Path string `json:"path"`
+
+	// The rule as it appears in the tag (i.e. `min=3`), before aliases are resolved.
+	Rule string `json:"rule"`
+
+	Passed bool     `json:"passed"`
+	Errors []string `json:"errors,omitempty"`
+
+	// How long it took to check the rule.
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// Records every rule checked by `ValidateAttribute`, in the order they are checked, along with their outcomes.
+// This helps finding out why a specific payload passed or failed validation. It is safe for concurrent use.
+//
+// Rules listed in `SkipRules`, and the ones left unchecked because a previous rule of the same attribute failed,
+// are not recorded.
+//
+// Usage:
+//
+//	trace := NewTrace()
+//	Validate(person, ValidationOptions{Trace: trace})
+//
+//	data, _ := json.Marshal(trace)
+//	// -> [{"path": "name", "rule": "min=2", "passed": false, "errors": ["INVALID_LENGTH"], "duration_ns": 1250}, ...]
+type Trace struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+}
+
+// Returns an empty trace.
+func NewTrace() *Trace {
+	return &Trace{entries: []TraceEntry{}}
+}
+
+// Returns the entries recorded so far.
+func (t *Trace) Entries() []TraceEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]TraceEntry{}, t.entries...)
+}
+
+// Encodes the entries recorded so far as a JSON array.
+func (t *Trace) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Entries())
+}
+
+func (t *Trace) record(entry TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries = append(t.entries, entry)
+}
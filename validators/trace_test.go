@@ -0,0 +1,63 @@
+package validators
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_Trace(t *testing.T) {
+	type Account struct {
+		Name   string   `json:"name" validate:"min=2,max=4"`
+		Role   string   `json:"role" validate:"in=ADMIN|GUEST,currency"`
+		Emails []string `json:"emails" validate:"each:email"`
+	}
+
+	trace := NewTrace()
+	Validate(Account{Name: "Leonardo", Role: "ADMIN", Emails: []string{"a"}}, ValidationOptions{Trace: trace, SkipRules: []string{CURRENCY}, KeyPrefix: "payload."})
+
+	type outcome struct {
+		Path   string
+		Rule   string
+		Passed bool
+		Errors []string
+	}
+
+	got := []outcome{}
+	for _, entry := range trace.Entries() {
+		if entry.Duration < 0 {
+			t.Errorf("Trace entry %v has a negative duration", entry)
+		}
+
+		got = append(got, outcome{entry.Path, entry.Rule, entry.Passed, entry.Errors})
+	}
+
+	want := []outcome{
+		{Path: "payload.name", Rule: "min=2", Passed: true},
+		{Path: "payload.name", Rule: "max=4", Passed: false, Errors: []string{"INVALID_LENGTH"}},
+		{Path: "payload.role", Rule: "in=ADMIN|GUEST", Passed: true},
+		{Path: "payload.emails[0]", Rule: "email", Passed: false, Errors: []string{"INVALID_FORMAT"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Trace.Entries() = %v, want %v", got, want)
+	}
+
+	data, err := json.Marshal(trace)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal(data, &entries); err != nil || len(entries) != len(want) {
+		t.Fatalf("json.Marshal() = %s, want %d entries", data, len(want))
+	}
+
+	if entries[1]["rule"] != "max=4" || entries[1]["passed"] != false {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	if _, ok := entries[1]["duration_ns"]; !ok {
+		t.Errorf("json.Marshal() = %s, want a duration", data)
+	}
+}
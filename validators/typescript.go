@@ -0,0 +1,383 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// Converts the given models into TypeScript interfaces.
+// Nested struct types are emitted as interfaces of their own, named after their Go types and listed before the types using them.
+//
+// Pointer fields accept `null` and fields with the `omitempty` option are optional.
+//
+// Usage:
+//
+//	type Person struct {
+//		Name   string   `json:"name" validate:"min=2"`
+//		Emails []string `json:"emails,omitempty"`
+//		Age    *int     `json:"age"`
+//	}
+//
+//	TypeScript(Person{})
+//	// export interface Person {
+//	//   name: string;
+//	//   emails?: string[];
+//	//   age: number | null;
+//	// }
+func TypeScript(models ...any) string {
+	var sb strings.Builder
+
+	for i, t := range namedStructTypes(models) {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		fmt.Fprintf(&sb, "export interface %s %s\n", t.Name(), tsObject(t, ""))
+	}
+
+	return sb.String()
+}
+
+// Converts the given models into zod schemas, mapping their validation rules to zod refinements.
+// Each schema is named after its Go type, as in `PersonSchema`, and is followed by its inferred type.
+//
+// The following rules are supported: `base64url`, `currency`, `datetime`, `digits`, `e164`, `email`, `eq`, `floatstr`, `in`, `intstr`, `ip`, `ipv4`, `ipv6`, `ksuid`, `len`, `max`, `min`, `range`, `regex`, `ulid`, `url`, `uuid`, `uuid4` and `uuid7`.
+// The ranges of the numeric string rules are not enforced by the schemas.
+//
+// Usage:
+//
+//	ZodSchema(Person{})
+//	// import { z } from "zod";
+//	//
+//	// export const PersonSchema = z.object({
+//	//   name: z.string().min(2),
+//	//   emails: z.array(z.string()).optional(),
+//	//   age: z.number().int().nullable(),
+//	// });
+//	// export type Person = z.infer<typeof PersonSchema>;
+func ZodSchema(models ...any) string {
+	var sb strings.Builder
+
+	sb.WriteString("import { z } from \"zod\";\n")
+
+	types := namedStructTypes(models)
+
+	defined := map[reflect.Type]bool{}
+	for _, t := range types {
+		fmt.Fprintf(&sb, "\nexport const %sSchema = %s;\n", t.Name(), zodObject(t, "", defined))
+		fmt.Fprintf(&sb, "export type %s = z.infer<typeof %sSchema>;\n", t.Name(), t.Name())
+
+		defined[t] = true
+	}
+
+	return sb.String()
+}
+
+// Returns the named struct types reachable from the given models, with dependencies listed first.
+func namedStructTypes(models []any) (types []reflect.Type) {
+	visited := map[reflect.Type]bool{}
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		t = baseType(t)
+
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			visit(t.Elem())
+			return
+		case reflect.Struct:
+		default:
+			return
+		}
+
+		if visited[t] || isStringType(t) {
+			return
+		}
+
+		if t.Name() != "" {
+			visited[t] = true
+		}
+
+		for _, sf := range tsFields(t) {
+			visit(sf.Type)
+		}
+
+		if t.Name() != "" {
+			types = append(types, t)
+		}
+	}
+
+	for _, model := range models {
+		if t := reflect.TypeOf(model); t != nil {
+			visit(t)
+		}
+	}
+
+	return types
+}
+
+func tsObject(t reflect.Type, indent string) string {
+	var sb strings.Builder
+
+	sb.WriteString("{\n")
+	for _, sf := range tsFields(t) {
+		optional := ""
+		if structs.Contains(structs.GetTagValues(sf, "json"), "omitempty") {
+			optional = "?"
+		}
+
+		fieldType := tsType(sf.Type, indent+"  ")
+		if sf.Type.Kind() == reflect.Pointer {
+			fieldType += " | null"
+		}
+
+		fmt.Fprintf(&sb, "%s  %s%s: %s;\n", indent, tsKey(structs.GetJSONTagValue(sf)), optional, fieldType)
+	}
+	sb.WriteString(indent + "}")
+
+	return sb.String()
+}
+
+func tsType(t reflect.Type, indent string) string {
+	t = baseType(t)
+
+	if t == structs.RawMessageType {
+		return "unknown"
+	}
+
+	if isStringType(t) {
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+
+		element := tsType(t.Elem(), indent)
+		if strings.ContainsAny(element, " {") {
+			element = "(" + element + ")"
+		}
+
+		return element + "[]"
+	case reflect.Map:
+		return "Record<string, " + tsType(t.Elem(), indent) + ">"
+	case reflect.Struct:
+		if t.Name() != "" {
+			return t.Name()
+		}
+
+		return tsObject(t, indent)
+	}
+
+	return "unknown"
+}
+
+func zodObject(t reflect.Type, indent string, defined map[reflect.Type]bool) string {
+	var sb strings.Builder
+
+	sb.WriteString("z.object({\n")
+	for _, sf := range tsFields(t) {
+		rules := []string{}
+		if _, ok := sf.Tag.Lookup(VALIDATION_TAG_KEYWORD); ok {
+			rules = structs.GetTagRules(sf, VALIDATION_TAG_KEYWORD)
+		}
+
+		schema := zodType(sf.Type, rules, indent+"  ", defined)
+		if sf.Type.Kind() == reflect.Pointer {
+			schema += ".nullable()"
+		}
+
+		if structs.Contains(structs.GetTagValues(sf, "json"), "omitempty") {
+			schema += ".optional()"
+		}
+
+		fmt.Fprintf(&sb, "%s  %s: %s,\n", indent, tsKey(structs.GetJSONTagValue(sf)), schema)
+	}
+	sb.WriteString(indent + "})")
+
+	return sb.String()
+}
+
+func zodType(t reflect.Type, rules []string, indent string, defined map[reflect.Type]bool) string {
+	t = baseType(t)
+
+	ruleValues := map[string]string{}
+	for _, rule := range (ValidationOptions{}).expandRules(rules) {
+		name, value := ruleParts(rule)
+		ruleValues[name] = value
+	}
+
+	if t == structs.RawMessageType {
+		return "z.unknown()"
+	}
+
+	// Bounds of times and durations are not lengths: times have no refinements for them,
+	// and durations (serialized as nanoseconds) are bounded by their values in nanoseconds
+	for _, rule := range []string{EQUAL, MIN, MAX} {
+		if value, ok := ruleValues[rule]; ok && (t == timeType || t == durationType) {
+			if n, err := parseDurationBound(value); err == nil && t == durationType {
+				ruleValues[rule] = strconv.FormatFloat(n, 'f', -1, 64)
+			} else {
+				delete(ruleValues, rule)
+			}
+		}
+	}
+
+	if isStringType(t) {
+		return "z.string()" + zodStringRefinements(ruleValues)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if accepted, ok := ruleValues[IN]; ok && !isValueProvider(accepted) {
+			return "z.enum([" + strings.Join(structs.Map(strings.Split(accepted, "|"), func(_ int, v string) string { return strconv.Quote(v) }), ", ") + "])"
+		}
+
+		return "z.string()" + zodStringRefinements(ruleValues)
+	case reflect.Bool:
+		return "z.boolean()"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		schema := "z.number()"
+		if t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 {
+			schema += ".int()"
+		}
+
+		if accepted, ok := ruleValues[IN]; ok && !isValueProvider(accepted) {
+			values := strings.Split(accepted, "|")
+			return schema + ".refine((v) => [" + strings.Join(values, ", ") + "].includes(v))"
+		}
+
+		return schema + zodLengthRefinements(ruleValues, "gte", "lte")
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return "z.string()"
+		}
+
+		return "z.array(" + zodType(t.Elem(), elementRules(t.Elem(), rules), indent, defined) + ")" + zodLengthRefinements(ruleValues, "min", "max")
+	case reflect.Map:
+		return "z.record(" + zodType(t.Elem(), nil, indent, defined) + ")"
+	case reflect.Struct:
+		if t.Name() == "" {
+			return zodObject(t, indent, defined)
+		}
+
+		// Types that are not defined yet can only be referenced lazily
+		if !defined[t] {
+			return "z.lazy(() => " + t.Name() + "Schema)"
+		}
+
+		return t.Name() + "Schema"
+	}
+
+	return "z.unknown()"
+}
+
+func zodStringRefinements(ruleValues map[string]string) (refinements string) {
+	formats := []struct {
+		rule       string
+		refinement string
+	}{
+		{BASE64URL, `.regex(/^[A-Za-z0-9_-]*={0,2}$/)`},
+		{CURRENCY, `.regex(/^[A-Z]{3}$/)`},
+		{DATETIME, ".datetime({ offset: true })"},
+		{DIGITS, `.regex(/^[0-9]+$/)`},
+		{E164, `.regex(/^\+[1-9][0-9]{6,14}$/)`},
+		{EMAIL, ".email()"},
+		{FLOATSTR, `.regex(/^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$/)`},
+		{INTSTR, `.regex(/^[-+]?[0-9]+$/)`},
+		{IP, ".ip()"},
+		{IPV4, `.ip({ version: "v4" })`},
+		{IPV6, `.ip({ version: "v6" })`},
+		{KSUID, `.regex(/^[0-9A-Za-z]{27}$/)`},
+		{ULID, ".ulid()"},
+		{URL, ".url()"},
+		{UUID, ".uuid()"},
+		{UUID4, `.regex(/^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/i)`},
+		{UUID7, `.regex(/^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/i)`},
+	}
+
+	for _, format := range formats {
+		if _, ok := ruleValues[format.rule]; ok {
+			refinements += format.refinement
+		}
+	}
+
+	if pattern, ok := ruleValues[REGEX]; ok {
+		refinements += ".regex(/" + strings.ReplaceAll(pattern, "/", `\/`) + "/)"
+	}
+
+	return refinements + zodLengthRefinements(ruleValues, "min", "max")
+}
+
+func zodLengthRefinements(ruleValues map[string]string, min string, max string) (refinements string) {
+	if value, ok := ruleValues[EQUAL]; ok {
+		refinements += "." + min + "(" + value + ")." + max + "(" + value + ")"
+	}
+
+	if value, ok := ruleValues[MIN]; ok {
+		refinements += "." + min + "(" + value + ")"
+	}
+
+	if value, ok := ruleValues[MAX]; ok {
+		refinements += "." + max + "(" + value + ")"
+	}
+
+	return refinements
+}
+
+// Returns the exported fields of the struct that are present in its JSON representation, including the ones of embedded structs.
+func tsFields(t reflect.Type) (fields []reflect.StructField) {
+	for _, sf := range reflect.VisibleFields(t) {
+		if sf.Anonymous && baseType(sf.Type).Kind() == reflect.Struct {
+			continue
+		}
+
+		if !sf.IsExported() || structs.GetJSONTagValue(sf) == "-" {
+			continue
+		}
+
+		fields = append(fields, sf)
+	}
+
+	return fields
+}
+
+// Returns `true` for the types whose JSON representation is a string, like `time.Time`.
+func isStringType(t reflect.Type) bool {
+	return t == timeType || t == uuidType
+}
+
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func tsKey(name string) string {
+	for i, r := range name {
+		isLetter := r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return strconv.Quote(name)
+		}
+	}
+
+	return name
+}
@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type tsAddress struct {
+	Street string `json:"street" validate:"min=3"`
+	Zip    string `json:"zip" validate:"len=5..10,regex(^\\d{5}(-\\d{4})?$)"`
+	Number int    `json:"number" validate:"range=1.."`
+
+	Coordinates [][]float64 `json:"coordinates" validate:"max=2,each:max=90"`
+}
+
+type tsAccount struct {
+	Identifiable
+	Role      string            `json:"role" validate:"in=ADMIN|GUEST"`
+	Level     *int              `json:"level" validate:"min=1,max=5"`
+	Ratio     float64           `json:"ratio,omitempty"`
+	Emails    []string          `json:"emails" validate:"min=1,each:email"`
+	Addresses []tsAddress       `json:"addresses"`
+	Labels    map[string]string `json:"labels"`
+	CreatedAt time.Time         `json:"created_at" validate:"datetime"`
+	Parent    *tsAccount        `json:"parent"`
+	Meta      struct {
+		Source string `json:"source"`
+	} `json:"meta"`
+	Secret string `json:"-"`
+}
+
+func Test_TypeScript(t *testing.T) {
+	want := strings.Join([]string{
+		"export interface tsAddress {",
+		"  street: string;",
+		"  zip: string;",
+		"  number: number;",
+		"  coordinates: number[][];",
+		"}",
+		"",
+		"export interface tsAccount {",
+		"  id: string;",
+		"  role: string;",
+		"  level: number | null;",
+		"  ratio?: number;",
+		"  emails: string[];",
+		"  addresses: tsAddress[];",
+		"  labels: Record<string, string>;",
+		"  created_at: string;",
+		"  parent: tsAccount | null;",
+		"  meta: {",
+		"    source: string;",
+		"  };",
+		"}",
+		"",
+	}, "\n")
+
+	if got := TypeScript(&tsAccount{}); got != want {
+		t.Errorf("TypeScript() = %v, want %v", got, want)
+	}
+}
+
+func Test_ZodSchema(t *testing.T) {
+	want := strings.Join([]string{
+		`import { z } from "zod";`,
+		"",
+		"export const tsAddressSchema = z.object({",
+		"  street: z.string().min(3),",
+		`  zip: z.string().regex(/^\d{5}(-\d{4})?$/).min(5).max(10),`,
+		"  number: z.number().int().gte(1),",
+		"  coordinates: z.array(z.array(z.number().lte(90))).max(2),",
+		"});",
+		"export type tsAddress = z.infer<typeof tsAddressSchema>;",
+		"",
+		"export const tsAccountSchema = z.object({",
+		"  id: z.string().uuid(),",
+		`  role: z.enum(["ADMIN", "GUEST"]),`,
+		"  level: z.number().int().gte(1).lte(5).nullable(),",
+		"  ratio: z.number().optional(),",
+		"  emails: z.array(z.string().email()).min(1),",
+		"  addresses: z.array(tsAddressSchema),",
+		"  labels: z.record(z.string()),",
+		"  created_at: z.string().datetime({ offset: true }),",
+		"  parent: z.lazy(() => tsAccountSchema).nullable(),",
+		"  meta: z.object({",
+		"    source: z.string(),",
+		"  }),",
+		"});",
+		"export type tsAccount = z.infer<typeof tsAccountSchema>;",
+		"",
+	}, "\n")
+
+	if got := ZodSchema(tsAccount{}); got != want {
+		t.Errorf("ZodSchema() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package validators
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+// Implemented by models with invariants spanning multiple fields, which cannot be expressed with tags,
+// as in a period whose end must come after its start.
+//
+// `Validate` calls `ValidateStruct` on the model and on every nested struct implementing it
+// (including the elements of slices/arrays and the values of maps), once their tags are validated.
+// The returned errors are keyed by paths relative to the struct, which are merged under the path of the struct itself.
+// Errors keyed by an empty path belong to the struct as a whole and, for the model, are reported under `_`.
+//
+// Usage:
+//
+//	type Period struct {
+//		Start time.Time `json:"start"`
+//		End   time.Time `json:"end"`
+//	}
+//
+//	func (p Period) ValidateStruct() map[string][]string {
+//		if p.End.Before(p.Start) {
+//			return map[string][]string{"end": {"INVALID_RANGE"}}
+//		}
+//
+//		return nil
+//	}
+//
+//	type Booking struct {
+//		Period Period `json:"period"`
+//	}
+//
+//	Validate(Booking{Period: Period{Start: tomorrow, End: today}}, ValidationOptions{})
+//	// -> {"period.end": ["INVALID_RANGE"]}
+type Validatable interface {
+	ValidateStruct() map[string][]string
+}
+
+var validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+
+// Returns the errors reported by the model and by the structs held by its attributes, as long as they are `Validatable`.
+func structFailures(model any, attributes structs.StructAttributes, options ValidationOptions) (result ValidationResult) {
+	if model != nil {
+		if rv, err := structs.PointerElement(reflect.ValueOf(model)); err == nil {
+			result = append(result, options.structErrors(rv, "")...)
+		}
+	}
+
+	for _, attr := range attributes {
+		switch attr.Value.Kind() {
+		case reflect.Struct:
+			result = append(result, options.structErrors(attr.Value, attr.FullName())...)
+		case reflect.Slice, reflect.Array:
+			// The elements of a slice/array of structs are not attributes themselves
+			for i := 0; i < attr.Value.Len(); i++ {
+				if el, err := structs.PointerElement(attr.Value.Index(i)); err == nil && el.Kind() == reflect.Struct {
+					path := joinedPath(attr.FullName(), []structs.PathSegment{{Index: i, IsIndex: true}})
+					result = append(result, options.structErrors(el, path)...)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Returns the errors reported by the struct, if it is `Validatable`, placed under the given path.
+// Panics raised by the struct are reported under that path if `Recover` is set.
+func (options ValidationOptions) structErrors(rv reflect.Value, path string) (result ValidationResult) {
+	validatable, ok := asValidatable(rv)
+	if !ok {
+		return result
+	}
+
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				errPath := path
+				if errPath == "" {
+					errPath = "_"
+				}
+
+				result = ValidationResult{options.localized(ValidationError{Path: options.KeyPrefix + errPath, Code: options.errorCode("unexpected")})}
+			}
+		}()
+	}
+
+	validations := validatable.ValidateStruct()
+
+	keys := make([]string, 0, len(validations))
+	for key := range validations {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		errPath := joinedPath(path, structs.ParsePath(key))
+		if errPath == "" {
+			errPath = "_"
+		}
+
+		for _, code := range validations[key] {
+			result = append(result, options.localized(ValidationError{Path: options.KeyPrefix + errPath, Code: code}))
+		}
+	}
+
+	return result
+}
+
+// Returns the value as a `Validatable`, if its type (or a pointer to it) implements the interface.
+func asValidatable(rv reflect.Value) (Validatable, bool) {
+	if !rv.IsValid() || !rv.CanInterface() {
+		return nil, false
+	}
+
+	if rv.Type().Implements(validatableType) {
+		return rv.Interface().(Validatable), true
+	}
+
+	if !reflect.PointerTo(rv.Type()).Implements(validatableType) {
+		return nil, false
+	}
+
+	return structs.EnsureAddressable(rv).Addr().Interface().(Validatable), true
+}
+
+// Appends the segments to the path, using the notation set in `structs.SliceIndexNotation`.
+func joinedPath(path string, segments []structs.PathSegment) string {
+	suffix := structs.FormatPath(segments, structs.SliceIndexNotation)
+
+	if path == "" || suffix == "" || structs.SliceIndexNotation == structs.POINTER_NOTATION || strings.HasPrefix(suffix, "[") {
+		return path + suffix
+	}
+
+	return path + "." + suffix
+}
@@ -0,0 +1,120 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oleoneto/go-structs/structs"
+)
+
+type period struct {
+	Start int `json:"start" validate:"min=0"`
+	End   int `json:"end"`
+}
+
+func (p period) ValidateStruct() map[string][]string {
+	if p.End < p.Start {
+		return map[string][]string{"end": {"INVALID_RANGE"}}
+	}
+
+	return nil
+}
+
+type booking struct {
+	Guests  int               `json:"guests"`
+	Period  period            `json:"period"`
+	Periods []period          `json:"periods"`
+	Rooms   map[string]period `json:"rooms"`
+	Backup  *period           `json:"backup"`
+}
+
+func (b *booking) ValidateStruct() map[string][]string {
+	if b.Guests > 2 && len(b.Rooms) < 2 {
+		return map[string][]string{"": {"NOT_ENOUGH_ROOMS"}, "rooms": {"INVALID_LENGTH"}}
+	}
+
+	return nil
+}
+
+func Test_Validate_Validatable(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: booking{Guests: 1, Period: period{Start: 1, End: 2}, Periods: []period{{End: 1}}},
+			want:  map[string][]string{},
+		},
+		{
+			name: "nested structs",
+			model: &booking{
+				Guests:  1,
+				Period:  period{Start: 2, End: 1},
+				Periods: []period{{End: 1}, {Start: 3, End: 2}},
+				Rooms:   map[string]period{"a": {Start: 1}},
+				Backup:  &period{Start: 1},
+			},
+			want: map[string][]string{
+				"period.end":     {"INVALID_RANGE"},
+				"periods[1].end": {"INVALID_RANGE"},
+				"rooms.a.end":    {"INVALID_RANGE"},
+				"backup.end":     {"INVALID_RANGE"},
+			},
+		},
+		{
+			name:  "errors are merged with the ones of the tags",
+			model: booking{Guests: 3, Period: period{Start: -2, End: -3}},
+			want: map[string][]string{
+				"_":            {"NOT_ENOUGH_ROOMS"},
+				"rooms":        {"INVALID_LENGTH"},
+				"period.start": {"INVALID_VALUE"},
+				"period.end":   {"INVALID_RANGE"},
+			},
+		},
+		{
+			name:    "key prefix",
+			model:   booking{Guests: 3},
+			options: ValidationOptions{KeyPrefix: "booking."},
+			want: map[string][]string{
+				"booking._":     {"NOT_ENOUGH_ROOMS"},
+				"booking.rooms": {"INVALID_LENGTH"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_joinedPath(t *testing.T) {
+	defer func(notation structs.PathNotation) { structs.SliceIndexNotation = notation }(structs.SliceIndexNotation)
+
+	tests := []struct {
+		notation structs.PathNotation
+		path     string
+		key      string
+		want     string
+	}{
+		{notation: structs.BRACKET_NOTATION, path: "", key: "end", want: "end"},
+		{notation: structs.BRACKET_NOTATION, path: "periods", key: "[1].end", want: "periods[1].end"},
+		{notation: structs.BRACKET_NOTATION, path: "period", key: "", want: "period"},
+		{notation: structs.DOT_NOTATION, path: "periods.1", key: "end", want: "periods.1.end"},
+		{notation: structs.POINTER_NOTATION, path: "/periods/1", key: "end", want: "/periods/1/end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			structs.SliceIndexNotation = tt.notation
+
+			if got := joinedPath(tt.path, structs.ParsePath(tt.key)); got != tt.want {
+				t.Errorf("joinedPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,14 +1,25 @@
 package validators
 
 import (
+	"bufio"
+	"encoding/base64"
 	"errors"
+	"io"
+	"math"
+	"net"
 	"net/mail"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/oleoneto/go-structs/collections"
 	"github.com/oleoneto/go-structs/structs"
 	"golang.org/x/text/currency"
 )
@@ -23,6 +34,72 @@ const (
 	//	}
 	VALIDATION_TAG_KEYWORD string = "validate"
 
+	// Use if at least one of the fields sharing the same group (within the same struct) must be set.
+	// Pointers are set when they are not nil. Other fields are set when their values are not zero values
+	// (and, for slices and maps, not empty). When `ValidationOptions.Present` is set, fields are set
+	// if they are present in the payload instead.
+	// If none of them are set, every field in the group is reported.
+	//
+	// Examples:
+	//
+	//	Email string `validate:"at_least_one_of=contact"`
+	//	Phone string `validate:"at_least_one_of=contact"`
+	AT_LEAST_ONE_OF string = "at_least_one_of"
+
+	// Use if a time (`time.Time`) must be later than the given one, which is either `now` (optionally followed by
+	// a duration, as in `now+24h`), an RFC 3339 timestamp or a date (midnight in UTC), as in `2030-01-01`.
+	// An invalid value results in an `UNEXPECTED_ERROR`.
+	//
+	// Examples:
+	//
+	//	ExpiresAt time.Time  `validate:"after=now"`
+	//	StartsAt  *time.Time `validate:"after=2024-01-01T09:00:00-03:00"`
+	AFTER string = "after"
+
+	// Use if field must contain a URL-safe base64-encoded string (only works on strings), such as a pagination cursor.
+	// Padding is optional and an empty string is considered valid.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Cursor  string   `validate:"base64url"`
+	//	Cursors []string `validate:"base64url"`
+	BASE64URL string = "base64url"
+
+	// Use if a time (`time.Time`) must be earlier than the given one, in the same forms accepted by `after`.
+	//
+	// Examples:
+	//
+	//	BirthDate time.Time `validate:"before=now"`
+	//	SentAt    time.Time `validate:"before=2030-01-01"`
+	BEFORE string = "before"
+
+	// Use if field must contain an IP address with a prefix length in CIDR notation, as in `192.0.2.0/24` or `2001:db8::/32`
+	// (only works on strings).
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Network  string   `validate:"cidr"`
+	//	Networks []string `validate:"cidr"`
+	CIDR string = "cidr"
+
+	// Use if field must contain a payment card number (only works on strings), optionally grouped by spaces or hyphens,
+	// as in `4111 1111 1111 1111`. The check digit is verified with the Luhn algorithm. See `IsCreditCard`.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	CardNumber  string   `validate:"creditcard"`
+	//	CardNumbers []string `validate:"creditcard"`
+	CREDITCARD string = "creditcard"
+
 	// Use if field must have a valid currency code as value (only works on strings).
 	//
 	// If the field is a slice or an array of strings, the slice/array type itself
@@ -45,6 +122,30 @@ const (
 	//	Dates  []string  `validate:"datetime"`
 	DATETIME string = "datetime"
 
+	// Use if field must only contain decimal digits (only works on strings), as in postal codes and PINs.
+	// Leading zeros are preserved. An optional range (`min..max`) limits the number of digits.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Pin   string   `validate:"digits=4..6"`
+	//	Codes []string `validate:"digits"`
+	DIGITS string = "digits"
+
+	// Use if field must contain a phone number in the E.164 format, as in `+5511987654321` (only works on strings).
+	// Formatted numbers are not accepted. See `PHONE` for those.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Phone  string   `validate:"e164"`
+	//	Phones []string `validate:"e164"`
+	E164 string = "e164"
+
 	// Use if field must contain an email address (only works on strings).
 	//
 	// If the field is a slice or an array of strings, the slice/array type itself
@@ -58,13 +159,57 @@ const (
 
 	// Use if string must have exactly 'eq' number of characters
 	// or if integer must be exactly equal to this value.
+	// Durations (`time.Duration`) and times (`time.Time`) are compared as in `min`.
 	//
 	// Examples:
 	//
 	//	Cards   []Card  `validate:"eq=2"`
 	EQUAL string = "eq"
 
+	// Use if field must be equal to another field of the same struct, referenced by its JSON or Go name.
+	//
+	// Examples:
+	//
+	//	Password             string `json:"password"`
+	//	PasswordConfirmation string `json:"password_confirmation" validate:"eqfield=Password"`
+	EQFIELD string = "eqfield"
+
+	// Use if exactly one of the fields sharing the same group (within the same struct) must be set.
+	// See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	// If none or more than one of them are set, every field in the group is reported.
+	//
+	// Examples:
+	//
+	//	Card   *Card   `validate:"exactly_one_of=payment"`
+	//	Bank   *Bank   `validate:"exactly_one_of=payment"`
+	//	Wallet *Wallet `validate:"exactly_one_of=payment"`
+	EXACTLY_ONE_OF string = "exactly_one_of"
+
+	// Use if field must contain a decimal number (only works on strings), as in `"-12.5"` or `"1e3"`.
+	// An optional range (`min..max`) limits the value of the number. Either bound can be omitted.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Amount  string   `validate:"floatstr=0.."`
+	//	Amounts []string `validate:"floatstr"`
+	FLOATSTR string = "floatstr"
+
+	// Use if field must be greater than another field of the same struct, referenced by its JSON or Go name.
+	// Numbers and times (`time.Time`) are compared by value, while strings, slices and maps are compared by length.
+	// The fields are not compared unless both of them are set. See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	//
+	// Examples:
+	//
+	//	StartsAt time.Time `json:"starts_at"`
+	//	EndsAt   time.Time `json:"ends_at" validate:"gtfield=starts_at"`
+	GTFIELD string = "gtfield"
+
 	// Use if field must be equal to one of the provided options.
+	// Options can also be provided at validation time by a value provider, referenced by its name
+	// prefixed by `VALUE_PROVIDER_PREFIX` (see `ValueProviders`).
 	//
 	// If the field is an array or a slice, each of its contained elements will be validated individually.
 	//
@@ -74,41 +219,263 @@ const (
 	//	Roles  []string `validate:"in=ADMIN|GUEST|SUPER USER"`
 	//	Level  int      `validate:"in=1|5|20"`
 	//	Levels []int    `validate:"in=1|5|20"`
+	//
+	//	Currency string `validate:"in=@currencies"`
 	IN string = "in"
 
-	// Use if string must have at least 'min' number of characters
-	// or if integer must be greater than or equal to this value.
+	// Use if field must contain an IBAN (only works on strings), as in `GB82 WEST 1234 5698 7654 32`.
+	// The length is checked against the country and the check digits are verified with the mod-97 algorithm. See `IsIBAN`.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
 	//
 	// Examples:
 	//
-	//	Name   string   `validate:"max=5"`
-	//	Roles  []string `validate:"max=1"`
-	//	Age    int      `validate:"max=18"`
+	//	Account  string   `validate:"iban"`
+	//	Accounts []string `validate:"iban"`
+	IBAN string = "iban"
+
+	// Use if field must contain an integer (only works on strings), as in `"-42"`.
+	// An optional range (`min..max`) limits the value of the integer. Either bound can be omitted.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Quantity   string   `validate:"intstr=1..100"`
+	//	Quantities []string `validate:"intstr=..100"`
+	INTSTR string = "intstr"
+
+	// Use if field must contain an IPv4 or IPv6 address, as in `192.0.2.1` or `2001:db8::1` (only works on strings).
+	// See `IPV4` and `IPV6` to accept a single version.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Address   string   `validate:"ip"`
+	//	Addresses []string `validate:"ip"`
+	IP string = "ip"
+
+	// Use if field must contain an IPv4 address in dotted decimal notation, as in `192.0.2.1` (only works on strings).
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Address   string   `validate:"ipv4"`
+	//	Addresses []string `validate:"ipv4"`
+	IPV4 string = "ipv4"
+
+	// Use if field must contain an IPv6 address, as in `2001:db8::1` (only works on strings).
+	// IPv4-mapped addresses, as in `::ffff:192.0.2.1`, are IPv6 addresses as well.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Address   string   `validate:"ipv6"`
+	//	Addresses []string `validate:"ipv6"`
+	IPV6 string = "ipv6"
+
+	// Shorthand for the `min` and `max` rules of strings, slices and arrays, as in `len=2..5` (`min=2,max=5`).
+	// Either bound can be omitted, as in `len=2..`. A single value, as in `len=5`, stands for `eq=5`.
+	//
+	// Examples:
+	//
+	//	Name   string   `validate:"len=2..50"`
+	//	Roles  []string `validate:"len=1.."`
+	LENGTH string = "len"
+
+	// Use if field must contain a KSUID, as in `0ujtsYcgvSTl8PAuAdqWYSMnLOv` (only works on strings). See `IsKSUID`.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Id  string   `validate:"ksuid"`
+	//	Ids []string `validate:"ksuid"`
+	KSUID string = "ksuid"
+
+	// Use if field must contain a hardware (MAC) address, as in `00:00:5e:00:53:01`, `00-00-5E-00-53-01` or `0000.5e00.5301`
+	// (only works on strings). EUI-64 and 20-octet IP over InfiniBand addresses are accepted as well.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Address   string   `validate:"mac"`
+	//	Addresses []string `validate:"mac"`
+	MAC string = "mac"
+
+	// Use if string must have at most 'max' number of characters
+	// or if integer must be less than or equal to this value.
+	// Durations (`time.Duration`) and times (`time.Time`) are compared as in `min`.
+	//
+	// Examples:
+	//
+	//	Name    string        `validate:"max=5"`
+	//	Roles   []string      `validate:"max=1"`
+	//	Age     int           `validate:"max=18"`
+	//	Timeout time.Duration `validate:"max=30s"`
 	MAX string = "max"
 
 	// Use if string must have at least 'min' number of characters
 	// or if integer must be greater than or equal to this value.
+	// Durations (`time.Duration`) are compared to durations, as in `1h30m`.
+	// Times (`time.Time`) are compared (inclusively) to times in the same forms accepted by `after`.
 	//
 	// Examples:
 	//
-	//	Name   string   `validate:"min=5"`
-	//	Roles  []string `validate:"min=1"`
-	//	Age    int      `validate:"min=18"`
+	//	Name     string        `validate:"min=5"`
+	//	Roles    []string      `validate:"min=1"`
+	//	Age      int           `validate:"min=18"`
+	//	Interval time.Duration `validate:"min=1h"`
+	//	Birthday time.Time     `validate:"min=1900-01-01"`
 	MIN string = "min"
 
+	// Use if at least the given period must have passed since a date, as in the birthdate of someone who must be of age.
+	// Periods are made of years (`y`), months (`m`), weeks (`w`) and days (`d`), as in `18y` or `1y6m`.
+	// Works on times (`time.Time`) and on strings holding an RFC 3339 timestamp or a date (as in `2006-01-02`).
+	// Calendar days are counted in `ValidationOptions.Location`. See `HasMinAge`.
+	//
+	// Examples:
+	//
+	//	BirthDate time.Time `validate:"min_age=18y"`
+	//	Birthday  string    `validate:"min_age=21y"`
+	MIN_AGE string = "min_age"
+
+	// Use if a string must contain at least one character other than whitespace.
+	// Other values must be set, as in `required`. It is applied to each element of a slice/array.
+	//
+	// Examples:
+	//
+	//	Name string   `validate:"notblank"`
+	//	Tags []string `validate:"notblank"`
+	NOTBLANK string = "notblank"
+
+	// Use if field must contain a phone number (only works on strings), as in `+55 (11) 98765-4321`.
+	// International numbers (starting with `+` or `00`) are always accepted. National numbers are only accepted
+	// when a region (ISO 3166-1 alpha-2) is given, as in `phone=BR`. An unknown region results in an `UNEXPECTED_ERROR`.
+	// See `NormalizePhone`.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Phone  string   `validate:"phone"`
+	//	Phones []string `validate:"phone=BR"`
+	PHONE string = "phone"
+
+	// Use if a decimal number must have at most the given number of significant digits, as in the precision of a
+	// `NUMERIC(precision, scale)` column. Works on numbers and on strings holding a plain decimal, as in `-1234.56`.
+	// When the field has a `scale` rule as well, the digits after the decimal point count as `scale` digits at least,
+	// so `precision=10,scale=2` leaves room for 8 digits before the decimal point, as the column does. See `FitsNumeric`.
+	//
+	// If the field is a slice or an array, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Price  float64 `validate:"precision=10,scale=2"`
+	//	Amount string  `validate:"precision=18,scale=6"`
+	PRECISION string = "precision"
+
+	// Shorthand for the `min` and `max` rules of numbers, as in `range=1..100` (`min=1,max=100`).
+	// Either bound can be omitted, as in `range=..100`. A single value, as in `range=5`, stands for `eq=5`.
+	//
+	// Examples:
+	//
+	//	Age    int     `validate:"range=18..130"`
+	//	Price  float64 `validate:"range=0.01.."`
+	RANGE string = "range"
+
 	// Use if field must contain a value that matches the specified regular expression.
 	//
 	// If the field is a slice or an array, the slice/array type itself
 	// won't be validated, but each of its contained elements will be validated individually.
 	//
+	// The pattern is enclosed in parentheses and may contain commas and equal signs.
+	// Since struct tags are quoted strings, backslashes must be escaped.
+	// A pattern that does not compile results in an `UNEXPECTED_ERROR`.
+	//
 	// Examples:
 	//
-	//	Name   string   `json:"name"   format:"regex([aA-zZ]+)"`
-	//	Phone  string   `json:"phone"  format:"regex(\d{3}.\d{3}.\d{4})"`
-	//	Phones []string `json:"phones" format:"regex(\d{3}.\d{3}.\d{4})"`
+	//	Name   string   `json:"name"   validate:"regex(^[a-zA-Z]+$)"`
+	//	Phone  string   `json:"phone"  validate:"regex(^\\d{3}-\\d{4}$)"`
+	//	Phones []string `json:"phones" validate:"regex(^\\d{3}-\\d{4}$)"`
+	//	Code   string   `json:"code"   validate:"regex(^[A-Z]{2,3}=\\d+$)"`
 	REGEX string = "regex"
 
-	// Use if field must contain a URL (only works on strings).
+	// Use if field must be set. See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	// Unlike the `required` rule of the jsonschema tag, it also applies to structs that were not decoded from a payload.
+	// It is not applied to the elements of a slice/array, which would require `each:required`.
+	//
+	// Examples:
+	//
+	//	Name  string   `validate:"required"`
+	//	Roles []string `validate:"required"`
+	REQUIRED string = "required"
+
+	// Use if field must be set whenever other fields of the same struct have the given values.
+	// Fields (referenced by their JSON or Go names) and values are separated by spaces, as in `Field value`.
+	// When more than one pair is given, the field is only required if all of them match.
+	// See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	//
+	// Examples:
+	//
+	//	State string `validate:"required_if=Country US"`
+	//	Vat   string `validate:"required_if=Type company Country DE"`
+	REQUIRED_IF string = "required_if"
+
+	// Use if field must be set whenever any of the listed fields (of the same struct) is set.
+	// Fields are referenced by their JSON or Go names. See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	//
+	// Examples:
+	//
+	//	Cvv string `validate:"required_with=card_number|expiration"`
+	REQUIRED_WITH string = "required_with"
+
+	// Use if field must be set whenever any of the listed fields (of the same struct) is not set.
+	// Fields are referenced by their JSON or Go names. See `AT_LEAST_ONE_OF` for what it means for a field to be set.
+	//
+	// Examples:
+	//
+	//	Phone string `validate:"required_without=Email"`
+	REQUIRED_WITHOUT string = "required_without"
+
+	// Use if a time must fall on the same calendar day as another field of the same struct, referenced by its JSON or Go name.
+	// Works on times (`time.Time`) and on strings holding an RFC 3339 timestamp or a date (as in `2006-01-02`).
+	// Calendar days are told apart in `ValidationOptions.Location`. Unset fields are not compared. See `IsSameDay`.
+	//
+	// Examples:
+	//
+	//	StartsAt time.Time `json:"starts_at"`
+	//	EndsAt   time.Time `json:"ends_at" validate:"same_day_as=starts_at"`
+	SAME_DAY_AS string = "same_day_as"
+
+	// Use if a decimal number must have at most the given number of digits after the decimal point, as in the scale of a
+	// `NUMERIC(precision, scale)` column. Trailing zeros are not counted. Works on numbers and on strings holding a plain decimal.
+	// See `PRECISION`.
+	//
+	// Examples:
+	//
+	//	Price  float64 `validate:"scale=2"`
+	//	Amount string  `validate:"precision=18,scale=6"`
+	SCALE string = "scale"
+
+	// Prefix of the names of value providers, when used as the value of the `in` rule. See `ValueProviders`.
+	VALUE_PROVIDER_PREFIX string = "@"
+
+	// Use if field must contain an absolute URL, with both a scheme and a host (only works on strings).
+	// The accepted schemes can be restricted by listing them, as in `url=https|http`.
 	//
 	// If the field is a slice or an array of strings, the slice/array type itself
 	// won't be validated, but each of its contained elements will be validated individually.
@@ -117,8 +484,20 @@ const (
 	//
 	//	Website  string   `format:"url"`
 	//	Websites []string `format:"url"`
+	//	Callback string   `validate:"url=https"`
 	URL string = "url"
 
+	// Use if field must contain a ULID, as in `01ARZ3NDEKTSV4RRFFQ69G5FAV` (only works on strings). See `IsULID`.
+	//
+	// If the field is a slice or an array of strings, the slice/array type itself
+	// won't be validated, but each of its contained elements will be validated individually.
+	//
+	// Examples:
+	//
+	//	Id  string   `validate:"ulid"`
+	//	Ids []string `validate:"ulid"`
+	ULID string = "ulid"
+
 	// Use if field must contain a UUID-formated string (only works on strings).
 	//
 	// If the field is a slice or an array of strings, the slice/array type itself
@@ -129,28 +508,292 @@ const (
 	//	Id        string   `format:"uuid"`
 	//	Accounts  []string `format:"uuid"`
 	UUID string = "uuid"
+
+	// Use if field must contain a version 4 (random) UUID, in the forms accepted by `uuid`.
+	// Fields of the `uuid.UUID` type are accepted as well.
+	//
+	// Examples:
+	//
+	//	Id        string    `validate:"uuid4"`
+	//	AccountId uuid.UUID `validate:"uuid4"`
+	UUID4 string = "uuid4"
+
+	// Use if field must contain a version 7 (time-ordered) UUID, in the forms accepted by `uuid`.
+	// Fields of the `uuid.UUID` type are accepted as well.
+	//
+	// Examples:
+	//
+	//	Id string `validate:"uuid7"`
+	UUID7 string = "uuid7"
 )
 
+// The default error codes returned by the validators.
+//
+// This map is shared by all goroutines and must not be modified once validation starts.
+// Use `ValidationOptions.ErrorCodes` to customize error codes per call, or `NewValidator` for a validator with its own codes.
 var Errors = map[string]string{
-	"immutable": "IMMUTABLE_VALUE",
-	"format":    "INVALID_FORMAT",
-	"length":    "INVALID_LENGTH",
-	"type":      "INVALID_TYPE",
-	"value":     "INVALID_VALUE",
+	"immutable":    "IMMUTABLE_VALUE",
+	"format":       "INVALID_FORMAT",
+	"length":       "INVALID_LENGTH",
+	"type":         "INVALID_TYPE",
+	"value":        "INVALID_VALUE",
+	"unexpected":   "UNEXPECTED_ERROR",
+	"unregistered": "UNREGISTERED_MODEL",
+	"model":        "INVALID_MODEL",
+	"exclusive":    "MUTUALLY_EXCLUSIVE",
+	"missing_one":  "MISSING_ONE_OF",
+	"required":     "REQUIRED_ATTRIBUTE_MISSING",
+	"mismatch":     "FIELD_MISMATCH",
+	"unknown_rule": "UNKNOWN_RULE",
 }
 
+var (
+	// Alternative names for validation rules, mapped to the name of the rule they stand for.
+	// This allows tag vocabularies that drifted apart to be validated the same way.
+	// For example: {"is_email": "email"}
+	//
+	// This map is shared by all goroutines and must not be modified once validation starts.
+	// Use `ValidationOptions.RuleAliases` to customize aliases per call instead.
+	RuleAliases = map[string]string{}
+
+	// Alternative keys for validation tags, mapped to the key of the tag they stand for.
+	// Rules found in aliased tags are validated as if they were declared in that tag, which must be either
+	// the validation tag (see `VALIDATION_TAG_KEYWORD`) or one of the `AdditionalTags`.
+	// For example: {"format": "validate"} validates `format:"uuid"` as `validate:"uuid"`.
+	//
+	// This map is shared by all goroutines and must not be modified once validation starts.
+	// Use `ValidationOptions.TagAliases` to customize aliases per call instead.
+	TagAliases = map[string]string{}
+
+	// Rules (or aliases) that should no longer be used, mapped to a message explaining how to migrate away from them.
+	// Deprecated rules are still validated, but are reported by `Lint`.
+	// For example: {"is_email": "use `email` instead"}
+	//
+	// This map is shared by all goroutines and must not be modified once validation starts.
+	DeprecatedRules = map[string]string{}
+
+	// Functions returning the values accepted by the `in` rule, keyed by the name they are referenced by.
+	// Providers are called every time a field referencing them is validated, so the accepted values can come from
+	// a database or the configuration of the service. For example: {"currencies": func() []string { ... }}
+	//
+	// A field referencing a provider that does not exist gets an `UNEXPECTED_ERROR`.
+	//
+	// This map is shared by all goroutines and must not be modified once validation starts.
+	// Use `ValidationOptions.ValueProviders` to customize providers per call instead.
+	ValueProviders = map[string]func() []string{}
+)
+
 type (
 	ValidationOptions struct {
 		Ignore    []string
 		SkipRules []string
+
+		// Attributes of the validation tag that are not applied to the elements of a slice/array.
+		// Defaults to `structs.NON_INHERITABLE_TAG_ATTRIBUTES` when nil.
+		// Use an empty (non-nil) list to apply all the rules of the slice/array to each of its elements.
+		NonInheritableTagAttributes []string
+
+		// Unexported fields to validate, named as in the struct. Other unexported fields are skipped.
+		UnexportedFields []string
+
+		// Forms of UUIDs accepted by the `uuid` rule. Only canonical UUIDs are accepted by default.
+		UUID UUIDOptions
+
+		// The paths of the attributes present in the payload, as reported by `structs.DecodeWithResult`.
+		// When set, rules that depend on whether a field is set (i.e. `required_with`) check if the field is present
+		// in the payload, instead of checking its value. This tells apart fields explicitly set to zero values from missing ones.
+		// `ValidatePayload` fills it in when it is nil.
+		Present []string
+
+		// A prefix applied to every key in the returned validations.
+		// For example: `payload.` or `items[2].`
+		KeyPrefix string
+
+		// A function that runs before the rules of each attribute are checked.
+		// This could be used for normalizing values prior to validation.
+		BeforeAttribute func(attribute structs.StructAttribute) structs.StructAttribute
+
+		// A function that runs for every attribute that fails validation, receiving its errors.
+		// This could be used for logging or reporting errors.
+		// The values of sensitive fields are masked. See `ExposeSensitive`.
+		OnError func(attribute structs.StructAttribute, errs []string)
+
+		// The values of attributes tagged as sensitive (see `structs.SENSITIVE_TAG_KEYWORD`), and of the ones nested
+		// inside them, are replaced by `structs.REDACTED_VALUE` before reaching `OnError`. When set, they are passed as they are.
+		// The values found in validation results (see `ValidateResult`) are always masked.
+		ExposeSensitive bool
+
+		// The tag used to identify sensitive fields. Defaults to `structs.SENSITIVE_TAG_KEYWORD`.
+		SensitiveTag string
+
+		// A function that runs after the validator is done processing the model.
+		// This could be used for ignoring certain errors or providing custom error messages.
+		AfterValidate func(validations map[string][]string) map[string][]string
+
+		// Error codes that should be used instead of the defaults found in `Errors`, keyed the same way.
+		// For example: {"format": "BAD_FORMAT"}
+		ErrorCodes map[string]string
+
+		// Tags the validation rules are read from, besides the validation tag (see `VALIDATION_TAG_KEYWORD`).
+		// This eases migrating from other validators without rewriting every tag at once.
+		// For example: `binding`.
+		AdditionalTags []string
+
+		// Alternative names for validation rules that should be used instead of the defaults found in `RuleAliases`.
+		// For example: {"is_email": "email"}
+		RuleAliases map[string]string
+
+		// Alternative keys for validation tags that should be used instead of the defaults found in `TagAliases`.
+		// For example: {"format": "validate"}
+		TagAliases map[string]string
+
+		// The locale (i.e. `pt-BR`) of the messages attached to the errors returned by `ValidateResult`.
+		// No messages are attached when empty. See `RegisterTranslations`.
+		// The output of `Validate` is not localized: it holds error codes, which clients, `AfterValidate`
+		// and `ErrorCodes` rely on, so replacing them with messages would break them.
+		// Use `ValidateResult(...).Messages()` for the localized counterpart of `Validate`.
+		Locale string
+
+		// Records every rule checked, along with its outcome and duration. See `Trace`.
+		Trace *Trace
+
+		// Records the failures found by every validation, once `AfterValidate` runs. See `FailureCollector`.
+		Collector *FailureCollector
+
+		// Value providers that should be used instead of the defaults found in `ValueProviders`.
+		ValueProviders map[string]func() []string
+
+		// When set, panics raised while validating an attribute (including the ones raised by `BeforeAttribute`,
+		// by `Validatable` structs and while walking the model, see `structs.AttributePanic`) are recovered from
+		// and reported as an `UNEXPECTED_ERROR` for the offending attribute.
+		// Panics that do not belong to an attribute, as the ones raised by `AfterValidate`, are reported under the `_` key.
+		Recover bool
+
+		// When set, rules that are neither built-in nor registered (see `RegisterRule`) result in an `UNKNOWN_RULE` error
+		// for the attributes using them, instead of being silently ignored. This surfaces typos such as `validate:"emial"`.
+		// See `Lint` for finding unknown rules without validating values.
+		StrictRules bool
+
+		// The location used to tell calendar days apart (see `same_day_as` and `min_age`)
+		// and to read dates without a time zone, as in `after=2030-01-01`. Defaults to UTC.
+		// For example: `time.LoadLocation("America/Sao_Paulo")`
+		Location *time.Location
+
+		// Names of the boolean options (i.e. `Recover`, or `UUID.Uppercase` for nested ones) that should be turned off
+		// when these options are merged on top of others, since booleans are otherwise kept if either of them enables it.
+		// Names are kept in the merged options, so they still apply when those are merged on top of others
+		// (as done by `WithOptions`), unless later overrides enable the options again. Unknown names are ignored.
+		// For example: []string{"Recover", "StrictRules"}
+		DisabledOptions []string
+
+		// Set by `NewValidator`, whose options carry copies of the package-level defaults (`Errors`, `RuleAliases`,
+		// `TagAliases` and `ValueProviders`): those are then only read from the options, never from the defaults.
+		isolated bool
+	}
+
+	// Forms of UUIDs accepted by the `uuid` rule, besides the canonical one (lowercase and hyphenated).
+	UUIDOptions struct {
+		// Accept uppercase (or mixed-case) hexadecimal digits, as in `2BF99C42-4777-4796-9131-6CBC13D951C8`.
+		Uppercase bool
+
+		// Accept UUIDs wrapped in braces, as in `{2bf99c42-4777-4796-9131-6cbc13d951c8}`.
+		Braces bool
+
+		// Accept UUIDs in the URN form, as in `urn:uuid:2bf99c42-4777-4796-9131-6cbc13d951c8`.
+		URN bool
 	}
 
 	PayloadValidationOptions struct {
 		ValidationOptions
 		structs.DecoderOptions
+
+		// When set, panics are recovered from while both decoding and validating the payload.
+		// Since both embedded options declare it, `options.Recover` refers to this field,
+		// which is applied on top of `ValidationOptions.Recover` and `structs.DecoderOptions.Recover`.
+		Recover bool
+
+		// Error codes that should be used instead of the defaults, while both decoding and validating the payload.
+		// Since both embedded options declare it, `options.ErrorCodes` refers to this field,
+		// whose codes take precedence over the ones of `ValidationOptions.ErrorCodes` and `structs.DecoderOptions.ErrorCodes`.
+		ErrorCodes map[string]string
+	}
+
+	// Validation errors found in a single field of a fixed-width record.
+	RecordError struct {
+		// Number (1-based) of the line containing the record.
+		Line int
+
+		// Position (1-based) of the first character of the field in the line.
+		// This is 0 for errors that do not belong to a fixed-width field.
+		Column int
+
+		// Number of characters the field takes in the line.
+		Length int
+
+		// The full name of the attribute. See `structs.StructAttribute.FullName()`.
+		Path string
+
+		Errors []string
 	}
 )
 
+// A validator carrying its own configuration, which is applied to every model it validates.
+//
+// The package-level defaults (`Errors`, `RuleAliases`, `TagAliases`, `ValueProviders` and `structs.NON_INHERITABLE_TAG_ATTRIBUTES`)
+// are copied into the validator when it is created, so validators with different settings can be used by different
+// goroutines without interfering with each other, and changes made to the defaults afterwards do not affect them.
+//
+// Usage:
+//
+//	validator := NewValidator(ValidationOptions{ErrorCodes: map[string]string{"format": "BAD_FORMAT"}})
+//	errs := validator.Validate(person) // -> {email: ["BAD_FORMAT"]}
+type Validator struct {
+	options ValidationOptions
+}
+
+// Returns a validator configured with the given options. The lists and maps of the options are copied,
+// so modifying them afterwards does not affect the validator. See `Validator`.
+func NewValidator(options ValidationOptions) *Validator {
+	options = options.Merge(ValidationOptions{})
+
+	options.ErrorCodes = collections.MergeMaps(Errors, options.ErrorCodes)
+	options.RuleAliases = collections.MergeMaps(RuleAliases, options.RuleAliases)
+	options.TagAliases = collections.MergeMaps(TagAliases, options.TagAliases)
+	options.isolated = true
+	options.ValueProviders = collections.MergeMaps(ValueProviders, options.ValueProviders)
+
+	if options.NonInheritableTagAttributes == nil {
+		options.NonInheritableTagAttributes = append([]string{}, structs.NON_INHERITABLE_TAG_ATTRIBUTES...)
+	}
+
+	return &Validator{options: options}
+}
+
+// Returns a copy of the options of the validator, with the error codes, aliases and providers it uses. See `NewValidator`.
+func (v *Validator) Options() ValidationOptions {
+	return v.options.Merge(ValidationOptions{})
+}
+
+// Validates the model using the options of the validator. See `Validate`.
+func (v *Validator) Validate(model any) map[string][]string {
+	return Validate(model, v.options)
+}
+
+// Validates the model using the options of the validator, keeping the context of each error. See `ValidateResult`.
+func (v *Validator) ValidateResult(model any) ValidationResult {
+	return ValidateResult(model, v.options)
+}
+
+// Decodes the payload with the given decoder (or with the default options, when nil)
+// and validates it using the options of the validator. See `ValidatePayload`.
+func (v *Validator) ValidatePayload(data []byte, model any, decoder *structs.JSONDecoder) map[string][]string {
+	if decoder == nil {
+		decoder = structs.NewJSONDecoder(structs.DecoderOptions{})
+	}
+
+	return ValidatePayload(data, model, PayloadValidationOptions{ValidationOptions: v.options, DecoderOptions: decoder.Options()})
+}
+
 // Validates a struct and its attributes and returns a list of validation errors.
 //
 // Usage:
@@ -161,30 +804,123 @@ type (
 //
 //	r := Resource{Id: "abc"}
 //	errs := ValidateAttribute(r) // -> {id: ["INVALID_FORMAT"]}
-func Validate(model any, options ValidationOptions) map[string][]string {
-	validations := make(map[string][]string)
+func Validate(model any, options ValidationOptions) (validations map[string][]string) {
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				validations = map[string][]string{options.KeyPrefix + recoveredPath(r): {options.errorCode("unexpected")}}
+			}
+		}()
+	}
 
-	attributes := structs.GetAttributes(
-		reflect.ValueOf(model),
-		[]string{},
-		options.Ignore...,
-	)
+	return options.afterValidation(validate(model, options).Map())
+}
+
+// Runs the `AfterValidate` hook and records the resulting validations in the `Collector`.
+func (options ValidationOptions) afterValidation(validations map[string][]string) map[string][]string {
+	if options.AfterValidate != nil {
+		validations = options.AfterValidate(validations)
+	}
+
+	if options.Collector != nil {
+		options.Collector.Observe(validations)
+	}
+
+	return validations
+}
+
+// Validates the model and returns the errors found, along with the rules that caused them. See `ValidationResult`.
+// Models whose types have nothing to validate (see `typeProgram`) are not walked, unless `BeforeAttribute` is set.
+func validate(model any, options ValidationOptions) ValidationResult {
+	var program *typeProgram
+	if t := reflect.TypeOf(model); t != nil {
+		if program = options.typeProgram(t); program.empty && options.BeforeAttribute == nil {
+			return ValidationResult{}
+		}
+	}
+
+	return validateAttributes(model, program, func() structs.StructAttributes {
+		return structs.GetAttributesWithOptions(reflect.ValueOf(model), options.attributeOptions())
+	}, options)
+}
 
-	for pos := 0; pos < len(attributes); pos++ {
-		attr := attributes[pos]
-		errs := ValidateAttribute(attr, options)
+// Validates the attributes returned by `walk`, which belong to the given model (nil when validating a document).
+// Rules are looked up in the program of the type of the model, if any.
+// Panics raised while walking are recovered from if `Recover` is set.
+func validateAttributes(model any, program *typeProgram, walk func() structs.StructAttributes, options ValidationOptions) (result ValidationResult) {
+	result = ValidationResult{}
+
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				result = ValidationResult{options.localized(ValidationError{Path: options.KeyPrefix + recoveredPath(r), Code: options.errorCode("unexpected")})}
+			}
+		}()
+	}
+
+	attributes := walk()
+
+	for pos := 0; pos < len(attributes); {
+		attr, rule, ruleValue, errs := validateAttribute(attributes[pos], program, options)
 
 		if len(errs) != 0 {
-			validations[attr.FullName()] = errs
+			result = append(result, options.validationErrors(attr, rule, ruleValue, errs)...)
+
+			if options.OnError != nil {
+				options.OnError(options.observedAttribute(attr), errs)
+			}
 
+			// The elements of an invalid slice/array/map are not validated
 			switch attr.Value.Kind() {
-			case reflect.Slice, reflect.Array:
-				pos += attr.SkipsPastLastChild()
+			case reflect.Slice, reflect.Array, reflect.Map:
+				pos = attributes.NextSibling(pos)
+				continue
 			}
 		}
+
+		pos++
 	}
 
-	return validations
+	for _, failure := range crossFieldFailures(attributes, options) {
+		result = append(result, options.validationErrors(failure.attribute, failure.rule, failure.ruleValue, failure.errs)...)
+
+		if options.OnError != nil {
+			options.OnError(options.observedAttribute(failure.attribute), failure.errs)
+		}
+	}
+
+	return append(result, structFailures(model, attributes, options)...)
+}
+
+// Returns the options used to walk the attributes of the models.
+func (options ValidationOptions) attributeOptions() structs.AttributeOptions {
+	return structs.AttributeOptions{
+		IgnoredFields:               options.Ignore,
+		NonInheritableTagAttributes: options.NonInheritableTagAttributes,
+		AdditionalValidationTags:    options.additionalTags(),
+		UnexportedFields:            options.UnexportedFields,
+	}
+}
+
+// Runs the `BeforeAttribute` hook and validates the resulting attribute.
+// Returns the rule the attribute failed along with its errors.
+func validateAttribute(attribute structs.StructAttribute, program *typeProgram, options ValidationOptions) (attr structs.StructAttribute, rule string, ruleValue string, errs []string) {
+	attr = attribute
+
+	if options.Recover {
+		defer func() {
+			if r := recover(); r != nil {
+				rule, ruleValue, errs = "", "", []string{options.errorCode("unexpected")}
+			}
+		}()
+	}
+
+	if options.BeforeAttribute != nil {
+		attr = options.BeforeAttribute(attr)
+	}
+
+	rule, ruleValue, errs = checkProgram(attr, program.fieldRules(attr.Field, options), options)
+	return attr, rule, ruleValue, errs
 }
 
 // Validates a struct attribute and returns a list of validation errors.
@@ -198,152 +934,524 @@ func Validate(model any, options ValidationOptions) map[string][]string {
 //	r := Resource{Name: "abc"}
 //	errs := ValidateAttribute(r["name"]) // -> ["INVALID_FORMAT"]
 func ValidateAttribute(attribute structs.StructAttribute, options ValidationOptions) []string {
-	validations := []string{}
+	_, _, errs := checkRules(attribute, options)
+	return errs
+}
 
-	FORMAT_ERROR := []string{Errors["format"]}
-	TYPE_ERROR := []string{Errors["type"]}
-	VALUE_ERROR := []string{Errors["value"]}
+// Checks the rules of the attribute in order, stopping at the first one that fails.
+// Rules are read from the program compiled for the tags of the attribute. See `ValidationOptions.program`.
+// Returns the canonical name and the value of that rule, along with its errors.
+func checkRules(attribute structs.StructAttribute, options ValidationOptions) (rule string, value string, errs []string) {
+	return checkProgram(attribute, options.program(attribute.Field), options)
+}
 
-	rules := structs.GetTagValues(attribute.Field, VALIDATION_TAG_KEYWORD)
-	for _, validationRule := range rules {
-		// The full validation ruleType. i.e min=20, required, nullable
-		ruleType := validationRule
+// Checks the given compiled rules of the attribute in order, stopping at the first one that fails. See `checkRules`.
+func checkProgram(attribute structs.StructAttribute, program []compiledRule, options ValidationOptions) (rule string, value string, errs []string) {
+	for _, compiled := range program {
+		// Skip this rule
+		if structs.Contains(options.SkipRules, compiled.name) {
+			continue
+		}
 
-		// If the full validation rule contains a value, like min=20, this will be set to 20.
-		var ruleValue string
+		// Shorthands are checked as the rules they stand for. See `expandRules`.
+		switch canonical := options.canonicalRule(compiled.name); canonical {
+		case LENGTH, RANGE:
+			if structs.Contains(options.SkipRules, canonical) {
+				continue
+			}
 
-		// This will split the rule and its value if one exits.
-		// For example, `min=20` will become (min, 20)
-		indexOfAsignment := strings.IndexByte(validationRule, '=')
-		if indexOfAsignment != -1 {
-			ruleType = validationRule[:indexOfAsignment]
-			ruleValue = validationRule[indexOfAsignment+1:]
-		}
+			for _, bound := range compiled.bounds {
+				if rule, value, errs := checkCompiledRule(attribute, bound, options); len(errs) != 0 {
+					return rule, value, errs
+				}
+			}
 
-		// Skip this rule
-		if structs.Contains(options.SkipRules, ruleType) {
 			continue
 		}
 
-		switch ruleType {
-		case CURRENCY:
-			f, err := structs.PointerElement(attribute.Value)
-			if err != nil {
-				return VALUE_ERROR
+		if rule, value, errs := checkCompiledRule(attribute, compiled, options); len(errs) != 0 {
+			return rule, value, errs
+		}
+	}
+
+	return "", "", []string{}
+}
+
+// Checks a single compiled rule against the attribute, unless it is skipped.
+// Returns the canonical name and the value of the rule, along with its errors.
+func checkCompiledRule(attribute structs.StructAttribute, compiled compiledRule, options ValidationOptions) (rule string, value string, errs []string) {
+	// Skip this rule
+	if structs.Contains(options.SkipRules, compiled.name) {
+		return "", "", nil
+	}
+
+	// Aliases are validated as the rule they stand for
+	ruleType := options.canonicalRule(compiled.name)
+	if structs.Contains(options.SkipRules, ruleType) {
+		return "", "", nil
+	}
+
+	// Element rules are only checked against the elements of a slice/array
+	if strings.HasPrefix(ruleType, structs.EACH_RULE_PREFIX) {
+		return "", "", nil
+	}
+
+	var start time.Time
+	if options.Trace != nil {
+		start = time.Now()
+	}
+
+	errs = checkRule(attribute, ruleType, compiled.value, options)
+
+	if options.Trace != nil {
+		options.Trace.record(TraceEntry{
+			Path:     options.KeyPrefix + attribute.FullName(),
+			Rule:     compiled.raw,
+			Passed:   len(errs) == 0,
+			Errors:   errs,
+			Duration: time.Since(start),
+		})
+	}
+
+	return ruleType, compiled.value, errs
+}
+
+// Checks a single rule (identified by its canonical name) against the attribute.
+// Returns nil if the attribute passes the rule, or if the rule is not handled by the validator (unless `StrictRules` is set).
+func checkRule(attribute structs.StructAttribute, ruleType string, ruleValue string, options ValidationOptions) []string {
+	// Error codes are only allocated for the rules that fail
+	failure := func(kind string) []string { return []string{options.errorCode(kind)} }
+
+	switch ruleType {
+	case REQUIRED:
+		if !options.isSet(attribute) {
+			return failure("required")
+		}
+	case NOTBLANK:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("required")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if strings.TrimSpace(f.String()) == "" {
+				return failure("required")
+			}
+		default:
+			if !options.isSet(attribute) {
+				return failure("required")
 			}
+		}
+	case BASE64URL:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
 
-			switch f.Kind() {
-			case reflect.Array, reflect.Slice:
-				// Assume children will be validated individually
-				continue
-			case reflect.String:
-				if _, err := currency.ParseISO(f.String()); err != nil {
-					return VALUE_ERROR
-				}
-			default:
-				return TYPE_ERROR
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if !IsBase64URL(f.String()) {
+				return failure("format")
 			}
-		case DATETIME:
-			f, err := structs.PointerElement(attribute.Value)
-			if err != nil {
-				return FORMAT_ERROR
+		default:
+			return failure("type")
+		}
+	case CURRENCY:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume children will be validated individually
+			return nil
+		case reflect.String:
+			if _, err := currency.ParseISO(f.String()); err != nil {
+				return failure("value")
 			}
+		default:
+			return failure("type")
+		}
+	case DATETIME:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
 
-			switch f.Kind() {
-			case reflect.Array, reflect.Slice:
-				// Assume that children will be validated individually
-				continue
-			case reflect.String:
-				if f.Kind() == reflect.String {
-					if _, err := time.Parse(time.RFC3339, f.String()); err != nil {
-						return FORMAT_ERROR
-					}
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if f.Kind() == reflect.String {
+				if _, err := time.Parse(time.RFC3339, f.String()); err != nil {
+					return failure("format")
+				}
+
+				return nil
+			}
+		default:
+			return failure("type")
+		}
+	case DIGITS, INTSTR, FLOATSTR:
+		bounds, err := parseRange(ruleValue)
+		if err != nil {
+			return failure("value")
+		}
 
-					continue
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			n, ok := parseNumericString(f.String(), ruleType)
+			if !ok {
+				return failure("format")
+			}
+
+			if !bounds.contains(n) {
+				// The range of digits limits the length of the value
+				if ruleType == DIGITS {
+					return failure("length")
 				}
-			default:
-				return TYPE_ERROR
+
+				return failure("value")
 			}
-		case EMAIL:
-			f, err := structs.PointerElement(attribute.Value)
+		default:
+			return failure("type")
+		}
+	case CIDR, IP, IPV4, IPV6, MAC:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if !isNetworkAddress(f.String(), ruleType) {
+				return failure("format")
+			}
+		default:
+			return failure("type")
+		}
+	case E164, PHONE:
+		if _, ok := phoneRegions[strings.ToUpper(ruleValue)]; ruleType == PHONE && ruleValue != "" && !ok {
+			return failure("unexpected")
+		}
+
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if (ruleType == E164 && !IsE164(f.String())) || (ruleType == PHONE && !IsPhone(f.String(), ruleValue)) {
+				return failure("format")
+			}
+		default:
+			return failure("type")
+		}
+	case CREDITCARD, IBAN:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if (ruleType == CREDITCARD && !IsCreditCard(f.String())) || (ruleType == IBAN && !IsIBAN(f.String())) {
+				return failure("format")
+			}
+		default:
+			return failure("type")
+		}
+	case EMAIL:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if _, err := mail.ParseAddress(f.String()); err != nil {
+				return failure("format")
+			}
+		default:
+			return failure("type")
+		}
+	case AFTER, BEFORE:
+		bound, err := parseTimeBound(ruleValue, options.location())
+		if err != nil {
+			return failure("unexpected")
+		}
+
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		switch {
+		case f.Kind() == reflect.Array || f.Kind() == reflect.Slice || f.Kind() == reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case f.Type() == timeType:
+			if !IsValidTime(f.Interface().(time.Time), bound, ruleType) {
+				return failure("value")
+			}
+		default:
+			return failure("type")
+		}
+	case MIN_AGE:
+		years, months, days, err := parseCalendarPeriod(ruleValue)
+		if err != nil {
+			return failure("unexpected")
+		}
+
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		if f.Kind() == reflect.Array || f.Kind() == reflect.Slice || f.Kind() == reflect.Map {
+			// Assume that children will be validated individually
+			return nil
+		}
+
+		date, valid, isTime := timeValue(f, options.location())
+		switch {
+		case !isTime:
+			return failure("type")
+		case !valid:
+			return failure("format")
+		case !HasMinAge(date, time.Now().In(options.location()), years, months, days):
+			return failure("value")
+		}
+	case PRECISION, SCALE:
+		digits, err := strconv.Atoi(ruleValue)
+		if err != nil || digits < 0 || (ruleType == PRECISION && digits == 0) {
+			return failure("unexpected")
+		}
+
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		if f.Kind() == reflect.Array || f.Kind() == reflect.Slice || f.Kind() == reflect.Map {
+			// Assume that children will be validated individually
+			return nil
+		}
+
+		value, ok := decimalValue(f)
+		if !ok {
+			return failure("type")
+		}
+
+		integer, fraction, ok := decimalDigits(value)
+		if !ok {
+			return failure("format")
+		}
+
+		if ruleType == SCALE {
+			if fraction > digits {
+				return failure("value")
+			}
+
+			return nil
+		}
+
+		if scale, ok := options.fieldScale(attribute); ok && scale > fraction {
+			fraction = scale
+		}
+
+		if integer+fraction > digits {
+			return failure("value")
+		}
+	case EQUAL, MAX, MIN:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		if f.Type() == timeType {
+			bound, err := parseTimeBound(ruleValue, options.location())
 			if err != nil {
-				return FORMAT_ERROR
+				return failure("unexpected")
 			}
 
+			if !IsValidTime(f.Interface().(time.Time), bound, ruleType) {
+				return failure("value")
+			}
+
+			return nil
+		}
+
+		parse := parsedLengthAttribute
+		if f.Type() == durationType {
+			parse = parseDurationBound
+		}
+
+		length, err := parse(ruleValue)
+		if err != nil {
+			return failure("value")
+		}
+
+		if !IsValidLength(f, length, ruleType) {
+			var defaultError string
+
 			switch f.Kind() {
-			case reflect.Array, reflect.Slice:
-				// Assume that children will be validated individually
-				continue
-			case reflect.String:
-				if _, err := mail.ParseAddress(f.String()); err != nil {
-					return FORMAT_ERROR
-				}
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+				return failure("value")
 			default:
-				return TYPE_ERROR
-			}
-		case EQUAL, MAX, MIN:
-			length, err := parsedLengthAttribute(ruleValue)
-			if err != nil {
-				return VALUE_ERROR
+				defaultError = options.errorCode("length")
 			}
 
-			f, err := structs.PointerElement(attribute.Value)
-			if err != nil {
-				return VALUE_ERROR
+			return []string{defaultError}
+		}
+	case IN:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("value")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		default:
+			acceptedValues, ok := options.acceptedValues(ruleValue)
+			if !ok {
+				return failure("unexpected")
 			}
 
-			if !IsValidLength(f, length, ruleType) {
-				var defaultError string
+			if !IsIn(f, acceptedValues) {
+				return failure("value")
+			}
+		}
+	case REGEX:
+		re, err := compiledPattern(ruleValue)
+		if err != nil {
+			return failure("unexpected")
+		}
 
-				switch f.Kind() {
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
-					return VALUE_ERROR
-				default:
-					defaultError = Errors["length"]
-				}
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
 
-				return append(validations, defaultError)
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if !re.MatchString(f.String()) {
+				return failure("format")
 			}
-		case IN:
-			f, err := structs.PointerElement(attribute.Value)
-			if err != nil {
-				return VALUE_ERROR
+		default:
+			return failure("type")
+		}
+	case URL:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			schemes := []string{}
+			if ruleValue != "" {
+				schemes = strings.Split(ruleValue, "|")
 			}
 
-			switch f.Kind() {
-			case reflect.Array, reflect.Slice:
-				// Assume that children will be validated individually
-				continue
-			default:
-				acceptedValues := strings.Split(ruleValue, "|")
-				if !IsIn(f, acceptedValues) {
-					return VALUE_ERROR
-				}
+			if !IsURL(f.String(), schemes...) {
+				return failure("format")
 			}
-		case UUID:
-			f, err := structs.PointerElement(attribute.Value)
-			if err != nil {
-				return FORMAT_ERROR
+		default:
+			return failure("type")
+		}
+	case UUID:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
+
+		switch f.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case reflect.String:
+			if !IsUUIDWithOptions(f.String(), options.UUID) {
+				return failure("format")
 			}
+		default:
+			return failure("type")
+		}
+	case KSUID, ULID, UUID4, UUID7:
+		f, err := structs.PointerElement(attribute.Value)
+		if err != nil {
+			return failure("format")
+		}
 
-			switch f.Kind() {
-			case reflect.Array, reflect.Slice:
-				// Assume that children will be validated individually
-				continue
-			case reflect.String:
-				if !IsUUID(f.String()) && len(validations) == 0 {
-					return FORMAT_ERROR
-				}
-			default:
-				return TYPE_ERROR
+		switch {
+		case f.Type() == uuidType && (ruleType == UUID4 || ruleType == UUID7):
+			if id := f.Interface().(uuid.UUID); !isIdentifier(id.String(), ruleType, UUIDOptions{}) {
+				return failure("format")
 			}
+		case f.Kind() == reflect.Array || f.Kind() == reflect.Slice || f.Kind() == reflect.Map:
+			// Assume that children will be validated individually
+			return nil
+		case f.Kind() == reflect.String:
+			if !isIdentifier(f.String(), ruleType, options.UUID) {
+				return failure("format")
+			}
+		default:
+			return failure("type")
+		}
+	default:
+		if fn, ok := registeredRule(ruleType); ok {
+			return checkRegisteredRule(fn, attribute, ruleValue)
+		}
+
+		if options.StrictRules && !isBuiltinRule(ruleType) {
+			return failure("unknown_rule")
 		}
 	}
 
-	return validations
+	return nil
 }
 
 // Decodes and validates the provided payload.
+// With `AtomicPopulate`, the decoded payload is validated before it reaches the model,
+// which is left untouched if either decoding or validating it finds errors.
 //
 // Usage:
 //
@@ -361,26 +1469,147 @@ func ValidateAttribute(attribute structs.StructAttribute, options ValidationOpti
 // }
 // */
 func ValidatePayload(data []byte, model any, options PayloadValidationOptions) map[string][]string {
-	decoderErrors := structs.Decode(
+	options = options.resolved()
+
+	// With `AtomicPopulate`, the payload is decoded into a new instance of the model, which is validated
+	// and only copied into the model if neither decoding nor validating it finds errors
+	target, instance := model, reflect.Value{}
+	if options.AtomicPopulate && structs.ValidateModel(model) == nil {
+		instance = reflect.New(reflect.TypeOf(model).Elem())
+		target = instance.Interface()
+	}
+
+	decoderOptions := options.DecoderOptions
+	decoderOptions.AtomicPopulate = false
+
+	decoded := structs.DecodeWithResult(
 		data,
-		model,
-		options.DecoderOptions,
+		target,
+		decoderOptions,
 	)
 
-	// NOTE: no need to go any further because the payload is invalid.
-	if _, ok := decoderErrors["_"]; ok {
+	if options.Present == nil {
+		options.Present = decoded.Populated
+	}
+
+	decoderErrors := prefixKeys(decoded.Validations, options.KeyPrefix)
+
+	// NOTE: no need to go any further because the payload (or the model) is invalid.
+	if _, ok := decoderErrors[options.KeyPrefix+"_"]; ok {
 		return decoderErrors
 	}
 
-	validations := Validate(model, options.ValidationOptions)
+	validations := structs.MergeValidations(decoderErrors, Validate(target, options.ValidationOptions))
 
-	for k, v := range decoderErrors {
-		validations[k] = v
+	if instance.IsValid() && len(validations) == 0 {
+		reflect.ValueOf(model).Elem().Set(instance.Elem())
 	}
 
 	return validations
 }
 
+// Returns the options with `Recover` and `ErrorCodes` applied to both the decoder and the validation options.
+func (options PayloadValidationOptions) resolved() PayloadValidationOptions {
+	options.ValidationOptions.Recover = options.ValidationOptions.Recover || options.Recover
+	options.DecoderOptions.Recover = options.DecoderOptions.Recover || options.Recover
+
+	if len(options.ErrorCodes) != 0 {
+		options.ValidationOptions.ErrorCodes = collections.MergeMaps(options.ValidationOptions.ErrorCodes, options.ErrorCodes)
+		options.DecoderOptions.ErrorCodes = collections.MergeMaps(options.DecoderOptions.ErrorCodes, options.ErrorCodes)
+	}
+
+	return options
+}
+
+// Returns the error code for the given decoding error (see `structs.DecodingErrors`), giving precedence to `ErrorCodes`
+// and then to the ones of `structs.DecoderOptions`.
+func (options PayloadValidationOptions) decodingError(key string) string {
+	return options.resolved().DecoderOptions.ErrorCode(key)
+}
+
+// Decodes and validates a fixed-width (positional) record. See `structs.DecodeFixedWidth`.
+//
+// Usage:
+//
+//	type Payment struct {
+//		Bank string `json:"bank" fixed:"start=0,len=3" validate:"in=001|341"`
+//	}
+//
+//	var p Payment
+//	errs := ValidateFixedWidth("237", &p, ValidationOptions{}) // -> {bank: ["INVALID_VALUE"]}
+func ValidateFixedWidth(line string, model any, options ValidationOptions) map[string][]string {
+	decoderErrors := prefixKeys(structs.DecodeFixedWidth(line, model), options.KeyPrefix)
+
+	// NOTE: no need to go any further because the model is invalid.
+	if _, ok := decoderErrors[options.KeyPrefix+"_"]; ok {
+		return decoderErrors
+	}
+
+	validations := Validate(model, options)
+
+	return structs.MergeValidations(decoderErrors, validations)
+}
+
+// Decodes and validates every line of a fixed-width (positional) file, such as a CNAB remittance file.
+// Lines are read one at a time, so files of any size can be processed.
+//
+// `newModel` is called once per line and must return a new struct pointer for the line to be decoded into.
+// It receives the line itself, so that different record types (i.e. header, detail and trailer)
+// can be decoded into different structs.
+//
+// Each returned error points to the line and column where the offending field starts.
+// Errors are sorted by their position in the file.
+// A non-nil error is only returned if the file could not be read.
+//
+// Usage:
+//
+//	errs, err := ValidateFixedWidthRecords(file, func(line string) any { return &Payment{} }, ValidationOptions{})
+//	// -> [{Line: 3, Column: 14, Length: 8, Path: amount, Errors: [INVALID_TYPE]}]
+func ValidateFixedWidthRecords(r io.Reader, newModel func(line string) any, options ValidationOptions) ([]RecordError, error) {
+	records := []RecordError{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		model := newModel(text)
+
+		validations := ValidateFixedWidth(text, model, options)
+		if len(validations) == 0 {
+			continue
+		}
+
+		columns, _ := structs.FixedWidthLayout(model)
+
+		for path, errs := range validations {
+			record := RecordError{Line: line, Path: path, Errors: errs}
+
+			for _, column := range columns {
+				if options.KeyPrefix+column.Path == path {
+					record.Column = column.Start + 1
+					record.Length = column.Length
+					break
+				}
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Line != records[j].Line {
+			return records[i].Line < records[j].Line
+		}
+
+		if records[i].Column != records[j].Column {
+			return records[i].Column < records[j].Column
+		}
+
+		return records[i].Path < records[j].Path
+	})
+
+	return records, scanner.Err()
+}
+
 // Returns `true` if value is one of the accepted values.
 //
 // Usage:
@@ -430,8 +1659,107 @@ func IsIn(value reflect.Value, acceptedValues []string) bool {
 //	IsUUID("something") // -> false
 //	IsUUID("2bf99c42-4777-4796-9131-6cbc13d951c8") // -> true
 func IsUUID(value string) bool {
-	pattern := regexp.MustCompile(`^(?:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
-	return pattern.MatchString(value)
+	return IsUUIDWithOptions(value, UUIDOptions{})
+}
+
+// Returns `true` if value is a UUID in one of the forms accepted by the options.
+// Values are parsed with `uuid.Parse`, so any version and variant is accepted.
+//
+// Usage:
+//
+//	IsUUIDWithOptions("{2bf99c42-4777-4796-9131-6cbc13d951c8}", UUIDOptions{})            // -> false
+//	IsUUIDWithOptions("{2bf99c42-4777-4796-9131-6cbc13d951c8}", UUIDOptions{Braces: true}) // -> true
+func IsUUIDWithOptions(value string, options UUIDOptions) bool {
+	digits := value
+
+	switch len(value) {
+	case 36:
+	case 36 + 2:
+		if !options.Braces || value[0] != '{' {
+			return false
+		}
+
+		digits = value[1 : len(value)-1]
+	case 36 + 9:
+		if !options.URN || !strings.HasPrefix(strings.ToLower(value), "urn:uuid:") {
+			return false
+		}
+
+		digits = value[9:]
+	default:
+		return false
+	}
+
+	if !options.Uppercase && digits != strings.ToLower(digits) {
+		return false
+	}
+
+	_, err := uuid.Parse(value)
+	return err == nil
+}
+
+// Returns `true` if value is a URL-safe base64-encoded string, with or without padding.
+//
+// Usage:
+//
+//	IsBase64URL("eyJpZCI6MX0") // -> true
+//	IsBase64URL("not base64!") // -> false
+func IsBase64URL(value string) bool {
+	_, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	return err == nil
+}
+
+// Reports whether the value is a network address of the kind expected by the given rule (`cidr`, `ip`, `ipv4`, `ipv6` or `mac`).
+func isNetworkAddress(value string, rule string) bool {
+	switch rule {
+	case CIDR:
+		_, _, err := net.ParseCIDR(value)
+		return err == nil
+	case MAC:
+		_, err := net.ParseMAC(value)
+		return err == nil
+	}
+
+	if net.ParseIP(value) == nil {
+		return false
+	}
+
+	// IPv6 addresses always contain colons, even the ones embedding an IPv4 address
+	switch rule {
+	case IPV4:
+		return !strings.Contains(value, ":")
+	case IPV6:
+		return strings.Contains(value, ":")
+	}
+
+	return true
+}
+
+// Returns `true` if value is an absolute URL, with both a scheme and a host.
+// When schemes are given, the scheme of the URL must be one of them (case-insensitive).
+//
+// Usage:
+//
+//	IsURL("https://example.com")        // -> true
+//	IsURL("example.com")                // -> false
+//	IsURL("ftp://example.com", "https") // -> false
+func IsURL(value string, schemes ...string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return false
+	}
+
+	if len(schemes) == 0 {
+		return true
+	}
+
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func IsValidLength(v reflect.Value, length float64, rule string) bool {
@@ -470,6 +1798,273 @@ func PassesRegex(pattern, str string) bool {
 	return re.MatchString(str)
 }
 
+// Maximum number of patterns kept in `patternsCache`.
+const maxCachedPatterns = 1024
+
+var (
+	// Compiled regular expressions of the `regex` rules, keyed by their patterns.
+	// Patterns given to `PassesRegex` are not cached, and at most `maxCachedPatterns` rules are,
+	// so patterns built at runtime (i.e. the ones of validation plans) cannot grow the cache indefinitely.
+	patternsCache sync.Map
+
+	// Number of patterns stored in `patternsCache`.
+	cachedPatterns int64
+)
+
+// Returns the compiled regular expression for the pattern of a `regex` rule.
+// Patterns are compiled once and shared between calls, up to `maxCachedPatterns` of them.
+func compiledPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patternsCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if atomic.AddInt64(&cachedPatterns, 1) > maxCachedPatterns {
+		atomic.AddInt64(&cachedPatterns, -1)
+		return re, nil
+	}
+
+	if _, loaded := patternsCache.LoadOrStore(pattern, re); loaded {
+		atomic.AddInt64(&cachedPatterns, -1)
+	}
+
+	return re, nil
+}
+
+// Replaces the `len` and `range` shorthands (or their aliases) by the `min`, `max` or `eq` rules they stand for.
+// Shorthands listed in `SkipRules` are dropped. Other rules are returned as they are.
+//
+// Usage:
+//
+//	options.expandRules([]string{"range=1..100", "len=..5", "len=3"}) // -> [min=1 max=100 max=5 eq=3]
+func (options ValidationOptions) expandRules(rules []string) []string {
+	expanded := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		name, value := ruleParts(rule)
+		if structs.Contains(options.SkipRules, name) {
+			continue
+		}
+
+		switch options.canonicalRule(name) {
+		case LENGTH, RANGE:
+			if structs.Contains(options.SkipRules, options.canonicalRule(name)) {
+				continue
+			}
+
+			for _, bound := range ruleBounds(value) {
+				expanded = append(expanded, bound.raw)
+			}
+		default:
+			expanded = append(expanded, rule)
+		}
+	}
+
+	return expanded
+}
+
+// Returns the name of the rule the given alias stands for, giving precedence to `RuleAliases`.
+// Names that are not aliases are returned as they are.
+// Options created by `NewValidator` only read the aliases they carry. The same goes for the other package-level defaults.
+func (options ValidationOptions) canonicalRule(rule string) string {
+	if canonical, ok := options.RuleAliases[rule]; ok {
+		return canonical
+	}
+
+	if options.isolated {
+		return rule
+	}
+
+	if canonical, ok := RuleAliases[rule]; ok {
+		return canonical
+	}
+
+	return rule
+}
+
+// Returns the tags the validation rules are read from, besides the validation tag:
+// the `AdditionalTags`, followed by the aliases of the validation tags (in alphabetical order). See `TagAliases`.
+// Aliases found in `TagAliases` take precedence over the default ones.
+//
+// Usage:
+//
+//	options := ValidationOptions{AdditionalTags: []string{"binding"}, TagAliases: map[string]string{"format": "validate"}}
+//	options.additionalTags() // -> [binding format]
+func (options ValidationOptions) additionalTags() []string {
+	defaults := TagAliases
+	if options.isolated {
+		defaults = nil
+	}
+
+	if len(options.TagAliases) == 0 && len(defaults) == 0 {
+		return options.AdditionalTags
+	}
+
+	targets := append([]string{VALIDATION_TAG_KEYWORD}, options.AdditionalTags...)
+
+	aliases := []string{}
+	for alias, tag := range collections.MergeMaps(defaults, options.TagAliases) {
+		if structs.Contains(targets, tag) && !structs.Contains(targets, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	sort.Strings(aliases)
+	return append(targets[1:len(targets):len(targets)], aliases...)
+}
+
+// Returns the values accepted by an `in` rule, calling its value provider if the rule references one.
+// Providers found in `ValueProviders` take precedence over the default ones.
+// The second value is `false` if the provider does not exist.
+func (options ValidationOptions) acceptedValues(ruleValue string) ([]string, bool) {
+	if !isValueProvider(ruleValue) {
+		return strings.Split(ruleValue, "|"), true
+	}
+
+	provider, ok := options.valueProvider(ruleValue)
+	if !ok {
+		return nil, false
+	}
+
+	return provider(), true
+}
+
+// Returns the value provider referenced by the value of an `in` rule, giving precedence to `ValueProviders`.
+func (options ValidationOptions) valueProvider(ruleValue string) (func() []string, bool) {
+	name := strings.TrimPrefix(ruleValue, VALUE_PROVIDER_PREFIX)
+
+	provider, ok := options.ValueProviders[name]
+	if !ok && !options.isolated {
+		provider, ok = ValueProviders[name]
+	}
+
+	return provider, ok && provider != nil
+}
+
+func isValueProvider(ruleValue string) bool {
+	return strings.HasPrefix(ruleValue, VALUE_PROVIDER_PREFIX)
+}
+
+// Returns the location calendar days are told apart in. See `Location`.
+func (options ValidationOptions) location() *time.Location {
+	if options.Location == nil {
+		return time.UTC
+	}
+
+	return options.Location
+}
+
+// Returns the error code for the given key, giving precedence to `ErrorCodes`.
+func (options ValidationOptions) errorCode(key string) string {
+	if code, ok := options.ErrorCodes[key]; ok || options.isolated {
+		return code
+	}
+
+	return Errors[key]
+}
+
+// Returns the attribute passed to `OnError`, redacting it when it is sensitive, unless `ExposeSensitive` is set.
+func (options ValidationOptions) observedAttribute(attribute structs.StructAttribute) structs.StructAttribute {
+	if !options.ExposeSensitive && structs.IsSensitive(attribute, options.SensitiveTag) {
+		return structs.RedactAttribute(attribute)
+	}
+
+	return attribute
+}
+
+// Returns the path of the attribute a recovered panic was raised for (see `structs.AttributePanic`), or `_` if it is unknown.
+func recoveredPath(r any) string {
+	if p, ok := r.(*structs.AttributePanic); ok && p.Path != "" {
+		return p.Path
+	}
+
+	return "_"
+}
+
+func prefixKeys(validations map[string][]string, prefix string) map[string][]string {
+	if prefix == "" {
+		return validations
+	}
+
+	prefixed := make(map[string][]string, len(validations))
+	for k, v := range validations {
+		prefixed[prefix+k] = v
+	}
+
+	return prefixed
+}
+
+// A range of numbers written as `min..max`. Either bound can be omitted, as in `1..` or `..100`.
+type numericRange struct {
+	min, max       float64
+	hasMin, hasMax bool
+}
+
+// Parses a range written as `min..max`. An empty value results in a range containing every number.
+func parseRange(value string) (r numericRange, err error) {
+	if value == "" {
+		return r, nil
+	}
+
+	lower, upper, ok := strings.Cut(value, "..")
+	if !ok {
+		return r, errors.New("invalid range attribute")
+	}
+
+	if lower != "" {
+		if r.min, err = strconv.ParseFloat(lower, 64); err != nil {
+			return r, err
+		}
+
+		r.hasMin = true
+	}
+
+	if upper != "" {
+		if r.max, err = strconv.ParseFloat(upper, 64); err != nil {
+			return r, err
+		}
+
+		r.hasMax = true
+	}
+
+	return r, nil
+}
+
+func (r numericRange) contains(n float64) bool {
+	return (!r.hasMin || n >= r.min) && (!r.hasMax || n <= r.max)
+}
+
+// Parses a string carrying a number in the format expected by the given rule (`digits`, `intstr` or `floatstr`).
+// For `digits`, the returned number is the number of digits in the string.
+func parseNumericString(value string, rule string) (float64, bool) {
+	switch rule {
+	case DIGITS:
+		if value == "" {
+			return 0, false
+		}
+
+		for _, c := range value {
+			if c < '0' || c > '9' {
+				return 0, false
+			}
+		}
+
+		return float64(len(value)), true
+	case INTSTR:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return float64(n), err == nil
+	case FLOATSTR:
+		n, err := strconv.ParseFloat(value, 64)
+		return n, err == nil && !math.IsInf(n, 0) && !math.IsNaN(n)
+	}
+
+	return 0, false
+}
+
 func parsedLengthAttribute(value string) (length float64, err error) {
 	if value == "" {
 		return length, errors.New("required length attribute")
@@ -1,8 +1,13 @@
 package validators
 
 import (
+	"database/sql"
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/oleoneto/go-structs/structs"
 )
@@ -253,6 +258,111 @@ func Test_Validate(t *testing.T) {
 				"m": {"INVALID_VALUE"},
 			},
 		},
+		{
+			name: "key prefix - 1",
+			model: Person{
+				Identifiable: Identifiable{UUID: "2b852002-f19d-11ec-8ea0-0242ac120002"},
+				Name:         "Leonardo",
+				Contact:      Contact{Emails: []string{"leo"}},
+			},
+			options: ValidationOptions{KeyPrefix: "items[2]."},
+			want: map[string][]string{
+				"items[2].contact.emails[0]": {"INVALID_FORMAT"},
+			},
+		},
+		{
+			name: "non-inheritable tag attributes - 1",
+			model: struct {
+				Tags []string `json:"tags" validate:"min=3,in=go|rust|zig"`
+			}{Tags: []string{"go", "zig", "c"}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"tags[2]": {"INVALID_VALUE"},
+			},
+		},
+		{
+			name: "non-inheritable tag attributes - 2",
+			model: struct {
+				Tags []string `json:"tags" validate:"min=3,in=go|rust|zig"`
+			}{Tags: []string{"go", "zig", "rust"}},
+			options: ValidationOptions{NonInheritableTagAttributes: []string{}},
+			want: map[string][]string{
+				"tags[0]": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "element rules - 1",
+			model: struct {
+				Emails []string `json:"emails" validate:"min=2,each:min=8,each:email"`
+			}{Emails: []string{"leo@example.com", "a@b.io", "leo"}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"emails[1]": {"INVALID_LENGTH"},
+				"emails[2]": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "element rules - 2",
+			model: struct {
+				Emails []string `json:"emails" validate:"min=2,each:email"`
+			}{Emails: []string{"leo"}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"emails": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "element rules - 3",
+			model: struct {
+				Emails []string `json:"emails" validate:"min=1,each:email"`
+			}{Emails: []string{"leo@example.com", "leo"}},
+			options: ValidationOptions{SkipRules: []string{"email"}},
+			want:    map[string][]string{},
+		},
+		{
+			name: "invalid list - 1",
+			model: struct {
+				Items []Identifiable `json:"items" validate:"min=3"`
+				Name  string         `json:"name" validate:"min=2"`
+			}{Items: []Identifiable{{UUID: "a"}, {UUID: "b"}}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"items": {"INVALID_LENGTH"},
+				"name":  {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "before attribute - 1",
+			model: Person{
+				Identifiable: Identifiable{UUID: "2B852002-F19D-11EC-8EA0-0242AC120002"},
+				Name:         "Leonardo",
+				Contact:      Contact{Emails: []string{"leo@example.com"}},
+			},
+			options: ValidationOptions{
+				BeforeAttribute: func(attr structs.StructAttribute) structs.StructAttribute {
+					if attr.FullName() == "id" {
+						attr.Value = reflect.ValueOf(strings.ToLower(attr.Value.String()))
+					}
+
+					return attr
+				},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name:  "after validate - 1",
+			model: Person{},
+			options: ValidationOptions{
+				AfterValidate: func(m map[string][]string) map[string][]string {
+					delete(m, "id")
+					return m
+				},
+			},
+			want: map[string][]string{
+				"name":           {"INVALID_LENGTH"},
+				"contact.emails": {"INVALID_LENGTH"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -263,6 +373,120 @@ func Test_Validate(t *testing.T) {
 	}
 }
 
+func Test_Validate_Recover(t *testing.T) {
+	model := Person{
+		Identifiable: Identifiable{UUID: "2b852002-f19d-11ec-8ea0-0242ac120002"},
+		Name:         "Leonardo",
+		Contact:      Contact{Emails: []string{"leo@example.com"}},
+	}
+
+	options := ValidationOptions{
+		Recover: true,
+		BeforeAttribute: func(attr structs.StructAttribute) structs.StructAttribute {
+			if attr.FullName() == "name" {
+				var m map[string]string
+				m["name"] = attr.Value.String()
+			}
+
+			return attr
+		},
+	}
+
+	want := map[string][]string{"name": {"UNEXPECTED_ERROR"}}
+	if got := Validate(model, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	options.AfterValidate = func(m map[string][]string) map[string][]string {
+		panic("after validate")
+	}
+
+	want = map[string][]string{"_": {"UNEXPECTED_ERROR"}}
+	if got := Validate(model, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	// Panics raised by `Validatable` structs are reported under their paths
+	type Booking struct {
+		Period fragilePeriod `json:"period"`
+	}
+
+	want = map[string][]string{"booking.period": {"UNEXPECTED_ERROR"}}
+	if got := Validate(Booking{}, ValidationOptions{Recover: true, KeyPrefix: "booking."}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
+type fragilePeriod struct{}
+
+func (fragilePeriod) ValidateStruct() map[string][]string {
+	panic("boom")
+}
+
+func Test_ValidatePayload_Recover(t *testing.T) {
+	type Person struct {
+		Name string `json:"name" validate:"min=2"`
+	}
+
+	// `Recover` and `ErrorCodes` apply to both decoding and validating the payload
+	options := PayloadValidationOptions{Recover: true, ErrorCodes: map[string]string{"unexpected": "PANIC"}}
+	options.BeforeHook = func(data []byte, model any) []byte { panic("boom") }
+
+	want := map[string][]string{"_": {"PANIC"}}
+	if got := ValidatePayload([]byte(`{"name": "Leo"}`), &Person{}, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidatePayload() = %v, want %v", got, want)
+	}
+
+	options.BeforeHook = nil
+	options.BeforeAttribute = func(attribute structs.StructAttribute) structs.StructAttribute { panic("boom") }
+
+	want = map[string][]string{"name": {"PANIC"}}
+	if got := ValidatePayload([]byte(`{"name": "Leo"}`), &Person{}, options); !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidatePayload() = %v, want %v", got, want)
+	}
+}
+
+func Test_Validate_Concurrency(t *testing.T) {
+	model := Person{Name: "Leonardo", Contact: Contact{Emails: []string{"leo@example.com"}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		code := fmt.Sprint("BAD_FORMAT_", i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			options := ValidationOptions{ErrorCodes: map[string]string{"format": code}}
+			want := map[string][]string{"id": {code}}
+
+			if got := Validate(model, options); !reflect.DeepEqual(got, want) {
+				t.Errorf("Validate() = %v, want %v", got, want)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func Test_Validate_AdditionalTags(t *testing.T) {
+	type Person struct {
+		Name   string   `json:"name" binding:"min=2"`
+		Emails []string `json:"emails" validate:"min=1" binding:"max=2,email"`
+	}
+
+	model := Person{Name: "L", Emails: []string{"leo@example.com", "leo"}}
+
+	if got := Validate(model, ValidationOptions{}); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no errors", got)
+	}
+
+	want := map[string][]string{"name": {"INVALID_LENGTH"}, "emails[1]": {"INVALID_FORMAT"}}
+	if got := Validate(model, ValidationOptions{AdditionalTags: []string{"binding"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
 func Test_ValidatePayload(t *testing.T) {
 	type Person struct {
 		UUID    string  `json:"id" validate:"uuid" jsonschema:"required"`
@@ -297,7 +521,7 @@ func Test_ValidatePayload(t *testing.T) {
 				},
 			},
 			want: map[string][]string{
-				"id":             {"REQUIRED_ATTRIBUTE_MISSING"},
+				"id":             {"REQUIRED_ATTRIBUTE_MISSING", "INVALID_FORMAT"},
 				"name":           {"INVALID_LENGTH"},
 				"contact.emails": {"INVALID_LENGTH"},
 			},
@@ -337,7 +561,7 @@ func Test_ValidatePayload(t *testing.T) {
 				},
 			},
 			want: map[string][]string{
-				"name":              {"INVALID_TYPE"},
+				"name":              {"INVALID_TYPE", "INVALID_LENGTH"},
 				"contact.emails[0]": {"INVALID_FORMAT"},
 			},
 		},
@@ -379,6 +603,38 @@ func Test_ValidatePayload(t *testing.T) {
 				"related[1]": {"INVALID_FORMAT"},
 			},
 		},
+		{
+			name: "invalid model - 1",
+			args: args{
+				data:    []byte(`{"name": "Leonardo"}`),
+				model:   Person{},
+				options: PayloadValidationOptions{},
+			},
+			want: map[string][]string{
+				"_": {"INVALID_MODEL"},
+			},
+		},
+		{
+			name: "key prefix - 1",
+			args: args{
+				data:  []byte(`{"name": 1, "contact": {"emails": ["leo"]}}`),
+				model: &Person{},
+				options: PayloadValidationOptions{
+					ValidationOptions: ValidationOptions{KeyPrefix: "payload."},
+					DecoderOptions: structs.DecoderOptions{
+						Rules: []structs.SchemaValidationRule{
+							structs.INVALID_TYPE,
+							structs.REQUIRED_ATTRIBUTE,
+						},
+					},
+				},
+			},
+			want: map[string][]string{
+				"payload.id":                {"REQUIRED_ATTRIBUTE_MISSING", "INVALID_FORMAT"},
+				"payload.name":              {"INVALID_TYPE", "INVALID_LENGTH"},
+				"payload.contact.emails[0]": {"INVALID_FORMAT"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,6 +646,143 @@ func Test_ValidatePayload(t *testing.T) {
 	}
 }
 
+func Test_ValidatePayload_AtomicPopulate(t *testing.T) {
+	type Person struct {
+		UUID string `json:"id" validate:"uuid" jsonschema:"required"`
+		Name string `json:"name" validate:"min=2"`
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		want   map[string][]string
+		person Person
+	}{
+		{
+			name:   "invalid payload - model is untouched",
+			data:   []byte(`{"name": "Leonardo"}`),
+			want:   map[string][]string{"id": {"REQUIRED_ATTRIBUTE_MISSING", "INVALID_FORMAT"}},
+			person: Person{Name: "Leo"},
+		},
+		{
+			name:   "invalid values - decoded value is validated and model is untouched",
+			data:   []byte(`{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "L"}`),
+			want:   map[string][]string{"name": {"INVALID_LENGTH"}},
+			person: Person{Name: "Leo"},
+		},
+		{
+			name:   "valid payload - model is populated",
+			data:   []byte(`{"id": "2b852002-f19d-11ec-8ea0-0242ac120002", "name": "Leonardo"}`),
+			want:   map[string][]string{},
+			person: Person{UUID: "2b852002-f19d-11ec-8ea0-0242ac120002", Name: "Leonardo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person := Person{Name: "Leo"}
+
+			got := ValidatePayload(tt.data, &person, PayloadValidationOptions{
+				DecoderOptions: structs.DecoderOptions{Rules: []structs.SchemaValidationRule{structs.REQUIRED_ATTRIBUTE}, AtomicPopulate: true},
+			})
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidatePayload() = %v, want %v", got, tt.want)
+			}
+
+			if !reflect.DeepEqual(person, tt.person) {
+				t.Errorf("ValidatePayload() model = %+v, want %+v", person, tt.person)
+			}
+		})
+	}
+}
+
+func Test_ValidateFixedWidth(t *testing.T) {
+	type Payment struct {
+		Bank   string `json:"bank" fixed:"start=0,len=3" validate:"in=001|341"`
+		Amount int    `json:"amount" fixed:"start=3,len=6" validate:"min=1"`
+	}
+
+	tests := []struct {
+		name    string
+		line    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name: "payment - 1",
+			line: "341000150",
+			want: map[string][]string{},
+		},
+		{
+			name: "payment - 2",
+			line: "237000000",
+			want: map[string][]string{
+				"bank":   {"INVALID_VALUE"},
+				"amount": {"INVALID_VALUE"},
+			},
+		},
+		{
+			name:    "payment - 3",
+			line:    "341x",
+			options: ValidationOptions{KeyPrefix: "records[0]."},
+			want: map[string][]string{
+				"records[0].amount": {"INVALID_LENGTH", "INVALID_VALUE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateFixedWidth(tt.line, &Payment{}, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateFixedWidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidateFixedWidthRecords(t *testing.T) {
+	type Header struct {
+		Kind string `json:"kind" fixed:"start=0,len=1" validate:"in=H"`
+		Bank string `json:"bank" fixed:"start=1,len=3" validate:"in=001|341"`
+	}
+
+	type Detail struct {
+		Kind   string `json:"kind" fixed:"start=0,len=1"`
+		Amount int    `json:"amount" fixed:"start=1,len=6" validate:"min=1"`
+	}
+
+	file := strings.Join([]string{
+		"H237",
+		"D000150",
+		"D00x150",
+		"D000000",
+		"D00",
+	}, "\n")
+
+	newModel := func(line string) any {
+		if strings.HasPrefix(line, "H") {
+			return &Header{}
+		}
+
+		return &Detail{}
+	}
+
+	got, err := ValidateFixedWidthRecords(strings.NewReader(file), newModel, ValidationOptions{})
+	if err != nil {
+		t.Fatalf("ValidateFixedWidthRecords() error = %v", err)
+	}
+
+	want := []RecordError{
+		{Line: 1, Column: 2, Length: 3, Path: "bank", Errors: []string{"INVALID_VALUE"}},
+		{Line: 3, Column: 2, Length: 6, Path: "amount", Errors: []string{"INVALID_TYPE", "INVALID_VALUE"}},
+		{Line: 4, Column: 2, Length: 6, Path: "amount", Errors: []string{"INVALID_VALUE"}},
+		{Line: 5, Column: 2, Length: 6, Path: "amount", Errors: []string{"INVALID_LENGTH", "INVALID_VALUE"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateFixedWidthRecords() = %+v, want %+v", got, want)
+	}
+}
+
 // MARK: Rules
 
 func Test_IsIn(t *testing.T) {
@@ -608,3 +1001,967 @@ func Test_PassesRegex(t *testing.T) {
 		})
 	}
 }
+
+func Test_Validate_MaskSensitive(t *testing.T) {
+	type Account struct {
+		Email    string `json:"email" validate:"email"`
+		Password string `json:"password" validate:"min=8" sensitive:""`
+	}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string]any
+	}{
+		{
+			name:    "masked",
+			options: ValidationOptions{},
+			want:    map[string]any{"email": "leo", "password": structs.REDACTED_VALUE},
+		},
+		{
+			name:    "exposed",
+			options: ValidationOptions{ExposeSensitive: true},
+			want:    map[string]any{"email": "leo", "password": "123"},
+		},
+		{
+			name:    "custom sensitive tag",
+			options: ValidationOptions{SensitiveTag: "secret"},
+			want:    map[string]any{"email": "leo", "password": "123"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]any{}
+			tt.options.OnError = func(attribute structs.StructAttribute, errs []string) {
+				got[attribute.FullName()] = attribute.Value.Interface()
+			}
+
+			Validate(Account{Email: "leo", Password: "123"}, tt.options)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OnError() received %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsUUIDWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		options UUIDOptions
+		want    bool
+	}{
+		{
+			name:    "canonical",
+			arg:     "2bf99c42-4777-4796-9131-6cbc13d951c8",
+			options: UUIDOptions{},
+			want:    true,
+		},
+		{
+			name:    "uppercase - 1",
+			arg:     "2BF99C42-4777-4796-9131-6CBC13D951C8",
+			options: UUIDOptions{},
+			want:    false,
+		},
+		{
+			name:    "uppercase - 2",
+			arg:     "2BF99C42-4777-4796-9131-6CBC13D951C8",
+			options: UUIDOptions{Uppercase: true},
+			want:    true,
+		},
+		{
+			name:    "braces - 1",
+			arg:     "{2bf99c42-4777-4796-9131-6cbc13d951c8}",
+			options: UUIDOptions{},
+			want:    false,
+		},
+		{
+			name:    "braces - 2",
+			arg:     "{2bf99c42-4777-4796-9131-6cbc13d951c8}",
+			options: UUIDOptions{Braces: true},
+			want:    true,
+		},
+		{
+			name:    "braces - 3",
+			arg:     "{2BF99C42-4777-4796-9131-6CBC13D951C8}",
+			options: UUIDOptions{Braces: true},
+			want:    false,
+		},
+		{
+			name:    "urn - 1",
+			arg:     "urn:uuid:2bf99c42-4777-4796-9131-6cbc13d951c8",
+			options: UUIDOptions{},
+			want:    false,
+		},
+		{
+			name:    "urn - 2",
+			arg:     "URN:UUID:2bf99c42-4777-4796-9131-6cbc13d951c8",
+			options: UUIDOptions{URN: true},
+			want:    true,
+		},
+		{
+			name:    "no hyphens",
+			arg:     "2bf99c424777479691316cbc13d951c8",
+			options: UUIDOptions{Uppercase: true, Braces: true, URN: true},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUUIDWithOptions(tt.arg, tt.options); got != tt.want {
+				t.Errorf("IsUUIDWithOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_NumericStrings(t *testing.T) {
+	type Order struct {
+		Pin      string   `json:"pin" validate:"digits=4..6"`
+		Quantity string   `json:"quantity" validate:"intstr=1..100"`
+		Amount   string   `json:"amount" validate:"floatstr=0.."`
+		Codes    []string `json:"codes" validate:"digits"`
+		Count    int      `json:"count" validate:"intstr"`
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Order{Pin: "0123", Quantity: "100", Amount: "12.5", Codes: []string{"007"}, Count: 1},
+			want:  map[string][]string{"count": {"INVALID_TYPE"}},
+		},
+		{
+			name:  "invalid format",
+			model: Order{Pin: "12a4", Quantity: "1.5", Amount: "NaN", Codes: []string{"", "-1"}},
+			want: map[string][]string{
+				"pin":      {"INVALID_FORMAT"},
+				"quantity": {"INVALID_FORMAT"},
+				"amount":   {"INVALID_FORMAT"},
+				"codes[0]": {"INVALID_FORMAT"},
+				"codes[1]": {"INVALID_FORMAT"},
+				"count":    {"INVALID_TYPE"},
+			},
+		},
+		{
+			name:  "out of range",
+			model: Order{Pin: "123", Quantity: "101", Amount: "-0.1"},
+			want: map[string][]string{
+				"pin":      {"INVALID_LENGTH"},
+				"quantity": {"INVALID_VALUE"},
+				"amount":   {"INVALID_VALUE"},
+				"count":    {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_RangeShorthand(t *testing.T) {
+	type Product struct {
+		Name  string   `json:"name" validate:"len=2..5"`
+		Code  string   `json:"code" validate:"len=3"`
+		Price float64  `json:"price" validate:"range=1..100"`
+		Stock int      `json:"stock" validate:"between=0.."`
+		Tags  []string `json:"tags" validate:"len=..2,each:len=2.."`
+	}
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Product{Name: "Pen", Code: "ABC", Price: 1, Stock: 0, Tags: []string{"ab"}},
+			options: ValidationOptions{RuleAliases: map[string]string{"between": RANGE}},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "invalid",
+			model:   Product{Name: "Pencil", Code: "AB", Price: 100.5, Stock: -1, Tags: []string{"ab", "c"}},
+			options: ValidationOptions{RuleAliases: map[string]string{"between": RANGE}},
+			want: map[string][]string{
+				"name":    {"INVALID_LENGTH"},
+				"code":    {"INVALID_LENGTH"},
+				"price":   {"INVALID_VALUE"},
+				"stock":   {"INVALID_VALUE"},
+				"tags[1]": {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name:    "skipped",
+			model:   Product{Name: "Pencil", Code: "AB", Price: 100.5, Stock: -1, Tags: []string{"ab", "cd", "ef"}},
+			options: ValidationOptions{RuleAliases: map[string]string{"between": RANGE}, SkipRules: []string{LENGTH, RANGE}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_ValueProviders(t *testing.T) {
+	type Price struct {
+		Currency  string   `json:"currency" validate:"in=@currencies"`
+		Regions   []string `json:"regions" validate:"in=@regions"`
+		Warehouse int      `json:"warehouse" validate:"in=@warehouses"`
+	}
+
+	calls := 0
+	ValueProviders["currencies"] = func() []string {
+		calls++
+		return []string{"USD", "EUR"}
+	}
+
+	defer delete(ValueProviders, "currencies")
+
+	options := ValidationOptions{
+		ValueProviders: map[string]func() []string{
+			"regions": func() []string { return []string{"US", "EU"} },
+		},
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Price{Currency: "EUR", Regions: []string{"US", "EU"}},
+			want:  map[string][]string{"warehouse": {"UNEXPECTED_ERROR"}},
+		},
+		{
+			name:  "invalid",
+			model: Price{Currency: "BRL", Regions: []string{"BR"}},
+			want: map[string][]string{
+				"currency":   {"INVALID_VALUE"},
+				"regions[0]": {"INVALID_VALUE"},
+				"warehouse":  {"UNEXPECTED_ERROR"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if calls != len(tests) {
+		t.Errorf("ValueProviders[currencies] was called %d times, want %d", calls, len(tests))
+	}
+}
+
+func Test_Validate_URL(t *testing.T) {
+	type Webhook struct {
+		Website  string   `json:"website" validate:"url"`
+		Callback *string  `json:"callback" validate:"url=https"`
+		Mirrors  []string `json:"mirrors" validate:"url=https|http"`
+		Port     int      `json:"port" validate:"url"`
+	}
+
+	secure, insecure := "https://example.com/hook", "http://example.com/hook"
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Webhook{Website: "ftp://files.example.com", Callback: &secure, Mirrors: []string{"HTTP://example.org"}},
+			want:  map[string][]string{"port": {"INVALID_TYPE"}},
+		},
+		{
+			name:  "invalid",
+			model: Webhook{Website: "example.com", Callback: &insecure, Mirrors: []string{"https://example.org", "ftp://example.org", "/path"}},
+			want: map[string][]string{
+				"website":    {"INVALID_FORMAT"},
+				"callback":   {"INVALID_FORMAT"},
+				"mirrors[1]": {"INVALID_FORMAT"},
+				"mirrors[2]": {"INVALID_FORMAT"},
+				"port":       {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsURL(t *testing.T) {
+	tests := []struct {
+		value   string
+		schemes []string
+		want    bool
+	}{
+		{value: "https://example.com", want: true},
+		{value: "mailto:leo@example.com", want: false},
+		{value: "example.com", want: false},
+		{value: "", want: false},
+		{value: "https://example.com", schemes: []string{"http", "https"}, want: true},
+		{value: "ftp://example.com", schemes: []string{"http", "https"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := IsURL(tt.value, tt.schemes...); got != tt.want {
+			t.Errorf("IsURL(%q, %v) = %v, want %v", tt.value, tt.schemes, got, tt.want)
+		}
+	}
+}
+
+func Test_Validate_Regex(t *testing.T) {
+	type Contact struct {
+		Phone  string   `json:"phone" validate:"regex(^\\d{3}-\\d{4}$)"`
+		Phones []string `json:"phones" validate:"regex(^\\d{3}-\\d{4}$),max=2"`
+		Code   *string  `json:"code" validate:"regex(^[A-Z]{2,3}=\\d+$)"`
+		Age    int      `json:"age" validate:"regex(^\\d+$)"`
+	}
+
+	valid, invalid := "AB=12", "AB12"
+
+	tests := []struct {
+		name    string
+		model   any
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Contact{Phone: "555-1234", Phones: []string{"555-0000", "555-9999"}, Code: &valid},
+			want:  map[string][]string{"age": {"INVALID_TYPE"}},
+		},
+		{
+			name:  "invalid",
+			model: Contact{Phone: "5551234", Phones: []string{"555-0000", "55-00000"}, Code: &invalid},
+			want: map[string][]string{
+				"phone":     {"INVALID_FORMAT"},
+				"phones[1]": {"INVALID_FORMAT"},
+				"code":      {"INVALID_FORMAT"},
+				"age":       {"INVALID_TYPE"},
+			},
+		},
+		{
+			name:  "max is not inherited by the elements",
+			model: Contact{Phone: "555-1234", Phones: []string{"555-0000", "555-0001", "555-0002"}, Code: &valid},
+			want:  map[string][]string{"phones": {"INVALID_LENGTH"}, "age": {"INVALID_TYPE"}},
+		},
+		{
+			name:    "skipped",
+			model:   Contact{Phone: "5551234", Code: &invalid},
+			options: ValidationOptions{SkipRules: []string{REGEX}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Only the patterns of the rules are cached
+	if _, ok := patternsCache.Load(`^\d{3}-\d{4}$`); !ok {
+		t.Errorf("the pattern of the regex rule was not cached")
+	}
+
+	PassesRegex(`^passes-regex$`, "passes-regex")
+	if _, ok := patternsCache.Load(`^passes-regex$`); ok {
+		t.Errorf("PassesRegex() cached its pattern")
+	}
+}
+
+func Test_Validate_UnexportedFields(t *testing.T) {
+	type Event struct {
+		sync.Mutex
+		Name      string    `json:"name" validate:"min=2"`
+		StartsAt  time.Time `json:"starts_at"`
+		timezone  string    `validate:"min=3"`
+		attendees []string  `validate:"email"`
+	}
+
+	event := &Event{Name: "Go", StartsAt: time.Now(), timezone: "X", attendees: []string{"leo"}}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name: "skipped by default",
+			want: map[string][]string{},
+		},
+		{
+			name:    "allowed",
+			options: ValidationOptions{UnexportedFields: []string{"timezone", "attendees"}},
+			want:    map[string][]string{"timezone": {"INVALID_LENGTH"}, "attendees[0]": {"INVALID_FORMAT"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(event, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_NestedLists(t *testing.T) {
+	type Point struct {
+		X int `json:"x" validate:"min=0"`
+	}
+
+	type Shape struct {
+		Grid  [][]float64 `json:"grid" validate:"min=1,each:max=9"`
+		Tags  [][]string  `json:"tags" validate:"email"`
+		Paths [][]Point   `json:"paths"`
+	}
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Shape{Grid: [][]float64{{1, 2}, {9}}, Tags: [][]string{{"leo@example.com"}}, Paths: [][]Point{{{X: 1}}}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid",
+			model: Shape{Grid: [][]float64{{1, 2}, {9, 10}}, Tags: [][]string{{}, {"leo@example.com", "leo"}}, Paths: [][]Point{{{X: 1}}, {{X: 1}, {X: -1}}}},
+			want: map[string][]string{
+				"grid[1][1]":    {"INVALID_VALUE"},
+				"tags[1][1]":    {"INVALID_FORMAT"},
+				"paths[1][1].x": {"INVALID_VALUE"},
+			},
+		},
+		{
+			name:  "outer rules",
+			model: Shape{Grid: [][]float64{}},
+			want:  map[string][]string{"grid": {"INVALID_LENGTH"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Pointers(t *testing.T) {
+	type Author struct {
+		Id string `json:"id" validate:"uuid"`
+	}
+
+	type Book struct {
+		Authors *[]*Author `json:"authors" validate:"min=1"`
+		Emails  []*string  `json:"emails" validate:"email"`
+		Scores  *[][]*int  `json:"scores" validate:"each:max=10"`
+	}
+
+	valid, invalid, high := "leo@example.com", "leo", 11
+
+	tests := []struct {
+		name  string
+		model any
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: &Book{Authors: &[]*Author{{Id: "2b852002-f19d-11ec-8ea0-0242ac120002"}}, Emails: []*string{&valid}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid",
+			model: &Book{Authors: &[]*Author{{Id: "1"}}, Emails: []*string{&valid, &invalid}, Scores: &[][]*int{{&high}}},
+			want: map[string][]string{
+				"authors[0].id": {"INVALID_FORMAT"},
+				"emails[1]":     {"INVALID_FORMAT"},
+				"scores[0][0]":  {"INVALID_VALUE"},
+			},
+		},
+		{
+			name:  "empty",
+			model: &Book{Authors: &[]*Author{}},
+			want:  map[string][]string{"authors": {"INVALID_LENGTH"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Required(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"notblank"`
+	}
+
+	type Person struct {
+		Name    string    `json:"name" validate:"required"`
+		Age     int       `json:"age" validate:"required"`
+		Nick    *string   `json:"nick" validate:"notblank"`
+		Roles   []string  `json:"roles" validate:"required,notblank"`
+		Address *Address  `json:"address" validate:"required"`
+		Tags    []*string `json:"tags" validate:"each:required"`
+	}
+
+	nick, blank, zero := "leo", "  ", ""
+
+	tests := []struct {
+		name    string
+		model   Person
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "valid",
+			model:   Person{Name: "Leo", Age: 30, Nick: &nick, Roles: []string{"admin"}, Address: &Address{Street: "Main St"}, Tags: []*string{&zero}},
+			options: ValidationOptions{},
+			want:    map[string][]string{},
+		},
+		{
+			name:    "missing",
+			model:   Person{Roles: []string{}, Tags: []*string{nil}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"name":    {"REQUIRED_ATTRIBUTE_MISSING"},
+				"age":     {"REQUIRED_ATTRIBUTE_MISSING"},
+				"nick":    {"REQUIRED_ATTRIBUTE_MISSING"},
+				"roles":   {"REQUIRED_ATTRIBUTE_MISSING"},
+				"address": {"REQUIRED_ATTRIBUTE_MISSING"},
+				"tags[0]": {"REQUIRED_ATTRIBUTE_MISSING"},
+			},
+		},
+		{
+			name:    "blank",
+			model:   Person{Name: "Leo", Age: 30, Nick: &blank, Roles: []string{"admin", " "}, Address: &Address{Street: "\t"}},
+			options: ValidationOptions{},
+			want: map[string][]string{
+				"nick":           {"REQUIRED_ATTRIBUTE_MISSING"},
+				"roles[1]":       {"REQUIRED_ATTRIBUTE_MISSING"},
+				"address.street": {"REQUIRED_ATTRIBUTE_MISSING"},
+			},
+		},
+		{
+			name:    "present in payload",
+			model:   Person{Roles: []string{"admin"}},
+			options: ValidationOptions{Present: []string{"name", "age", "roles", "address"}, SkipRules: []string{NOTBLANK}},
+			want:    map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_Maps(t *testing.T) {
+	type Limit struct {
+		Max int `json:"max" validate:"min=1"`
+	}
+
+	type Service struct {
+		Contacts map[string]string   `json:"contacts" validate:"max=2,each:email"`
+		Limits   map[string]Limit    `json:"limits"`
+		Aliases  map[string][]string `json:"aliases" validate:"each:min=2"`
+	}
+
+	tests := []struct {
+		name  string
+		model Service
+		want  map[string][]string
+	}{
+		{
+			name: "valid",
+			model: Service{
+				Contacts: map[string]string{"admin": "admin@example.com"},
+				Limits:   map[string]Limit{"cpu": {Max: 2}},
+				Aliases:  map[string][]string{"web": {"www", "site"}},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name: "invalid values",
+			model: Service{
+				Contacts: map[string]string{"admin": "admin@example.com", "support": "support"},
+				Limits:   map[string]Limit{"cpu": {Max: 0}, "memory": {Max: 512}},
+				Aliases:  map[string][]string{"web": {"www", "w"}},
+			},
+			want: map[string][]string{
+				"contacts.support": {"INVALID_FORMAT"},
+				"limits.cpu.max":   {"INVALID_VALUE"},
+				"aliases.web[1]":   {"INVALID_LENGTH"},
+			},
+		},
+		{
+			name: "entries of an invalid map are not validated",
+			model: Service{
+				Contacts: map[string]string{"a": "a", "b": "b", "c": "c"},
+			},
+			want: map[string][]string{
+				"contacts": {"INVALID_LENGTH"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_NullableWrappers(t *testing.T) {
+	type Profile struct {
+		Name    sql.NullString   `json:"name" validate:"required"`
+		Email   sql.NullString   `json:"email" validate:"email"`
+		Age     sql.NullInt64    `json:"age" validate:"min=18"`
+		Aliases []sql.NullString `json:"aliases" validate:"each:min=2"`
+	}
+
+	tests := []struct {
+		name  string
+		model Profile
+		want  map[string][]string
+	}{
+		{
+			name: "valid",
+			model: Profile{
+				Name:    sql.NullString{Valid: true},
+				Email:   sql.NullString{String: "leo@example.com", Valid: true},
+				Age:     sql.NullInt64{Int64: 30, Valid: true},
+				Aliases: []sql.NullString{{String: "leo", Valid: true}},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name: "invalid values",
+			model: Profile{
+				Name:    sql.NullString{String: "Leo"},
+				Email:   sql.NullString{String: "leo", Valid: true},
+				Age:     sql.NullInt64{Int64: 16, Valid: true},
+				Aliases: []sql.NullString{{String: "leo", Valid: true}, {String: "l", Valid: true}},
+			},
+			want: map[string][]string{
+				"name":       {"REQUIRED_ATTRIBUTE_MISSING"},
+				"email":      {"INVALID_FORMAT"},
+				"age":        {"INVALID_VALUE"},
+				"aliases[1]": {"INVALID_LENGTH"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type optionalString struct {
+	value string
+	set   bool
+}
+
+func (o optionalString) IsNull() bool { return !o.set }
+func (o optionalString) Value() any   { return o.value }
+
+func Test_Validate_Nullable(t *testing.T) {
+	type Profile struct {
+		Name  optionalString `json:"name" validate:"required"`
+		Email optionalString `json:"email" validate:"email"`
+	}
+
+	tests := []struct {
+		name  string
+		model Profile
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Profile{Name: optionalString{set: true}, Email: optionalString{value: "leo@example.com", set: true}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid values",
+			model: Profile{Name: optionalString{value: "Leo"}, Email: optionalString{value: "leo", set: true}},
+			want: map[string][]string{
+				"name":  {"REQUIRED_ATTRIBUTE_MISSING"},
+				"email": {"INVALID_FORMAT"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_NetworkAddresses(t *testing.T) {
+	type Host struct {
+		Address  string   `json:"address" validate:"ip"`
+		Public   *string  `json:"public" validate:"ipv4"`
+		Local    string   `json:"local" validate:"ipv6"`
+		Network  string   `json:"network" validate:"cidr"`
+		Hardware string   `json:"hardware" validate:"mac"`
+		Peers    []string `json:"peers" validate:"ipv4"`
+		Port     int      `json:"port" validate:"ip"`
+	}
+
+	public := "192.0.2.1"
+
+	tests := []struct {
+		name  string
+		model Host
+		want  map[string][]string
+	}{
+		{
+			name: "valid",
+			model: Host{
+				Address:  "2001:db8::1",
+				Public:   &public,
+				Local:    "::ffff:192.0.2.1",
+				Network:  "2001:db8::/32",
+				Hardware: "00-00-5E-00-53-01",
+				Peers:    []string{"192.0.2.2", "198.51.100.7"},
+			},
+			want: map[string][]string{"port": {"INVALID_TYPE"}},
+		},
+		{
+			name: "invalid",
+			model: Host{
+				Address:  "256.0.0.1",
+				Local:    public,
+				Network:  public,
+				Hardware: "00:00:5e:00:53",
+				Peers:    []string{"192.0.2.2", "2001:db8::2"},
+			},
+			want: map[string][]string{
+				"address":  {"INVALID_FORMAT"},
+				"public":   {"INVALID_FORMAT"},
+				"local":    {"INVALID_FORMAT"},
+				"network":  {"INVALID_FORMAT"},
+				"hardware": {"INVALID_FORMAT"},
+				"peers[1]": {"INVALID_FORMAT"},
+				"port":     {"INVALID_TYPE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Validate_StrictRules(t *testing.T) {
+	type Account struct {
+		Email   string   `json:"email" validate:"emial"`
+		Backup  string   `json:"backup" validate:"is_email"`
+		Name    *string  `json:"name" validate:"required,lenght=3"`
+		Code    string   `json:"code" validate:"regex(^[a-z]+$),required_with=name"`
+		Aliases []string `json:"aliases" validate:"each:emial"`
+	}
+
+	account := Account{Email: "a@b.co", Backup: "a@b.co", Code: "abc", Aliases: []string{"x"}}
+	aliases := map[string]string{"is_email": EMAIL}
+
+	tests := []struct {
+		name    string
+		options ValidationOptions
+		want    map[string][]string
+	}{
+		{
+			name:    "unknown rules are ignored by default",
+			options: ValidationOptions{RuleAliases: aliases},
+			want:    map[string][]string{"name": {"REQUIRED_ATTRIBUTE_MISSING"}},
+		},
+		{
+			name:    "strict",
+			options: ValidationOptions{RuleAliases: aliases, StrictRules: true},
+			want: map[string][]string{
+				"email":      {"UNKNOWN_RULE"},
+				"name":       {"REQUIRED_ATTRIBUTE_MISSING"},
+				"aliases[0]": {"UNKNOWN_RULE"},
+			},
+		},
+		{
+			name:    "strict without aliases",
+			options: ValidationOptions{StrictRules: true, SkipRules: []string{"emial"}},
+			want: map[string][]string{
+				"backup": {"UNKNOWN_RULE"},
+				"name":   {"REQUIRED_ATTRIBUTE_MISSING"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(account, tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	result := ValidateResult(account, ValidationOptions{StrictRules: true, Locale: "en", SkipRules: []string{REQUIRED}})
+	for _, err := range result {
+		if err.Path == "email" && err.Message != "has an unknown validation rule: emial" {
+			t.Errorf("ValidateResult() message = %q, want %q", err.Message, "has an unknown validation rule: emial")
+		}
+	}
+}
+
+func Test_Validate_QuotedRuleValues(t *testing.T) {
+	type Shipment struct {
+		City    string   `json:"city" validate:"in='Washington, D.C.|Lisbon'"`
+		Region  string   `json:"region" validate:"in=North\\, East|South"`
+		Code    string   `json:"code" validate:"regex='^[A-Z]{2,3}$'"`
+		Carrier string   `json:"carrier" validate:"in='O\\'Hare|Leo\\'s',min=3"`
+		Tags    []string `json:"tags" validate:"each:in='a,b|c'"`
+	}
+
+	tests := []struct {
+		name  string
+		model Shipment
+		want  map[string][]string
+	}{
+		{
+			name:  "valid",
+			model: Shipment{City: "Washington, D.C.", Region: "North, East", Code: "ABC", Carrier: "O'Hare", Tags: []string{"a,b", "c"}},
+			want:  map[string][]string{},
+		},
+		{
+			name:  "invalid",
+			model: Shipment{City: "Washington", Region: "North", Code: "A", Carrier: "Leo", Tags: []string{"a"}},
+			want: map[string][]string{
+				"city":    {"INVALID_VALUE"},
+				"region":  {"INVALID_VALUE"},
+				"code":    {"INVALID_FORMAT"},
+				"carrier": {"INVALID_VALUE"},
+				"tags[0]": {"INVALID_VALUE"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.model, ValidationOptions{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NewValidator(t *testing.T) {
+	type Person struct {
+		Email string `json:"email" validate:"email"`
+		Role  string `json:"role" validate:"in=@roles"`
+	}
+
+	errorCodes := map[string]string{"format": "BAD_FORMAT"}
+	strict := NewValidator(ValidationOptions{
+		ErrorCodes:     errorCodes,
+		ValueProviders: map[string]func() []string{"roles": func() []string { return []string{"ADMIN"} }},
+	})
+	lenient := NewValidator(ValidationOptions{
+		ValueProviders: map[string]func() []string{"roles": func() []string { return []string{"ADMIN", "GUEST"} }},
+	})
+
+	// Changes made to the options and defaults after the validators are created do not affect them
+	errorCodes["format"] = "CHANGED"
+	Errors["format"] = "CHANGED"
+	defer func() { Errors["format"] = "INVALID_FORMAT" }()
+
+	person := Person{Email: "leo", Role: "GUEST"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if got, want := strict.Validate(person), map[string][]string{"email": {"BAD_FORMAT"}, "role": {"INVALID_VALUE"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Validator.Validate() = %v, want %v", got, want)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if got, want := lenient.Validate(person), map[string][]string{"email": {"INVALID_FORMAT"}}; !reflect.DeepEqual(got, want) {
+				t.Errorf("Validator.Validate() = %v, want %v", got, want)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := strict.Options().ErrorCodes["required"]; got != "REQUIRED_ATTRIBUTE_MISSING" {
+		t.Errorf("Validator.Options() error code = %v, want %v", got, "REQUIRED_ATTRIBUTE_MISSING")
+	}
+
+	// Aliases and providers registered after the validators are created are not used by them either
+	type Member struct {
+		Email string `json:"email" validate:"mail"`
+		Team  string `json:"team" validate:"in=@teams"`
+	}
+
+	RuleAliases["mail"] = "email"
+	ValueProviders["teams"] = func() []string { return []string{"core"} }
+	defer func() {
+		delete(RuleAliases, "mail")
+		delete(ValueProviders, "teams")
+	}()
+
+	member := Member{Email: "leo", Team: "core"}
+	if got, want := lenient.Validate(member), map[string][]string{"team": {"UNEXPECTED_ERROR"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Validator.Validate() = %v, want %v", got, want)
+	}
+	if got, want := Validate(member, ValidationOptions{}), map[string][]string{"email": {Errors["format"]}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	decoder := structs.NewJSONDecoder(structs.DecoderOptions{Rules: []structs.SchemaValidationRule{structs.REQUIRED_ATTRIBUTE}})
+	want := map[string][]string{"email": {"BAD_FORMAT"}}
+	if got := strict.ValidatePayload([]byte(`{"email": "leo", "role": "ADMIN"}`), &Person{}, decoder); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validator.ValidatePayload() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package validators
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/oleoneto/go-structs/collections"
+)
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// Validates a single value (i.e. a path parameter) against a list of comma-separated rules, written as in a validation tag.
+// Returns the error codes found, or an empty list if the value is valid.
+//
+// The elements of slices/arrays and the entries of maps are validated as the ones of struct fields,
+// and their errors are returned along with the ones of the value itself, without repetitions.
+// Rules comparing fields (i.e. `eqfield`) are of no use for standalone values.
+//
+// Usage:
+//
+//	ValidateValue("2b852002-f19d-11ec-8ea0-0242ac120002", "uuid") // -> []
+//	ValidateValue("abc", "required,uuid")                          // -> ["INVALID_FORMAT"]
+//	ValidateValue([]string{"a@b.co", "?"}, "min=1,email")          // -> ["INVALID_FORMAT"]
+func ValidateValue(value any, rules string) []string {
+	return ValidateValueWithOptions(value, rules, ValidationOptions{})
+}
+
+// Validates a single value like `ValidateValue`, using the given options (i.e. `ErrorCodes` or `RuleAliases`).
+// Paths are meaningless for standalone values, so `KeyPrefix` and `AfterValidate` are not used.
+//
+// Usage:
+//
+//	ValidateValueWithOptions("abc", "uuid", ValidationOptions{ErrorCodes: map[string]string{"format": "BAD_FORMAT"}})
+//	// -> ["BAD_FORMAT"]
+func ValidateValueWithOptions(value any, rules string, options ValidationOptions) []string {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		t = anyType
+	}
+
+	// The value is validated as the only field of a struct, so that elements and entries are walked as usual
+	model := reflect.New(reflect.StructOf([]reflect.StructField{{
+		Name: "Value",
+		Type: t,
+		Tag:  reflect.StructTag(`json:"value" ` + VALIDATION_TAG_KEYWORD + `:` + strconv.Quote(rules)),
+	}})).Elem()
+
+	if value != nil {
+		model.Field(0).Set(reflect.ValueOf(value))
+	}
+
+	options.KeyPrefix = ""
+
+	errs := []string{}
+	for _, err := range validate(model.Interface(), options) {
+		errs = append(errs, err.Code)
+	}
+
+	return collections.Uniq(errs)
+}
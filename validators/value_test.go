@@ -0,0 +1,45 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		rules string
+		want  []string
+	}{
+		{name: "valid uuid", value: "2b852002-f19d-11ec-8ea0-0242ac120002", rules: "uuid", want: []string{}},
+		{name: "invalid uuid", value: "abc", rules: "required,uuid", want: []string{"INVALID_FORMAT"}},
+		{name: "missing", value: "", rules: "required,uuid", want: []string{"REQUIRED_ATTRIBUTE_MISSING"}},
+		{name: "number", value: 17, rules: "min=18", want: []string{"INVALID_VALUE"}},
+		{name: "pattern", value: "12-34", rules: `regex(^\d{2}-\d{2}$)`, want: []string{}},
+		{name: "elements", value: []string{"a@b.co", "?", "!"}, rules: "min=1,email", want: []string{"INVALID_FORMAT"}},
+		{name: "empty list", value: []string{}, rules: "min=1,email", want: []string{"INVALID_LENGTH"}},
+		{name: "nil pointer", value: (*string)(nil), rules: "required", want: []string{"REQUIRED_ATTRIBUTE_MISSING"}},
+		{name: "nil", value: nil, rules: "required", want: []string{"REQUIRED_ATTRIBUTE_MISSING"}},
+		{name: "no rules", value: "abc", rules: "", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidateValue(tt.value, tt.rules); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidateValueWithOptions(t *testing.T) {
+	options := ValidationOptions{
+		ErrorCodes:  map[string]string{"format": "BAD_FORMAT"},
+		RuleAliases: map[string]string{"is_uuid": UUID},
+		KeyPrefix:   "params.",
+	}
+
+	if got := ValidateValueWithOptions("abc", "is_uuid", options); !reflect.DeepEqual(got, []string{"BAD_FORMAT"}) {
+		t.Errorf("ValidateValueWithOptions() = %v, want %v", got, []string{"BAD_FORMAT"})
+	}
+}